@@ -0,0 +1,17 @@
+data "turbonomic_compliance_report" "example" {
+  items = [
+    for name, instance in aws_instance.web : {
+      resource_address = "aws_instance.web[\"${name}\"]"
+      vendor_id        = instance.id
+      attribute        = "instance_type"
+      configured_value = instance.instance_type
+    }
+  ]
+}
+
+check "turbonomic_compliance_check" {
+  assert {
+    condition     = data.turbonomic_compliance_report.example.summary.recommendation_pending == 0
+    error_message = join("\n", [for item in data.turbonomic_compliance_report.example.items : item.message if item.status == "recommendation_pending"])
+  }
+}
@@ -0,0 +1,11 @@
+data "turbonomic_entities" "example" {
+  entity_type      = "VirtualMachine"
+  environment_type = "CLOUD"
+  cloud_type       = "AWS"
+  name_regex       = "<name_regex>"
+  tag_filters      = { "<tag_key>" = "<tag_value>" }
+}
+
+output "entity_uuids" {
+  value = { for entity in data.turbonomic_entities.example.entities : entity.display_name => entity.uuid }
+}
@@ -0,0 +1,8 @@
+data "turbonomic_entity" "example" {
+  entity_name = "<entity_name>"
+  entity_type = "VirtualMachine"
+}
+
+output "attached_volumes" {
+  value = [for provider in data.turbonomic_entity.example.providers : provider.display_name if provider.class_name == "VirtualVolume"]
+}
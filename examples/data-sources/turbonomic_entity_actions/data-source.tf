@@ -5,3 +5,12 @@ data "turbonomic_entity_actions" "testing" {
   environment_type = "<environment_type>"
   states           = ["<states>"]
 }
+
+data "turbonomic_entity_actions" "tagged" {
+  entity_type = "VirtualMachine"
+  name_regex  = "^payments-"
+  tag_filters = { team = "payments" }
+  account_id  = "<account_id>"
+  region      = "<region>"
+  all_matches = true
+}
@@ -0,0 +1,10 @@
+data "turbonomic_entity_stats" "example" {
+  entity_uuid = "<entity_uuid>"
+  commodities = ["VCPU", "VMem"]
+  start_date  = "-7d"
+  aggregation = "p95"
+}
+
+output "vcpu_utilization" {
+  value = one([for stat in data.turbonomic_entity_stats.example.stats : stat.utilization if stat.name == "VCPU"])
+}
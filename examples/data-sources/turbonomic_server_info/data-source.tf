@@ -0,0 +1,5 @@
+data "turbonomic_server_info" "example" {}
+
+output "turbonomic_version" {
+  value = data.turbonomic_server_info.example.version
+}
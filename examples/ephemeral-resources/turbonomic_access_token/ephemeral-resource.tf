@@ -0,0 +1,10 @@
+ephemeral "turbonomic_access_token" "observer" {
+  role = "OBSERVER"
+}
+
+provider "restapi" {
+  uri = "https://${var.hostname}/api/v3"
+  headers = {
+    Authorization = "Bearer ${ephemeral.turbonomic_access_token.observer.access_token}"
+  }
+}
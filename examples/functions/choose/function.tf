@@ -0,0 +1,10 @@
+#result : "m5.xlarge" as upsize_only rejects the downsize to m5.large
+
+output "instance_type" {
+  value = provider::turbonomic::choose(
+    data.turbonomic_aws_instance.example.new_instance_type,     # "m5.large"
+    data.turbonomic_aws_instance.example.current_instance_type, # "m5.xlarge"
+    "t3.nano",
+    "upsize_only"
+  )
+}
@@ -0,0 +1,5 @@
+#result : 1
+
+output "is_upsize" {
+  value = provider::turbonomic::compare_instance_types("aws", "m5.2xlarge", "m5.xlarge") > 0
+}
@@ -0,0 +1,11 @@
+#result : 125
+
+output "throughput_mibps" {
+  value = provider::turbonomic::convert_units(1024000, "Kbit/sec", "MiB/s")
+}
+
+#result : 2
+
+output "vcpu_count" {
+  value = provider::turbonomic::convert_units(5200, "MHz", "vCPU", 2600)
+}
@@ -0,0 +1,25 @@
+#result : {turbonomic_optimized_by = "turbonomic-terraform-provider"}
+
+output "turbonomic_tag" {
+  value = provider::turbonomic::get_tags()
+}
+
+#result : {turbonomic_optimized_by = "turbonomic-terraform-provider", turbonomic_action_id = "638861526930578",
+#          turbonomic_workspace = "prod"}
+
+#result : {managed_by = "platform-terraform", cost_center = "1234"}
+
+output "turbonomic_provider_tag" {
+  value = provider::turbonomic::get_tags({
+    tag_key    = "managed_by"
+    tag_value  = "platform-terraform"
+    extra_tags = "cost_center=1234"
+  })
+}
+
+output "turbonomic_provenance_tag" {
+  value = provider::turbonomic::get_tags({
+    action_id = data.turbonomic_aws_instance.example.action_id
+    workspace = terraform.workspace
+  })
+}
@@ -0,0 +1,5 @@
+#result : "Ds_v3"
+
+output "vm_family" {
+  value = provider::turbonomic::instance_type_family("azure", "Standard_D4s_v3")
+}
@@ -0,0 +1,5 @@
+#result : "Managed Premium SSD"
+
+output "disk_tier" {
+  value = provider::turbonomic::sku_to_tier("azure", "disk", "Premium_LRS")
+}
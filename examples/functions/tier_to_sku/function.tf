@@ -0,0 +1,5 @@
+#result : "pd-balanced"
+
+output "disk_type" {
+  value = provider::turbonomic::tier_to_sku("gcp", "disk", "Balanced Persistent Disk")
+}
@@ -0,0 +1,259 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// accessTokenPath is the Turbonomic endpoint that issues OAuth 2.0 access tokens
+	accessTokenPath     = "/oauth2/token"
+	accessTokenAudience = "turbonomic"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &accessTokenEphemeralResource{}
+)
+
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenEphemeralResource{}
+}
+
+// accessTokenEphemeralResource defines the ephemeral resource implementation.
+type accessTokenEphemeralResource struct {
+	providerData *TurbonomicProviderData
+}
+
+// AccessTokenModel describes the ephemeral resource data model.
+type AccessTokenModel struct {
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Role         types.String `tfsdk:"role"`
+	AccessToken  types.String `tfsdk:"access_token"`
+	TokenType    types.String `tfsdk:"token_type"`
+	Scope        types.String `tfsdk:"scope"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+}
+
+// accessTokenResponse is the response of the Turbonomic token endpoint
+type accessTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// accessTokenRejectedError is returned when the token endpoint rejects the access token request
+type accessTokenRejectedError struct {
+	ClientId   string
+	StatusCode int
+	Body       string
+}
+
+func (e *accessTokenRejectedError) Error() string {
+	return fmt.Sprintf("turbonomic rejected the access token request for client %s with status %d: %s", e.ClientId, e.StatusCode, e.Body)
+}
+
+func (r *accessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *accessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The following example demonstrates the syntax for the `turbonomic_access_token` ephemeral resource. " +
+			"This can be used to mint a short-lived OAuth 2.0 access token for scripts and other providers that call Turbonomic, " +
+			"the token is never stored in the plan or state",
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "the OAuth 2.0 client ID; defaults to the client_id of the provider",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "the OAuth 2.0 client secret; defaults to the client_secret of the provider",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "the OAuth 2.0 role the token is scoped to, such as OBSERVER; defaults to the role of the provider",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(validRoles...),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "the OAuth 2.0 access token",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token_type": schema.StringAttribute{
+				MarkdownDescription: "type of the access token, E.G: Bearer",
+				Computed:            true,
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "scope of the access token, E.G: role:OBSERVER",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "expiration time of the access token in RFC3339 format",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *accessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected: *TurbonomicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *accessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var state AccessTokenModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.providerData == nil {
+		resp.Diagnostics.AddError("unable to create an access token",
+			"the provider has not been configured with a Turbonomic hostname")
+		return
+	}
+
+	oauth := r.providerData.OAuth
+	if !state.ClientId.IsNull() {
+		oauth.ClientId = state.ClientId.ValueString()
+	}
+	if !state.ClientSecret.IsNull() {
+		oauth.ClientSecret = state.ClientSecret.ValueString()
+	}
+	if !state.Role.IsNull() {
+		oauth.Role = state.Role.ValueString()
+	}
+
+	if len(StringsWithValues(oauth.ClientId, oauth.ClientSecret, oauth.Role)) != 3 {
+		resp.Diagnostics.AddError("unable to create an access token",
+			"an OAuth 2.0 client is required: set client_id, client_secret and role in the ephemeral resource or the provider")
+		return
+	}
+
+	httpClient, err := newHTTPClient(r.providerData.Skipverify, r.providerData.CAFile)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to create an access token", err.Error())
+		return
+	}
+	r.providerData.TraceRecorder.wrapClient(httpClient)
+
+	token, err := requestAccessToken(ctx, httpClient, r.providerData.Hostname, oauth)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError("unable to create an access token", err.Error())
+		return
+	}
+
+	state.AccessToken = types.StringValue(token.AccessToken)
+	state.TokenType = types.StringValue(token.TokenType)
+	state.Scope = types.StringValue(token.Scope)
+	state.ExpiresAt = types.StringNull()
+	if token.ExpiresIn > 0 {
+		state.ExpiresAt = types.StringValue(time.Now().UTC().Add(time.Duration(token.ExpiresIn) * time.Second).Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &state)...)
+}
+
+/*
+requestAccessToken requests an access token from the Turbonomic token endpoint with the client
+credentials grant.
+
+Parameters:
+  - ctx: The context of the request
+  - httpClient: The HTTP client of the request
+  - hostname: The hostname of the Turbonomic instance
+  - oauth: The OAuth 2.0 client and the role the token is scoped to
+
+Returns:
+  - accessTokenResponse: The access token
+  - error: An error when the token cannot be obtained, it never contains the client secret
+*/
+func requestAccessToken(ctx context.Context, httpClient *http.Client, hostname string, oauth OAuthConfig) (accessTokenResponse, error) {
+	var token accessTokenResponse
+
+	form := url.Values{
+		"client_id":     {oauth.ClientId},
+		"client_secret": {oauth.ClientSecret},
+		"role":          {oauth.Role},
+		"audience":      {accessTokenAudience},
+		"grant_type":    {"client_credentials"},
+	}
+
+	tokenUrl := url.URL{Scheme: "https", Host: hostname, Path: accessTokenPath}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenUrl.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return token, fmt.Errorf("unable to create the access token request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return token, fmt.Errorf("unable to request an access token from %s: %w", hostname, err)
+	}
+	defer func() {
+		_ = httpResp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return token, fmt.Errorf("unable to read the access token response: %v", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return token, &accessTokenRejectedError{ClientId: oauth.ClientId, StatusCode: httpResp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+
+	if err := json.Unmarshal(body, &token); err != nil {
+		return token, fmt.Errorf("unable to parse the access token response: %v", err)
+	}
+	if len(token.AccessToken) == 0 {
+		return token, fmt.Errorf("turbonomic returned an empty access token for client %s", oauth.ClientId)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests the access token request to the Turbonomic token endpoint
+func TestAccessTokenRequest(t *testing.T) {
+	oauth := OAuthConfig{ClientId: "client", ClientSecret: "s3cr3t", Role: "OBSERVER"}
+	expectedBody := "audience=turbonomic&client_id=client&client_secret=s3cr3t&grant_type=client_credentials&role=OBSERVER"
+
+	tests := []struct {
+		name          string
+		responseBody  string
+		responseCode  int
+		expected      accessTokenResponse
+		expectedError string
+	}{
+		{
+			name:         "token issued",
+			responseBody: `{"access_token":"eyJhbGciOi","scope":"role:OBSERVER","token_type":"Bearer","expires_in":600}`,
+			responseCode: http.StatusOK,
+			expected: accessTokenResponse{
+				AccessToken: "eyJhbGciOi",
+				Scope:       "role:OBSERVER",
+				TokenType:   "Bearer",
+				ExpiresIn:   600,
+			},
+		},
+		{
+			name:          "invalid client",
+			responseBody:  `{"error":"invalid_client"}`,
+			responseCode:  http.StatusUnauthorized,
+			expectedError: `turbonomic rejected the access token request for client client with status 401: {"error":"invalid_client"}`,
+		},
+		{
+			name:          "empty token",
+			responseBody:  `{"token_type":"Bearer"}`,
+			responseCode:  http.StatusOK,
+			expectedError: "turbonomic returned an empty access token for client client",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := mockTurboServer(t, []MockRoute{
+				{
+					Method:       http.MethodPost,
+					Path:         accessTokenPath,
+					ExpectedBody: expectedBody,
+					ResponseBody: tc.responseBody,
+					ResponseCode: tc.responseCode,
+				},
+			})
+
+			httpClient, err := newHTTPClient(true, "")
+			assert.NoError(t, err)
+
+			token, err := requestAccessToken(context.Background(), httpClient, strings.TrimPrefix(server.URL, "https://"), oauth)
+			if len(tc.expectedError) > 0 {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.NotContains(t, err.Error(), oauth.ClientSecret)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, token)
+		})
+	}
+}
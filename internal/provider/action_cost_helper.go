@@ -0,0 +1,131 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+const (
+	// Cost statistic constants
+	CostPriceStatistic    = "costPrice"
+	SavingsTypeFilter     = "savingsType"
+	SavingsTypeSavings    = "savings"
+	SavingsTypeInvestment = "investment"
+
+	// HoursPerMonth is the number of hours used by Turbonomic to convert hourly prices to monthly ones
+	HoursPerMonth = 730
+)
+
+// currencySymbols maps the currency symbol used in the units of the cost statistics to its ISO 4217 code
+var currencySymbols = map[string]string{
+	"$": "USD",
+	"€": "EUR",
+	"£": "GBP",
+	"¥": "JPY",
+	"₹": "INR",
+}
+
+// ActionCostModel describes the financial impact of the recommended action. It is embedded in the
+// models of the typed data sources and only set when they return the new values of the action.
+type ActionCostModel struct {
+	CurrentCost    types.Float64 `tfsdk:"current_cost"`
+	ProjectedCost  types.Float64 `tfsdk:"projected_cost"`
+	MonthlySavings types.Float64 `tfsdk:"monthly_savings"`
+	Currency       types.String  `tfsdk:"currency"`
+}
+
+// addActionCostAttributes adds the cost attributes of ActionCostModel to the given schema attributes
+func addActionCostAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["current_cost"] = schema.Float64Attribute{
+		MarkdownDescription: "monthly cost of the entity before the recommended action",
+		Computed:            true,
+	}
+	attributes["projected_cost"] = schema.Float64Attribute{
+		MarkdownDescription: "monthly cost of the entity after the recommended action",
+		Computed:            true,
+	}
+	attributes["monthly_savings"] = schema.Float64Attribute{
+		MarkdownDescription: "monthly savings of the recommended action, a negative value is an investment",
+		Computed:            true,
+	}
+	attributes["currency"] = schema.StringAttribute{
+		MarkdownDescription: "currency of the cost attributes, E.G: USD",
+		Computed:            true,
+	}
+
+	return attributes
+}
+
+/*
+GetActionCost computes the monthly cost impact of an action from its costPrice statistics.
+The hourly savings of the action are taken from the costPrice statistic with the savingsType filter,
+the current cost is the hourly cost price of the entity and the projected cost is derived from both.
+
+Parameters:
+  - currentHourlyCost: The hourly cost price of the entity, zero when unknown
+  - action: The action to compute the cost impact for
+
+Returns:
+  - ActionCostModel: The cost attributes, null when the action has no cost statistics
+*/
+func GetActionCost(currentHourlyCost float64, action turboclient.ActionResults) ActionCostModel {
+	cost := ActionCostModel{
+		CurrentCost:    types.Float64Null(),
+		ProjectedCost:  types.Float64Null(),
+		MonthlySavings: types.Float64Null(),
+		Currency:       types.StringNull(),
+	}
+
+	if len(action) == 0 {
+		return cost
+	}
+
+	for _, stat := range action[0].Stats {
+		if stat.Name != CostPriceStatistic {
+			continue
+		}
+
+		for _, filter := range stat.Filters {
+			if filter.Type != SavingsTypeFilter {
+				continue
+			}
+
+			hourlySavings := stat.Value
+			if filter.Value == SavingsTypeInvestment {
+				hourlySavings = -stat.Value
+			} else if filter.Value != SavingsTypeSavings {
+				continue
+			}
+
+			cost.MonthlySavings = types.Float64Value(hourlySavings * HoursPerMonth)
+			if currency, ok := currencyFromUnits(stat.Units); ok {
+				cost.Currency = types.StringValue(currency)
+			}
+
+			if currentHourlyCost > 0 {
+				cost.CurrentCost = types.Float64Value(currentHourlyCost * HoursPerMonth)
+				cost.ProjectedCost = types.Float64Value((currentHourlyCost - hourlySavings) * HoursPerMonth)
+			}
+
+			return cost
+		}
+	}
+
+	return cost
+}
+
+// currencyFromUnits returns the currency code of cost units such as "$/h"
+func currencyFromUnits(units string) (string, bool) {
+	for symbol, currency := range currencySymbols {
+		if strings.HasPrefix(units, symbol) {
+			return currency, true
+		}
+	}
+	return "", false
+}
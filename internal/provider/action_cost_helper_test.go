@@ -0,0 +1,51 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+func TestGetActionCost(t *testing.T) {
+	var actions turboclient.ActionResults
+	err := json.Unmarshal([]byte(loadTestFile(t, googleVMTestDataBaseDir, validVmActionRespTestData)), &actions)
+	assert.NoError(t, err)
+
+	t.Run("Investment with entity cost", func(t *testing.T) {
+		cost := GetActionCost(0.047204293, actions)
+		assert.InDelta(t, -6.380419, cost.MonthlySavings.ValueFloat64(), 1e-6)
+		assert.InDelta(t, 34.459134, cost.CurrentCost.ValueFloat64(), 1e-6)
+		assert.InDelta(t, 40.839553, cost.ProjectedCost.ValueFloat64(), 1e-6)
+		assert.Equal(t, "USD", cost.Currency.ValueString())
+	})
+
+	t.Run("Unknown entity cost", func(t *testing.T) {
+		cost := GetActionCost(0, actions)
+		assert.False(t, cost.MonthlySavings.IsNull())
+		assert.True(t, cost.CurrentCost.IsNull())
+		assert.True(t, cost.ProjectedCost.IsNull())
+	})
+
+	t.Run("No action", func(t *testing.T) {
+		cost := GetActionCost(0.1, nil)
+		assert.True(t, cost.MonthlySavings.IsNull())
+		assert.True(t, cost.Currency.IsNull())
+	})
+
+	t.Run("Investment savings type", func(t *testing.T) {
+		var investment turboclient.ActionResults
+		err := json.Unmarshal([]byte(`[{"stats": [{"name": "costPrice", "filters": [{"type": "savingsType", "value": "investment"}], "units": "€/h", "value": 0.5}]}]`), &investment)
+		assert.NoError(t, err)
+
+		cost := GetActionCost(1, investment)
+		assert.Equal(t, -365.0, cost.MonthlySavings.ValueFloat64())
+		assert.Equal(t, 730.0, cost.CurrentCost.ValueFloat64())
+		assert.Equal(t, 1095.0, cost.ProjectedCost.ValueFloat64())
+		assert.Equal(t, "EUR", cost.Currency.ValueString())
+	})
+}
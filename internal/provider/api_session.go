@@ -0,0 +1,107 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+)
+
+// apiSession checks the connectivity of a provider configuration and sends the requests that the
+// turbonomic client does not support, such as deleting a tag, logged in with the credentials of the
+// provider by the check or on the first request
+type apiSession struct {
+	httpClient *http.Client
+	hostname   string
+	creds      connectivityCredentials
+
+	mu            sync.Mutex
+	loggedIn      bool
+	authorization string
+}
+
+// newAPISession creates the session of a provider configuration, it keeps its own login cookie
+func newAPISession(httpClient *http.Client, hostname string, creds connectivityCredentials) *apiSession {
+	sessionClient := *httpClient
+	sessionClient.Jar, _ = cookiejar.New(nil)
+
+	return &apiSession{
+		httpClient: &sessionClient,
+		hostname:   hostname,
+		creds:      creds,
+	}
+}
+
+// checkConnectivity logs the session in and reads the version of the Turbonomic instance, so that the
+// provider fails fast when the instance is unreachable or rejects the credentials. The login is kept
+// for the following requests of the session.
+func (s *apiSession) checkConnectivity(ctx context.Context) (serverVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	authorization, err := logIn(ctx, s.httpClient, s.hostname, s.creds)
+	if err != nil {
+		return serverVersion{}, err
+	}
+	s.loggedIn, s.authorization = true, authorization
+
+	return readServerVersion(ctx, s.httpClient, s.hostname, s.authorization)
+}
+
+/*
+do sends a request to the Turbonomic API, logging in again once when the session expired.
+
+Parameters:
+  - ctx: The context of the request
+  - method: The HTTP method of the request
+  - path: The escaped path of the request, E.G: /api/v3/entities/75878700658784/tags/owner
+  - operation: The description of the request in the errors
+
+Returns:
+  - []byte: The body of a successful response
+  - error: A *connectivityError that classifies the failure
+*/
+func (s *apiSession) do(ctx context.Context, method string, path string, operation string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if !s.loggedIn {
+			authorization, err := logIn(ctx, s.httpClient, s.hostname, s.creds)
+			if err != nil {
+				return nil, err
+			}
+			s.loggedIn, s.authorization = true, authorization
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, "https://"+s.hostname+path, nil)
+		if err != nil {
+			return nil, &connectivityError{UnexpectedResponseSummary, err}
+		}
+		req.Header.Set("Accept", "application/json")
+		if s.authorization != "" {
+			req.Header.Set("Authorization", s.authorization)
+		}
+
+		body, err := doConnectivityRequest(s.httpClient, req, operation)
+		if err != nil && attempt == 0 && classifyConnectivityError(err).Summary == BadCredentialsSummary {
+			s.loggedIn = false
+			continue
+		}
+		return body, err
+	}
+}
+
+// deleteEntityTag deletes a tag of a Turbonomic entity, as the turbonomic client can only add tags
+func (s *apiSession) deleteEntityTag(ctx context.Context, uuid string, key string) error {
+	path := fmt.Sprintf("/api/v3/entities/%s/tags/%s", url.PathEscape(uuid), url.PathEscape(key))
+	if _, err := s.do(ctx, http.MethodDelete, path, fmt.Sprintf("delete the tag %s of entity %s", key, uuid)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that the session logs in once, then deletes the tags with the login cookie
+func TestAPISessionDeleteEntityTag(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{Method: http.MethodPost, Path: loginPath, ResponseCode: http.StatusOK, ResponseBody: `{"status":"ok"}`, Times: 1},
+		{Method: http.MethodDelete, Path: "/api/v3/entities/75878700658784/tags/turbonomic_action_id", ResponseCode: http.StatusOK, Times: 1},
+		{Method: http.MethodDelete, Path: "/api/v3/entities/75878700658784/tags/cost center", ResponseCode: http.StatusOK, Times: 1},
+	})
+	httpClient, err := newHTTPClient(true, "")
+	require.NoError(t, err)
+
+	session := newAPISession(httpClient, strings.TrimPrefix(server.URL, "https://"),
+		connectivityCredentials{Username: "testuser", Password: "password"})
+	assert.NoError(t, session.deleteEntityTag(context.Background(), "75878700658784", ActionIdTagName))
+	assert.NoError(t, session.deleteEntityTag(context.Background(), "75878700658784", "cost center"))
+}
+
+// Tests that the session logs in again once when its session expired
+func TestAPISessionExpired(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{Method: http.MethodPost, Path: accessTokenPath, ResponseCode: http.StatusOK, ResponseBody: accessTokenRespTestData, Times: 2},
+		{Method: http.MethodDelete, Path: "/api/v3/entities/{id}/tags/{key}", ResponseCode: http.StatusUnauthorized, Times: 1},
+		{
+			Method: http.MethodDelete, Path: "/api/v3/entities/{id}/tags/{key}", ResponseCode: http.StatusOK,
+			Headers: map[string]string{"Authorization": "Bearer eyJhbGciOi"}, Times: 1,
+		},
+	})
+	httpClient, err := newHTTPClient(true, "")
+	require.NoError(t, err)
+
+	session := newAPISession(httpClient, strings.TrimPrefix(server.URL, "https://"),
+		connectivityCredentials{OAuth: OAuthConfig{ClientId: "12345", ClientSecret: "s3cr3t", Role: "OBSERVER"}})
+	assert.NoError(t, session.deleteEntityTag(context.Background(), "75878700658784", ActionIdTagName))
+}
+
+// Tests that the session keeps the login of the connectivity check for the following requests
+func TestAPISessionCheckConnectivity(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{Method: http.MethodPost, Path: loginPath, ResponseCode: http.StatusOK, ResponseBody: `{"status":"ok"}`, Times: 1},
+		{Method: http.MethodGet, Path: versionInfoPath, ResponseCode: http.StatusOK, ResponseBody: versionInfoRespTestData, Times: 1},
+		{Method: http.MethodDelete, Path: "/api/v3/entities/75878700658784/tags/turbonomic_action_id", ResponseCode: http.StatusOK, Times: 1},
+	})
+	httpClient, err := newHTTPClient(true, "")
+	require.NoError(t, err)
+
+	session := newAPISession(httpClient, strings.TrimPrefix(server.URL, "https://"),
+		connectivityCredentials{Username: "testuser", Password: "password"})
+	version, err := session.checkConnectivity(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, version.Version)
+	assert.NoError(t, session.deleteEntityTag(context.Background(), "75878700658784", ActionIdTagName))
+}
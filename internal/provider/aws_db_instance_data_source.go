@@ -45,10 +45,16 @@ type AwsDbInstanceEntityModel struct {
 	CurrentAllocatedStorage types.Int64  `tfsdk:"current_allocated_storage"`
 	NewAllocatedStorage     types.Int64  `tfsdk:"new_allocated_storage"`
 	DefaultAllocatedStorage types.Int64  `tfsdk:"default_allocated_storage"`
+	ActionId                types.Int64  `tfsdk:"action_id"`
+	DisableEntityTagging    types.Bool   `tfsdk:"disable_entity_tagging"`
+	ActionCostModel
 }
 
 type AwsDbInstanceDataSource struct {
-	client *turboclient.Client
+	client     *turboclient.Client
+	requests   *clientRequests
+	tagConfig  TagConfig
+	serverInfo ServerInfo
 }
 
 func NewAwsDbInstanceDataSource() datasource.DataSource {
@@ -155,6 +161,10 @@ func (d *AwsDbInstanceDataSource) Schema(ctx context.Context, req datasource.Sch
 			},
 		},
 	}
+
+	addActionCostAttributes(resp.Schema.Attributes)
+	resp.Schema.Attributes["disable_entity_tagging"] = disableEntityTaggingAttribute
+	resp.Schema.Attributes["action_id"] = actionIdAttribute
 }
 
 func (d *AwsDbInstanceDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
@@ -173,17 +183,20 @@ func (d *AwsDbInstanceDataSource) Configure(_ context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*turboclient.Client)
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"unexpected data-source configure type",
-			fmt.Sprintf("expected: *turboclient.Client, got: %T. please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("expected: *TurbonomicProviderData, got: %T. please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+	d.tagConfig = providerData.TagConfig
+	d.serverInfo = providerData.ServerInfo
 }
 
 func (d *AwsDbInstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -200,6 +213,8 @@ func (d *AwsDbInstanceDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
+	client := d.requests.client(ctx, d.client)
+
 	var entity turboclient.SearchResults
 	var errDiag *diag.ErrorDiagnostic
 	entityArgs := []EntityOption{
@@ -208,7 +223,7 @@ func (d *AwsDbInstanceDataSource) Read(ctx context.Context, req datasource.ReadR
 		WithEnvironmentType("CLOUD"),
 		WithCloudType("AWS"),
 	}
-	entity, errDiag = GetEntitiesByName(d.client, entityArgs...)
+	entity, errDiag = GetEntitiesByName(client, entityArgs...)
 
 	var err error
 	var errDetail string
@@ -235,7 +250,7 @@ func (d *AwsDbInstanceDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 	// as stat returns current value as projected when there are no action, calling it before  checking action to ensure data is available
-	commodityActions, errDiag := GetStatsByEntityUUIDAndType(d.client, entity[0].UUID, "DatabaseServer")
+	commodityActions, errDiag := GetStatsByEntityUUIDAndType(client, entity[0].UUID, "DatabaseServer")
 	if errDiag != nil {
 		tflog.Warn(ctx, errDiag.Detail())
 		resp.Diagnostics.AddWarning(errDiag.Summary(), errDiag.Detail())
@@ -249,7 +264,7 @@ func (d *AwsDbInstanceDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	actions, errDiag := GetActions(d.client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
+	actions, errDiag := GetActions(client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
 	errDetail = ""
 	if errDiag != nil {
 		errDetail = errDiag.Detail()
@@ -257,7 +272,7 @@ func (d *AwsDbInstanceDataSource) Read(ctx context.Context, req datasource.ReadR
 		errDetail = fmt.Sprintf("no matching action found for entity id: %s", entity[0].UUID)
 	} else {
 		tflog.Debug(ctx, fmt.Sprintf("action id found: %d\n", actions[0].ActionID))
-
+		resp.Diagnostics.Append(capabilityDiagnostics(d.serverInfo, actionExecutionCapabilities...)...)
 		canExecute, executeMsg := canExecuteAction(actions)
 		if canExecute {
 			state, err = HandleAwsDbInstanceAction(ctx, resp, state, actions)
@@ -275,7 +290,7 @@ func (d *AwsDbInstanceDataSource) Read(ctx context.Context, req datasource.ReadR
 
 		// if action doesn't exist or got an error while retrieving entity action using API, update new with current value
 		setCurrentAwsDbInstanceToNewState(&state)
-		if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+		if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging)); err != nil {
 			resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 		}
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -286,7 +301,9 @@ func (d *AwsDbInstanceDataSource) Read(ctx context.Context, req datasource.ReadR
 	setDefaultsAwsDbInstanceToCurrentState(&state)
 	setDefaultsAwsDbInstanceToNewState(&state)
 
-	if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+	state.ActionCostModel = GetActionCost(entity[0].CostPrice, actions)
+	state.ActionId = types.Int64Value(actions[0].ActionID)
+	if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging).WithAction(actions)); err != nil {
 		resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 	}
 
@@ -22,7 +22,7 @@ type AwsEbsVolumeStateAdapter struct {
 
 // HandleAwsEbsVolumeCurrentState(ctx, entity) is a custom function to extract the display name for setting it to current types.
 func HandleAwsEbsVolumeCurrentState(ctx context.Context, state AwsEbsVolumeEntityModel, entities turboclient.SearchResults) (AwsEbsVolumeEntityModel, error) {
-	state.CurrentType = types.StringValue(strings.ToLower(entities[0].Template.DisplayName))
+	state.CurrentType = types.StringValue(getEbsVolumeType(entities[0].Template.DisplayName))
 	return state, nil
 }
 
@@ -38,12 +38,21 @@ func HandleAwsEbsVolumeAction(ctx context.Context, resp *datasource.ReadResponse
 			continue
 		}
 
-		state.CurrentType = types.StringValue(strings.ToLower(act.CurrentEntity.DisplayName))
-		state.NewType = types.StringValue(strings.ToLower(act.NewEntity.DisplayName))
+		state.CurrentType = types.StringValue(getEbsVolumeType(act.CurrentEntity.DisplayName))
+		state.NewType = types.StringValue(getEbsVolumeType(act.NewEntity.DisplayName))
 	}
 	return state, nil
 }
 
+// getEbsVolumeType returns the EBS volume type of a Turbonomic storage tier, falling back to the
+// lower case display name for the tiers missing from the tier mappings
+func getEbsVolumeType(disk string) string {
+	if volumeType, err := tierToSku(CloudAWS, DiskResourceKind, disk); err == nil {
+		return volumeType
+	}
+	return strings.ToLower(disk)
+}
+
 // HandleVolumeCommodityAction processes commodity actions for volume entities and updates the state with appropriate values.
 // It maps statistics like StorageAccess, IOThroughput, and StorageAmount to their corresponding state fields.
 //
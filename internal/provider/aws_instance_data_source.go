@@ -29,17 +29,23 @@ var (
 )
 
 type AwsInstanceEntityModel struct {
-	EntityUuid          types.String `tfsdk:"entity_uuid"`
-	EntityName          types.String `tfsdk:"entity_name"`
-	VendorId            types.String `tfsdk:"vendor_id"`
-	EntityType          types.String `tfsdk:"entity_type"`
-	CurrentInstanceType types.String `tfsdk:"current_instance_type"`
-	NewInstanceType     types.String `tfsdk:"new_instance_type"`
-	DefaultInstanceType types.String `tfsdk:"default_instance_type"`
+	EntityUuid           types.String `tfsdk:"entity_uuid"`
+	EntityName           types.String `tfsdk:"entity_name"`
+	VendorId             types.String `tfsdk:"vendor_id"`
+	EntityType           types.String `tfsdk:"entity_type"`
+	CurrentInstanceType  types.String `tfsdk:"current_instance_type"`
+	NewInstanceType      types.String `tfsdk:"new_instance_type"`
+	DefaultInstanceType  types.String `tfsdk:"default_instance_type"`
+	ActionId             types.Int64  `tfsdk:"action_id"`
+	DisableEntityTagging types.Bool   `tfsdk:"disable_entity_tagging"`
+	ActionCostModel
 }
 
 type AwsInstanceDataSource struct {
-	client *turboclient.Client
+	client     *turboclient.Client
+	requests   *clientRequests
+	tagConfig  TagConfig
+	serverInfo ServerInfo
 }
 
 func NewAwsInstanceDataSource() datasource.DataSource {
@@ -100,6 +106,10 @@ func (d *AwsInstanceDataSource) Schema(ctx context.Context, req datasource.Schem
 			},
 		},
 	}
+
+	addActionCostAttributes(resp.Schema.Attributes)
+	resp.Schema.Attributes["disable_entity_tagging"] = disableEntityTaggingAttribute
+	resp.Schema.Attributes["action_id"] = actionIdAttribute
 }
 
 func (d *AwsInstanceDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
@@ -115,17 +125,20 @@ func (d *AwsInstanceDataSource) Configure(_ context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*turboclient.Client)
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"unexpected data-source configure type",
-			fmt.Sprintf("expected: *turboclient.Client, got: %T. please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("expected: *TurbonomicProviderData, got: %T. please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+	d.tagConfig = providerData.TagConfig
+	d.serverInfo = providerData.ServerInfo
 }
 
 func (d *AwsInstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -142,13 +155,15 @@ func (d *AwsInstanceDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
+	client := d.requests.client(ctx, d.client)
+
 	var entity turboclient.SearchResults
 	var errDiag *diag.ErrorDiagnostic
 	entityArgs := []EntityOptionWithVendorId{
 		WithVendorId(state.VendorId.ValueString()),
 		WithEntityTypeForVendorId(enTyp),
 	}
-	entity, errDiag = GetEntitiesByVendorId(d.client, entityArgs...)
+	entity, errDiag = GetEntitiesByVendorId(client, entityArgs...)
 	if len(entity) == 0 || errDiag != nil {
 		if errDiag != nil {
 			tflog.Debug(ctx, fmt.Sprintf("error while searching by vendor id: %s", errDiag.Detail()))
@@ -164,7 +179,7 @@ func (d *AwsInstanceDataSource) Read(ctx context.Context, req datasource.ReadReq
 			WithCloudType("AWS"),
 			ShowVendorIdString(true),
 		}
-		entity, errDiag = GetEntitiesByName(d.client, entityArgs...)
+		entity, errDiag = GetEntitiesByName(client, entityArgs...)
 	}
 
 	var err error
@@ -192,7 +207,7 @@ func (d *AwsInstanceDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	actions, errDiag := GetActions(d.client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
+	actions, errDiag := GetActions(client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
 	errDetail = ""
 	if errDiag != nil {
 		errDetail = errDiag.Detail()
@@ -200,7 +215,7 @@ func (d *AwsInstanceDataSource) Read(ctx context.Context, req datasource.ReadReq
 		errDetail = fmt.Sprintf("no matching action found for entity id: %s", entity[0].UUID)
 	} else {
 		tflog.Debug(ctx, fmt.Sprintf("action id found: %d\n", actions[0].ActionID))
-
+		resp.Diagnostics.Append(capabilityDiagnostics(d.serverInfo, actionExecutionCapabilities...)...)
 		canExecute, executeMsg := canExecuteAction(actions)
 		if canExecute {
 			state, err = HandleAwsInstanceAction(ctx, resp, state, actions)
@@ -218,7 +233,7 @@ func (d *AwsInstanceDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 		// if action doesn't exist or got an error while retrieving entity action using API, update new with current value
 		setCurrentAwsInstanceToNewState(&state)
-		if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+		if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging)); err != nil {
 			resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 		}
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -229,7 +244,9 @@ func (d *AwsInstanceDataSource) Read(ctx context.Context, req datasource.ReadReq
 	setDefaultsAwsInstanceToCurrentState(&state)
 	setDefaultsAwsInstanceToNewState(&state)
 
-	if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+	state.ActionCostModel = GetActionCost(entity[0].CostPrice, actions)
+	state.ActionId = types.Int64Value(actions[0].ActionID)
+	if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging).WithAction(actions)); err != nil {
 		resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 	}
 
@@ -27,16 +27,22 @@ var (
 )
 
 type AzurermLinuxVirtualMachineEntityModel struct {
-	EntityUuid  types.String `tfsdk:"entity_uuid"`
-	EntityName  types.String `tfsdk:"entity_name"`
-	EntityType  types.String `tfsdk:"entity_type"`
-	CurrentSize types.String `tfsdk:"current_size"`
-	NewSize     types.String `tfsdk:"new_size"`
-	DefaultSize types.String `tfsdk:"default_size"`
+	EntityUuid           types.String `tfsdk:"entity_uuid"`
+	EntityName           types.String `tfsdk:"entity_name"`
+	EntityType           types.String `tfsdk:"entity_type"`
+	CurrentSize          types.String `tfsdk:"current_size"`
+	NewSize              types.String `tfsdk:"new_size"`
+	DefaultSize          types.String `tfsdk:"default_size"`
+	ActionId             types.Int64  `tfsdk:"action_id"`
+	DisableEntityTagging types.Bool   `tfsdk:"disable_entity_tagging"`
+	ActionCostModel
 }
 
 type AzurermLinuxVirtualMachineDataSource struct {
-	client *turboclient.Client
+	client     *turboclient.Client
+	requests   *clientRequests
+	tagConfig  TagConfig
+	serverInfo ServerInfo
 }
 
 func NewAzurermLinuxVirtualMachineDataSource() datasource.DataSource {
@@ -89,6 +95,10 @@ func (d *AzurermLinuxVirtualMachineDataSource) Schema(ctx context.Context, req d
 			},
 		},
 	}
+
+	addActionCostAttributes(resp.Schema.Attributes)
+	resp.Schema.Attributes["disable_entity_tagging"] = disableEntityTaggingAttribute
+	resp.Schema.Attributes["action_id"] = actionIdAttribute
 }
 
 func (d *AzurermLinuxVirtualMachineDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -96,17 +106,20 @@ func (d *AzurermLinuxVirtualMachineDataSource) Configure(_ context.Context, req
 		return
 	}
 
-	client, ok := req.ProviderData.(*turboclient.Client)
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"unexpected data-source configure type",
-			fmt.Sprintf("expected: *turboclient.Client, got: %T. please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("expected: *TurbonomicProviderData, got: %T. please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+	d.tagConfig = providerData.TagConfig
+	d.serverInfo = providerData.ServerInfo
 }
 
 func (d *AzurermLinuxVirtualMachineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -123,6 +136,8 @@ func (d *AzurermLinuxVirtualMachineDataSource) Read(ctx context.Context, req dat
 		return
 	}
 
+	client := d.requests.client(ctx, d.client)
+
 	var entity turboclient.SearchResults
 	var errDiag *diag.ErrorDiagnostic
 	entityArgs := []EntityOption{
@@ -131,11 +146,11 @@ func (d *AzurermLinuxVirtualMachineDataSource) Read(ctx context.Context, req dat
 		WithEnvironmentType("CLOUD"),
 		WithCloudType("AZURE"),
 	}
-	entity, errDiag = GetEntitiesByName(d.client, append(entityArgs, WithOSNames("linux", "rhel"))...)
+	entity, errDiag = GetEntitiesByName(client, append(entityArgs, WithOSNames("linux", "rhel"))...)
 	if errDiag != nil {
 		errDetail := fmt.Sprintf("entity %s of type: %s and osNames: %s not found in Turbonomic instance, searching without osNames", enName, enTyp, "[Linux Rhel]")
 		tflog.Warn(ctx, errDetail)
-		entity, errDiag = GetEntitiesByName(d.client, entityArgs...)
+		entity, errDiag = GetEntitiesByName(client, entityArgs...)
 	}
 
 	var err error
@@ -163,7 +178,7 @@ func (d *AzurermLinuxVirtualMachineDataSource) Read(ctx context.Context, req dat
 		return
 	}
 
-	actions, errDiag := GetActions(d.client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
+	actions, errDiag := GetActions(client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
 	errDetail = ""
 	if errDiag != nil {
 		errDetail = errDiag.Detail()
@@ -171,7 +186,7 @@ func (d *AzurermLinuxVirtualMachineDataSource) Read(ctx context.Context, req dat
 		errDetail = fmt.Sprintf("no matching action found for entity id: %s", entity[0].UUID)
 	} else {
 		tflog.Debug(ctx, fmt.Sprintf("action id found: %d\n", actions[0].ActionID))
-
+		resp.Diagnostics.Append(capabilityDiagnostics(d.serverInfo, actionExecutionCapabilities...)...)
 		canExecute, executeMsg := canExecuteAction(actions)
 		if canExecute {
 			state, err = HandleAzurermLinuxVirtualMachineAction(ctx, resp, state, actions)
@@ -189,7 +204,7 @@ func (d *AzurermLinuxVirtualMachineDataSource) Read(ctx context.Context, req dat
 
 		// if action doesn't exist or got an error while retrieving entity action using API, update new with current value
 		setCurrentAzurermLinuxVirtualMachineToNewState(&state)
-		if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+		if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging)); err != nil {
 			resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 		}
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -200,7 +215,9 @@ func (d *AzurermLinuxVirtualMachineDataSource) Read(ctx context.Context, req dat
 	setDefaultsAzurermLinuxVirtualMachineToCurrentState(&state)
 	setDefaultsAzurermLinuxVirtualMachineToNewState(&state)
 
-	if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+	state.ActionCostModel = GetActionCost(entity[0].CostPrice, actions)
+	state.ActionId = types.Int64Value(actions[0].ActionID)
+	if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging).WithAction(actions)); err != nil {
 		resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 	}
 
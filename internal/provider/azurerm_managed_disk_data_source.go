@@ -46,10 +46,16 @@ type AzurermManagedDiskEntityModel struct {
 	CurrentDiskSizeGb         types.Int64  `tfsdk:"current_disk_size_gb"`
 	NewDiskSizeGb             types.Int64  `tfsdk:"new_disk_size_gb"`
 	DefaultDiskSizeGb         types.Int64  `tfsdk:"default_disk_size_gb"`
+	ActionId                  types.Int64  `tfsdk:"action_id"`
+	DisableEntityTagging      types.Bool   `tfsdk:"disable_entity_tagging"`
+	ActionCostModel
 }
 
 type AzurermManagedDiskDataSource struct {
-	client *turboclient.Client
+	client     *turboclient.Client
+	requests   *clientRequests
+	tagConfig  TagConfig
+	serverInfo ServerInfo
 }
 
 func NewAzurermManagedDiskDataSource() datasource.DataSource {
@@ -164,6 +170,10 @@ func (d *AzurermManagedDiskDataSource) Schema(ctx context.Context, req datasourc
 			},
 		},
 	}
+
+	addActionCostAttributes(resp.Schema.Attributes)
+	resp.Schema.Attributes["disable_entity_tagging"] = disableEntityTaggingAttribute
+	resp.Schema.Attributes["action_id"] = actionIdAttribute
 }
 
 func (d *AzurermManagedDiskDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
@@ -180,17 +190,20 @@ func (d *AzurermManagedDiskDataSource) Configure(_ context.Context, req datasour
 		return
 	}
 
-	client, ok := req.ProviderData.(*turboclient.Client)
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"unexpected data-source configure type",
-			fmt.Sprintf("expected: *turboclient.Client, got: %T. please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("expected: *TurbonomicProviderData, got: %T. please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+	d.tagConfig = providerData.TagConfig
+	d.serverInfo = providerData.ServerInfo
 }
 
 func (d *AzurermManagedDiskDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -207,13 +220,15 @@ func (d *AzurermManagedDiskDataSource) Read(ctx context.Context, req datasource.
 		return
 	}
 
+	client := d.requests.client(ctx, d.client)
+
 	var entity turboclient.SearchResults
 	var errDiag *diag.ErrorDiagnostic
 	entityArgs := []EntityOptionWithVendorId{
 		WithVendorId(state.VendorId.ValueString()),
 		WithEntityTypeForVendorId(enTyp),
 	}
-	entity, errDiag = GetEntitiesByVendorId(d.client, entityArgs...)
+	entity, errDiag = GetEntitiesByVendorId(client, entityArgs...)
 	if len(entity) == 0 || errDiag != nil {
 		if errDiag != nil {
 			tflog.Debug(ctx, fmt.Sprintf("error while searching by vendor id: %s", errDiag.Detail()))
@@ -229,7 +244,7 @@ func (d *AzurermManagedDiskDataSource) Read(ctx context.Context, req datasource.
 			WithCloudType("AZURE"),
 			ShowVendorIdString(true),
 		}
-		entity, errDiag = GetEntitiesByName(d.client, entityArgs...)
+		entity, errDiag = GetEntitiesByName(client, entityArgs...)
 	}
 
 	var err error
@@ -257,7 +272,7 @@ func (d *AzurermManagedDiskDataSource) Read(ctx context.Context, req datasource.
 		return
 	}
 	// as stat returns current value as projected when there are no action, calling it before  checking action to ensure data is available
-	commodityActions, errDiag := GetStatsByEntityUUIDAndType(d.client, entity[0].UUID, "VirtualVolume")
+	commodityActions, errDiag := GetStatsByEntityUUIDAndType(client, entity[0].UUID, "VirtualVolume")
 	if errDiag != nil {
 		tflog.Warn(ctx, errDiag.Detail())
 		resp.Diagnostics.AddWarning(errDiag.Summary(), errDiag.Detail())
@@ -271,7 +286,7 @@ func (d *AzurermManagedDiskDataSource) Read(ctx context.Context, req datasource.
 		return
 	}
 
-	actions, errDiag := GetActions(d.client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
+	actions, errDiag := GetActions(client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
 	errDetail = ""
 	if errDiag != nil {
 		errDetail = errDiag.Detail()
@@ -279,7 +294,7 @@ func (d *AzurermManagedDiskDataSource) Read(ctx context.Context, req datasource.
 		errDetail = fmt.Sprintf("no matching action found for entity id: %s", entity[0].UUID)
 	} else {
 		tflog.Debug(ctx, fmt.Sprintf("action id found: %d\n", actions[0].ActionID))
-
+		resp.Diagnostics.Append(capabilityDiagnostics(d.serverInfo, actionExecutionCapabilities...)...)
 		canExecute, executeMsg := canExecuteAction(actions)
 		if canExecute {
 			state, err = HandleAzurermManagedDiskAction(ctx, resp, state, actions)
@@ -297,7 +312,7 @@ func (d *AzurermManagedDiskDataSource) Read(ctx context.Context, req datasource.
 
 		// if action doesn't exist or got an error while retrieving entity action using API, update new with current value
 		setCurrentAzurermManagedDiskToNewState(&state)
-		if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+		if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging)); err != nil {
 			resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 		}
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -308,7 +323,9 @@ func (d *AzurermManagedDiskDataSource) Read(ctx context.Context, req datasource.
 	setDefaultsAzurermManagedDiskToCurrentState(&state)
 	setDefaultsAzurermManagedDiskToNewState(&state)
 
-	if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+	state.ActionCostModel = GetActionCost(entity[0].CostPrice, actions)
+	state.ActionId = types.Int64Value(actions[0].ActionID)
+	if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging).WithAction(actions)); err != nil {
 		resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 	}
 
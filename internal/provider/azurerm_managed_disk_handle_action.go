@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -26,17 +25,6 @@ const (
 	ZoneRedundantSSD DiskType = "Zone-redundant SSD"
 )
 
-var (
-	diskTypeToStorageType = map[DiskType]string{
-		StandardHDD:      "Standard_LRS",
-		StandardSSD:      "StandardSSD_LRS",
-		PremiumSSD:       "Premium_LRS",
-		UltraDisk:        "UltraSSD_LRS",
-		PremiumSSDv2:     "PremiumV2_LRS",
-		ZoneRedundantSSD: "StandardSSD_ZRS",
-	}
-)
-
 // AzurermManagedDiskStateAdapter adapts AzurermManagedDiskEntityModel to VolumeStateUpdater interface
 type AzurermManagedDiskStateAdapter struct {
 	State *AzurermManagedDiskEntityModel
@@ -65,15 +53,7 @@ func HandleAzurermManagedDiskAction(ctx context.Context, resp *datasource.ReadRe
 }
 
 func getStorageType(disk string) (string, error) {
-	storageType, ok := diskTypeToStorageType[DiskType(
-		strings.TrimPrefix(
-			disk, "Managed ",
-		),
-	)]
-	if !ok {
-		return "", fmt.Errorf("unknown storage type provided: %s", disk)
-	}
-	return storageType, nil
+	return tierToSku(CloudAzure, DiskResourceKind, disk)
 }
 
 // HandleVolumeCommodityAction processes commodity actions for volume entities and updates the state with appropriate values.
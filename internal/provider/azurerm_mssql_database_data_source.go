@@ -27,16 +27,22 @@ var (
 )
 
 type AzurermMssqlDatabaseEntityModel struct {
-	EntityName     types.String `tfsdk:"entity_name"`
-	EntityType     types.String `tfsdk:"entity_type"`
-	CurrentSkuName types.String `tfsdk:"current_sku_name"`
-	NewSkuName     types.String `tfsdk:"new_sku_name"`
-	DefaultSkuName types.String `tfsdk:"default_sku_name"`
-	EntityUuid     types.String `tfsdk:"entity_uuid"`
+	EntityName           types.String `tfsdk:"entity_name"`
+	EntityType           types.String `tfsdk:"entity_type"`
+	CurrentSkuName       types.String `tfsdk:"current_sku_name"`
+	NewSkuName           types.String `tfsdk:"new_sku_name"`
+	DefaultSkuName       types.String `tfsdk:"default_sku_name"`
+	EntityUuid           types.String `tfsdk:"entity_uuid"`
+	ActionId             types.Int64  `tfsdk:"action_id"`
+	DisableEntityTagging types.Bool   `tfsdk:"disable_entity_tagging"`
+	ActionCostModel
 }
 
 type AzurermMssqlDatabaseDataSource struct {
-	client *turboclient.Client
+	client     *turboclient.Client
+	requests   *clientRequests
+	tagConfig  TagConfig
+	serverInfo ServerInfo
 }
 
 func NewAzurermMssqlDatabaseDataSource() datasource.DataSource {
@@ -86,6 +92,10 @@ func (d *AzurermMssqlDatabaseDataSource) Schema(ctx context.Context, req datasou
 			},
 		},
 	}
+
+	addActionCostAttributes(resp.Schema.Attributes)
+	resp.Schema.Attributes["disable_entity_tagging"] = disableEntityTaggingAttribute
+	resp.Schema.Attributes["action_id"] = actionIdAttribute
 }
 
 func (d *AzurermMssqlDatabaseDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -93,17 +103,20 @@ func (d *AzurermMssqlDatabaseDataSource) Configure(_ context.Context, req dataso
 		return
 	}
 
-	client, ok := req.ProviderData.(*turboclient.Client)
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"unexpected data-source configure type",
-			fmt.Sprintf("expected: *turboclient.Client, got: %T. please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("expected: *TurbonomicProviderData, got: %T. please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+	d.tagConfig = providerData.TagConfig
+	d.serverInfo = providerData.ServerInfo
 }
 
 func (d *AzurermMssqlDatabaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -120,6 +133,8 @@ func (d *AzurermMssqlDatabaseDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 
+	client := d.requests.client(ctx, d.client)
+
 	var entity turboclient.SearchResults
 	var errDiag *diag.ErrorDiagnostic
 	entityArgs := []EntityOption{
@@ -128,7 +143,7 @@ func (d *AzurermMssqlDatabaseDataSource) Read(ctx context.Context, req datasourc
 		WithEnvironmentType("CLOUD"),
 		WithCloudType("AZURE"),
 	}
-	entity, errDiag = GetEntitiesByName(d.client, entityArgs...)
+	entity, errDiag = GetEntitiesByName(client, entityArgs...)
 
 	var err error
 	var errDetail string
@@ -155,7 +170,7 @@ func (d *AzurermMssqlDatabaseDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 
-	actions, errDiag := GetActions(d.client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
+	actions, errDiag := GetActions(client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
 	errDetail = ""
 	if errDiag != nil {
 		errDetail = errDiag.Detail()
@@ -163,7 +178,7 @@ func (d *AzurermMssqlDatabaseDataSource) Read(ctx context.Context, req datasourc
 		errDetail = fmt.Sprintf("no matching action found for entity id: %s", entity[0].UUID)
 	} else {
 		tflog.Debug(ctx, fmt.Sprintf("action id found: %d\n", actions[0].ActionID))
-
+		resp.Diagnostics.Append(capabilityDiagnostics(d.serverInfo, actionExecutionCapabilities...)...)
 		canExecute, executeMsg := canExecuteAction(actions)
 		if canExecute {
 			state, err = HandleAzurermMssqlDatabaseAction(ctx, resp, state, actions)
@@ -181,7 +196,7 @@ func (d *AzurermMssqlDatabaseDataSource) Read(ctx context.Context, req datasourc
 
 		// if action doesn't exist or got an error while retrieving entity action using API, update new with current value
 		setCurrentAzurermMssqlDatabaseToNewState(&state)
-		if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+		if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging)); err != nil {
 			resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 		}
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -192,7 +207,9 @@ func (d *AzurermMssqlDatabaseDataSource) Read(ctx context.Context, req datasourc
 	setDefaultsAzurermMssqlDatabaseToCurrentState(&state)
 	setDefaultsAzurermMssqlDatabaseToNewState(&state)
 
-	if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+	state.ActionCostModel = GetActionCost(entity[0].CostPrice, actions)
+	state.ActionId = types.Int64Value(actions[0].ActionID)
+	if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging).WithAction(actions)); err != nil {
 		resp.Diagnostics.AddWarning("error while tagging an entity", err.Error())
 	}
 
@@ -0,0 +1,186 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Policies of the choose function
+	ChoosePolicyAny               = "any"
+	ChoosePolicyUpsizeOnly        = "upsize_only"
+	ChoosePolicyDownsizeOnly      = "downsize_only"
+	ChoosePolicyNeverChangeFamily = "never_change_family"
+)
+
+var choosePolicies = []string{ChoosePolicyAny, ChoosePolicyUpsizeOnly, ChoosePolicyDownsizeOnly, ChoosePolicyNeverChangeFamily}
+
+/*
+chooseValue picks the value to apply between the recommended, current and default values.
+The recommended value is returned when the policy allows the change from the current value,
+the current value when it does not, and the default value when neither is set.
+
+Numbers are compared by value and have no family, other values are compared as instance types.
+
+Parameters:
+  - recommended: The value recommended by Turbonomic, empty when there is no recommendation
+  - current: The current value of the resource, empty when the resource does not exist
+  - def: The default value
+  - policy: One of any, upsize_only, downsize_only or never_change_family
+
+Returns:
+  - string: The chosen value, empty when no value is set
+  - error: An error when the policy is unknown or the values cannot be compared
+*/
+func chooseValue(recommended, current, def, policy string) (string, error) {
+	if !slices.Contains(choosePolicies, policy) {
+		return "", fmt.Errorf("unknown policy %q, expected one of: %s", policy, strings.Join(choosePolicies, ", "))
+	}
+
+	if len(recommended) == 0 || len(current) == 0 || recommended == current {
+		for _, value := range []string{recommended, current, def} {
+			if len(value) != 0 {
+				return value, nil
+			}
+		}
+		return "", nil
+	}
+
+	allowed := true
+	switch policy {
+	case ChoosePolicyUpsizeOnly, ChoosePolicyDownsizeOnly:
+		comparison, err := compareValues(recommended, current)
+		if err != nil {
+			return "", err
+		}
+		allowed = (policy == ChoosePolicyUpsizeOnly && comparison > 0) ||
+			(policy == ChoosePolicyDownsizeOnly && comparison < 0)
+	case ChoosePolicyNeverChangeFamily:
+		sameFamily, err := sameValueFamily(recommended, current)
+		if err != nil {
+			return "", err
+		}
+		allowed = sameFamily
+	}
+
+	if allowed {
+		return recommended, nil
+	}
+	return current, nil
+}
+
+// compareValues compares two numbers or two instance types
+func compareValues(a, b string) (int, error) {
+	numberA, errA := strconv.ParseFloat(a, 64)
+	numberB, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return cmp.Compare(numberA, numberB), nil
+	}
+	return compareInstanceTypes("", a, b)
+}
+
+// sameValueFamily returns whether two numbers or two instance types belong to the same family
+func sameValueFamily(a, b string) (bool, error) {
+	_, errA := strconv.ParseFloat(a, 64)
+	_, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return true, nil
+	}
+
+	familyA, err := instanceTypeFamily("", a)
+	if err != nil {
+		return false, err
+	}
+	familyB, err := instanceTypeFamily("", b)
+	if err != nil {
+		return false, err
+	}
+	return familyA == familyB, nil
+}
+
+var _ function.Function = &ChooseFunction{}
+
+type ChooseFunction struct{}
+
+func NewChooseFunction() function.Function {
+	return &ChooseFunction{}
+}
+
+func (f *ChooseFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "choose"
+}
+
+func (f *ChooseFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Pick the safe value between the recommended, current and default values",
+		Description: "Returns the recommended value when the policy allows the change from the current value, the current value " +
+			"when it does not, and the default value when neither is set. The policy is one of any, upsize_only, downsize_only " +
+			"or never_change_family. Numbers are compared by value, other values are compared as AWS, Azure or GCP instance types",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:           "recommended",
+				Description:    "value recommended by Turbonomic, E.G: the new_instance_type of a data source",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:           "current",
+				Description:    "current value of the resource, null when the resource does not exist",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:           "default",
+				Description:    "value used when neither the recommended nor the current value is set",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:        "policy",
+				Description: "one of any, upsize_only, downsize_only or never_change_family",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Returns the value allowed by the policy
+func (f *ChooseFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var recommended, current, def types.String
+	var policy string
+
+	resp.Error = req.Arguments.Get(ctx, &recommended, &current, &def, &policy)
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := chooseValue(recommended.ValueString(), current.ValueString(), def.ValueString(), policy)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	value := types.StringNull()
+	if len(result) != 0 {
+		value = types.StringValue(result)
+	}
+	resp.Error = resp.Result.Set(ctx, value)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChooseValue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		recommended string
+		current     string
+		def         string
+		policy      string
+		expected    string
+	}{
+		{name: "Any policy takes the recommendation", recommended: "m5.large", current: "m5.2xlarge", def: "t3.nano", policy: ChoosePolicyAny, expected: "m5.large"},
+		{name: "No recommendation keeps the current value", current: "m5.2xlarge", def: "t3.nano", policy: ChoosePolicyUpsizeOnly, expected: "m5.2xlarge"},
+		{name: "New resource takes the default", def: "t3.nano", policy: ChoosePolicyDownsizeOnly, expected: "t3.nano"},
+		{name: "New resource takes the recommendation", recommended: "t3.small", def: "t3.nano", policy: ChoosePolicyUpsizeOnly, expected: "t3.small"},
+		{name: "No value", policy: ChoosePolicyAny, expected: ""},
+		{name: "Upsize only allows an upsize", recommended: "m5.2xlarge", current: "m5.xlarge", policy: ChoosePolicyUpsizeOnly, expected: "m5.2xlarge"},
+		{name: "Upsize only rejects a downsize", recommended: "m5.large", current: "m5.xlarge", policy: ChoosePolicyUpsizeOnly, expected: "m5.xlarge"},
+		{name: "Upsize only rejects a same size family change", recommended: "t3.large", current: "m5.large", policy: ChoosePolicyUpsizeOnly, expected: "m5.large"},
+		{name: "Downsize only allows a downsize", recommended: "Standard_D2s_v3", current: "Standard_D4s_v3", policy: ChoosePolicyDownsizeOnly, expected: "Standard_D2s_v3"},
+		{name: "Downsize only rejects an upsize", recommended: "n2-standard-8", current: "n2-standard-4", policy: ChoosePolicyDownsizeOnly, expected: "n2-standard-4"},
+		{name: "Never change family allows a resize", recommended: "n2-standard-8", current: "n2-standard-4", policy: ChoosePolicyNeverChangeFamily, expected: "n2-standard-8"},
+		{name: "Never change family rejects a family change", recommended: "n2-highmem-4", current: "n2-standard-4", policy: ChoosePolicyNeverChangeFamily, expected: "n2-standard-4"},
+		{name: "Numbers are compared by value", recommended: "100", current: "250", policy: ChoosePolicyUpsizeOnly, expected: "250"},
+		{name: "Numbers have no family", recommended: "100", current: "250", policy: ChoosePolicyNeverChangeFamily, expected: "100"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := chooseValue(tc.recommended, tc.current, tc.def, tc.policy)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestChooseValueErrors(t *testing.T) {
+	_, err := chooseValue("m5.large", "m5.xlarge", "", "freeze")
+	assert.ErrorContains(t, err, `unknown policy "freeze"`)
+
+	_, err = chooseValue("gp3", "m5.xlarge", "", ChoosePolicyUpsizeOnly)
+	assert.ErrorContains(t, err, "unknown instance type: gp3")
+
+	_, err = chooseValue("Standard_D2s_v3", "m5.xlarge", "", ChoosePolicyDownsizeOnly)
+	assert.ErrorContains(t, err, "cannot compare azure instance type")
+}
+
+func TestChooseFunctionRun(t *testing.T) {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("m5.large"),
+			types.StringValue("m5.xlarge"),
+			types.StringNull(),
+			types.StringValue(ChoosePolicyUpsizeOnly),
+		}),
+	}
+	resp := function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+	NewChooseFunction().Run(context.Background(), req, &resp)
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, types.StringValue("m5.xlarge"), resp.Result.Value())
+
+	req = function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringNull(),
+			types.StringNull(),
+			types.StringNull(),
+			types.StringValue(ChoosePolicyAny),
+		}),
+	}
+	resp = function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+	NewChooseFunction().Run(context.Background(), req, &resp)
+	assert.Nil(t, resp.Error)
+	assert.True(t, resp.Result.Value().IsNull())
+}
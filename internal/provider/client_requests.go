@@ -0,0 +1,119 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+// clientRequests are the limiter and the trace recorder of the requests of the turbonomic client of
+// a provider configuration, and the session of the requests that the client does not support, shared
+// by its data sources
+type clientRequests struct {
+	limiter  *requestLimiter
+	recorder *traceRecorder
+	session  *apiSession
+}
+
+/*
+client returns the turbonomic client that sends the requests of a data source read under the request
+limits, records them in the trace file and deletes the entity tags with the session.
+
+Parameters:
+  - ctx: The context of the read, which stops waiting for the request limits once it is done
+  - client: The turbonomic client of the provider configuration
+
+Returns:
+  - turboclient.T8cClient: The client of the read, nil when the turbonomic client is nil
+*/
+func (r *clientRequests) client(ctx context.Context, client *turboclient.Client) turboclient.T8cClient {
+	if client == nil {
+		return nil
+	}
+	if r == nil {
+		r = &clientRequests{}
+	}
+	return &requestClient{T8cClient: client, ctx: ctx, requests: r}
+}
+
+// requestClient is a turbonomic client bound to the context of a data source read
+type requestClient struct {
+	turboclient.T8cClient
+	ctx      context.Context
+	requests *clientRequests
+}
+
+// entityTagDeleter is implemented by the clients that can delete a tag of an entity, which the
+// turbonomic client does not support
+type entityTagDeleter interface {
+	DeleteEntityTag(uuid string, key string) error
+}
+
+// sendRequest sends a request of the client under the request limits and records it in the trace file
+func sendRequest[Req any, Resp any](c *requestClient, operation string, request Req, send func(Req) (Resp, error)) (Resp, error) {
+	release, err := c.requests.limiter.wait(c.ctx)
+	if err != nil {
+		var response Resp
+		return response, fmt.Errorf("%s canceled while waiting for the request limits: %w", operation, err)
+	}
+	defer release()
+
+	start := time.Now()
+	response, err := send(request)
+	c.requests.recorder.recordOperation(operation, request, response, err, start)
+	return response, err
+}
+
+func (c *requestClient) SearchEntityByVendorId(searchReq turboclient.SearchRequestByVendorId) (turboclient.SearchResults, error) {
+	return sendRequest(c, "SearchEntityByVendorId", searchReq, c.T8cClient.SearchEntityByVendorId)
+}
+
+func (c *requestClient) GetStats(statsReq turboclient.StatsRequest) (turboclient.StatsResponse, error) {
+	return sendRequest(c, "GetStats", statsReq, c.T8cClient.GetStats)
+}
+
+func (c *requestClient) GetActionsByUUID(actionReq turboclient.ActionsRequest) (turboclient.ActionResults, error) {
+	return sendRequest(c, "GetActionsByUUID", actionReq, c.T8cClient.GetActionsByUUID)
+}
+
+func (c *requestClient) GetEntity(reqOpts turboclient.EntityRequest) (*turboclient.EntityResults, error) {
+	return sendRequest(c, "GetEntity", reqOpts, c.T8cClient.GetEntity)
+}
+
+func (c *requestClient) GetEntityTags(reqOpts turboclient.EntityRequest) ([]turboclient.Tag, error) {
+	return sendRequest(c, "GetEntityTags", reqOpts, c.T8cClient.GetEntityTags)
+}
+
+func (c *requestClient) TagEntity(reqOpts turboclient.TagEntityRequest) ([]turboclient.Tag, error) {
+	return sendRequest(c, "TagEntity", reqOpts, c.T8cClient.TagEntity)
+}
+
+func (c *requestClient) SearchEntities(searchCriteria turboclient.SearchDTO, reqParams turboclient.CommonReqParams) (turboclient.SearchResults, error) {
+	return sendRequest(c, "SearchEntities", searchCriteria, func(searchCriteria turboclient.SearchDTO) (turboclient.SearchResults, error) {
+		return c.T8cClient.SearchEntities(searchCriteria, reqParams)
+	})
+}
+
+func (c *requestClient) SearchEntityByName(searchReq turboclient.SearchRequest) (turboclient.SearchResults, error) {
+	return sendRequest(c, "SearchEntityByName", searchReq, c.T8cClient.SearchEntityByName)
+}
+
+// DeleteEntityTag deletes a tag of an entity with the session of the provider configuration, under the
+// request limits of the client
+func (c *requestClient) DeleteEntityTag(uuid string, key string) error {
+	if c.requests.session == nil {
+		return fmt.Errorf("unable to delete the tag %s of entity %s: the provider has no turbonomic session", key, uuid)
+	}
+
+	release, err := c.requests.limiter.wait(c.ctx)
+	if err != nil {
+		return fmt.Errorf("unable to delete the tag %s of entity %s: %w", key, uuid, err)
+	}
+	defer release()
+	return c.requests.session.deleteEntityTag(c.ctx, uuid, key)
+}
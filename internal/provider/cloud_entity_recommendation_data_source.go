@@ -31,10 +31,15 @@ type CloudEntityRecommendationEntityModel struct {
 	CurrentSize types.String `tfsdk:"current_instance_type"`
 	NewSize     types.String `tfsdk:"new_instance_type"`
 	DefaultSize types.String `tfsdk:"default_size"`
+
+	DisableEntityTagging types.Bool `tfsdk:"disable_entity_tagging"`
 }
 
 type CloudEntityRecommendationDataSource struct {
-	client *turboclient.Client
+	client     *turboclient.Client
+	requests   *clientRequests
+	tagConfig  TagConfig
+	serverInfo ServerInfo
 }
 
 func NewCloudEntityRecommendationDataSource() datasource.DataSource {
@@ -88,6 +93,8 @@ func (d *CloudEntityRecommendationDataSource) Schema(ctx context.Context, req da
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+
+			"disable_entity_tagging": disableEntityTaggingAttribute,
 		},
 	}
 }
@@ -97,17 +104,20 @@ func (d *CloudEntityRecommendationDataSource) Configure(_ context.Context, req d
 		return
 	}
 
-	client, ok := req.ProviderData.(*turboclient.Client)
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected: *turboclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected: *TurbonomicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+	d.tagConfig = providerData.TagConfig
+	d.serverInfo = providerData.ServerInfo
 }
 
 func (d *CloudEntityRecommendationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -127,7 +137,9 @@ func (d *CloudEntityRecommendationDataSource) Read(ctx context.Context, req data
 		return
 	}
 
-	entity, errDiag := GetEntitiesByName(d.client, WithEntityName(enName), WithEntityType(enTyp), WithEnvironmentType("CLOUD"))
+	client := d.requests.client(ctx, d.client)
+
+	entity, errDiag := GetEntitiesByName(client, WithEntityName(enName), WithEntityType(enTyp), WithEnvironmentType("CLOUD"))
 	if errDiag != nil {
 		tflog.Error(ctx, errDiag.Detail())
 		resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
@@ -161,7 +173,7 @@ func (d *CloudEntityRecommendationDataSource) Read(ctx context.Context, req data
 		return
 	}
 
-	actions, errDiag := GetActions(d.client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
+	actions, errDiag := GetActions(client, WithEntityUuid(entity[0].UUID), WithActionTypes([]string{"SCALE"}))
 	if errDiag != nil {
 		tflog.Error(ctx, errDiag.Detail())
 		resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
@@ -173,7 +185,7 @@ func (d *CloudEntityRecommendationDataSource) Read(ctx context.Context, req data
 		// if action doesn't exist, update new with default value
 		state.NewSize = state.CurrentSize
 
-		if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+		if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging)); err != nil {
 			resp.Diagnostics.AddError("error while tagging an entity", err.Error())
 		}
 
@@ -182,6 +194,7 @@ func (d *CloudEntityRecommendationDataSource) Read(ctx context.Context, req data
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("action id found: %d\n", actions[0].ActionID))
+	resp.Diagnostics.Append(capabilityDiagnostics(d.serverInfo, actionExecutionCapabilities...)...)
 
 	state, err = HandleCloudEntityRecommendationAction(ctx, resp, state, actions)
 	if err != nil {
@@ -202,7 +215,7 @@ func (d *CloudEntityRecommendationDataSource) Read(ctx context.Context, req data
 		state.DefaultSize,
 	)
 
-	if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+	if err := TagEntity(client, state.EntityUuid.ValueString(), d.tagConfig.WithOverride(state.DisableEntityTagging)); err != nil {
 		resp.Diagnostics.AddError("error while tagging an entity", err.Error())
 	}
 
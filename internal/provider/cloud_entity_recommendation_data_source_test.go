@@ -69,6 +69,12 @@ func TestCloudDataSource(t *testing.T) {
 			ResponseBody: loadTestFile(t, cloudTestDataBaseDir, validVmActionRespTestData),
 			ResponseCode: http.StatusOK,
 		},
+		{
+			// the recommendation of a virtual machine does not need its commodity stats
+			Method: http.MethodPost,
+			Path:   "/api/v3/stats/{id}",
+			Times:  NotCalled,
+		},
 	}, LoginAndTagRoutes(t)...))
 	defer mockServer.Close()
 
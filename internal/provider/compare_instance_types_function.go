@@ -0,0 +1,74 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &CompareInstanceTypesFunction{}
+
+type CompareInstanceTypesFunction struct{}
+
+func NewCompareInstanceTypesFunction() function.Function {
+	return &CompareInstanceTypesFunction{}
+}
+
+func (f *CompareInstanceTypesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "compare_instance_types"
+}
+
+func (f *CompareInstanceTypesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compare the size of two instance types",
+		Description: "Returns -1, 0 or 1 when the size of instance type a is smaller, equal or bigger than the size of instance type b, " +
+			"E.G: m5.2xlarge is bigger than m5.xlarge. AWS sizes are compared by normalization factor, Azure and GCP sizes by number of vCPUs",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cloud",
+				Description: "case insensitive cloud provider, one of aws, azure or gcp",
+			},
+			function.StringParameter{
+				Name:        "a",
+				Description: "first instance type, E.G: m5.2xlarge",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "second instance type, E.G: m5.xlarge",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+// Returns the comparison of the sizes of the instance types
+func (f *CompareInstanceTypesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cloud, a, b string
+
+	resp.Error = req.Arguments.Get(ctx, &cloud, &a, &b)
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := compareInstanceTypes(cloud, a, b)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, int64(result))
+}
@@ -0,0 +1,500 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+const (
+	// ComplianceCompliant is the status of a resource without a pending recommendation, or configured as recommended
+	ComplianceCompliant = "compliant"
+	// ComplianceRecommendationPending is the status of a resource with a recommendation that can be applied
+	ComplianceRecommendationPending = "recommendation_pending"
+	// ComplianceNotExecutable is the status of a resource with a recommendation that the action mode or
+	// the action schedule prevents from executing
+	ComplianceNotExecutable = "not_executable"
+	// ComplianceEntityUnknown is the status of a resource that does not match an entity of Turbonomic
+	ComplianceEntityUnknown = "entity_unknown"
+	// ComplianceError is the status of a resource that could not be checked
+	ComplianceError = "error"
+)
+
+// complianceAttributeTargets are the tier classes and the commodities that the steps of an action
+// change for each resource attribute, E.G: the instance_type of an aws_instance is its compute tier
+var complianceAttributeTargets = map[string][]string{
+	"instance_type":          {"ComputeTier"},
+	"instance_class":         {"ComputeTier"},
+	"machine_type":           {"ComputeTier"},
+	"size":                   {"ComputeTier", StorageAmount},
+	"sku_name":               {"DatabaseTier"},
+	"type":                   {"StorageTier"},
+	"storage_type":           {"StorageTier"},
+	"storage_account_type":   {"StorageTier"},
+	"iops":                   {StorageAccess},
+	"provisioned_iops":       {StorageAccess},
+	"disk_iops_read_write":   {StorageAccess},
+	"throughput":             {IOThroughput},
+	"provisioned_throughput": {IOThroughput},
+	"disk_mbps_read_write":   {IOThroughput},
+	"allocated_storage":      {StorageAmount},
+	"disk_size_gb":           {StorageAmount},
+}
+
+var (
+	_ datasource.DataSource              = &complianceReportDataSource{}
+	_ datasource.DataSourceWithConfigure = &complianceReportDataSource{}
+)
+
+func NewComplianceReportDataSource() datasource.DataSource {
+	return &complianceReportDataSource{}
+}
+
+// complianceReportDataSource defines the data source implementation.
+type complianceReportDataSource struct {
+	client     *turboclient.Client
+	requests   *clientRequests
+	serverInfo ServerInfo
+}
+
+// ComplianceReportModel describes the data source data model.
+type ComplianceReportModel struct {
+	Items   []ComplianceItemModel  `tfsdk:"items"`
+	Summary ComplianceSummaryModel `tfsdk:"summary"`
+}
+
+// ComplianceItemModel describes a resource of the workspace checked against the recommendations of its entity.
+type ComplianceItemModel struct {
+	ResourceAddress   types.String   `tfsdk:"resource_address"`
+	EntityUuid        types.String   `tfsdk:"entity_uuid"`
+	EntityName        types.String   `tfsdk:"entity_name"`
+	EntityType        types.String   `tfsdk:"entity_type"`
+	VendorId          types.String   `tfsdk:"vendor_id"`
+	Attribute         types.String   `tfsdk:"attribute"`
+	ConfiguredValue   types.String   `tfsdk:"configured_value"`
+	Status            types.String   `tfsdk:"status"`
+	Message           types.String   `tfsdk:"message"`
+	CurrentValue      types.String   `tfsdk:"current_value"`
+	RecommendedValues []types.String `tfsdk:"recommended_values"`
+	ActionId          types.Int64    `tfsdk:"action_id"`
+}
+
+// ComplianceSummaryModel describes the number of resources of each status.
+type ComplianceSummaryModel struct {
+	Total                 types.Int64 `tfsdk:"total"`
+	Compliant             types.Int64 `tfsdk:"compliant"`
+	RecommendationPending types.Int64 `tfsdk:"recommendation_pending"`
+	NotExecutable         types.Int64 `tfsdk:"not_executable"`
+	EntityUnknown         types.Int64 `tfsdk:"entity_unknown"`
+	Error                 types.Int64 `tfsdk:"error"`
+}
+
+func (d *complianceReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compliance_report"
+}
+
+func (d *complianceReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	lookupPaths := []path.Expression{
+		path.MatchRelative().AtParent().AtName("entity_uuid"),
+		path.MatchRelative().AtParent().AtName("entity_name"),
+		path.MatchRelative().AtParent().AtName("vendor_id"),
+	}
+
+	itemAttributes := map[string]schema.Attribute{
+		"resource_address": schema.StringAttribute{
+			MarkdownDescription: "address of the Terraform resource, E.G: aws_instance.web",
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"entity_uuid": schema.StringAttribute{
+			MarkdownDescription: "Turbonomic UUID of the entity of the resource, computed when the entity is looked up by name or vendor id. An entity_uuid that does not exist in Turbonomic gives the entity_unknown status",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(lookupPaths...),
+			},
+		},
+		"entity_name": schema.StringAttribute{
+			MarkdownDescription: "case sensitive name of the entity of the resource, requires entity_type",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("entity_type")),
+			},
+		},
+		"entity_type": schema.StringAttribute{
+			MarkdownDescription: "case insensitive type of the entity of the resource",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOfCaseInsensitive(slices.Collect(maps.Values(entityTypes))...),
+			},
+		},
+		"vendor_id": schema.StringAttribute{
+			MarkdownDescription: "vendor-provided identity of the entity of the resource, E.G: the AWS instance id",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"attribute": schema.StringAttribute{
+			MarkdownDescription: "name of the resource attribute that holds the configured value, E.G: instance_type. The configured value " +
+				"is compared with the steps of the action that change the tier or the commodity of the attribute, or with every step " +
+				"when the attribute is not set or not known",
+			Optional: true,
+		},
+		"configured_value": schema.StringAttribute{
+			MarkdownDescription: "value of the resource attribute in the configuration, the resource is compliant when it matches the recommended value",
+			Optional:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "compliance of the resource, one of: " + strings.Join([]string{ComplianceCompliant,
+				ComplianceRecommendationPending, ComplianceNotExecutable, ComplianceEntityUnknown, ComplianceError}, ", "),
+			Computed: true,
+		},
+		"message": schema.StringAttribute{
+			MarkdownDescription: "description of the status",
+			Computed:            true,
+		},
+		"current_value": schema.StringAttribute{
+			MarkdownDescription: "current value of the entity according to the pending recommendation",
+			Computed:            true,
+		},
+		"recommended_values": schema.ListAttribute{
+			MarkdownDescription: "values recommended by the pending action and its compound actions",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"action_id": schema.Int64Attribute{
+			MarkdownDescription: "id of the pending action",
+			Computed:            true,
+		},
+	}
+
+	summaryAttributes := map[string]schema.Attribute{
+		"total": schema.Int64Attribute{
+			MarkdownDescription: "number of checked resources",
+			Computed:            true,
+		},
+	}
+	for _, status := range []string{ComplianceCompliant, ComplianceRecommendationPending, ComplianceNotExecutable,
+		ComplianceEntityUnknown, ComplianceError} {
+		summaryAttributes[status] = schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("number of resources with the %s status", status),
+			Computed:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "The following example demonstrates the syntax for the `turbonomic_compliance_report` data source. This can be used to compare the configuration of the resources of a workspace with the pending Turbonomic recommendations from a single check block",
+		Attributes: map[string]schema.Attribute{
+			"items": schema.ListNestedAttribute{
+				MarkdownDescription: "resources to check, each identifies its entity by entity_uuid, vendor_id or entity_name and entity_type",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: itemAttributes,
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"summary": schema.SingleNestedAttribute{
+				MarkdownDescription: "number of resources of each status",
+				Computed:            true,
+				Attributes:          summaryAttributes,
+			},
+		},
+	}
+}
+
+func (d *complianceReportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected: *TurbonomicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+	d.serverInfo = providerData.ServerInfo
+}
+
+func (d *complianceReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ComplianceReportModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		for i := range state.Items {
+			setComplianceError(&state.Items[i], "the Turbonomic instance is unreachable, the resource was not checked")
+		}
+		state.Summary = summarizeCompliance(state.Items)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	client := d.requests.client(ctx, d.client)
+
+	checkedCapabilities := false
+	for i := range state.Items {
+		item := &state.Items[i]
+
+		uuid, errDiag := resolveComplianceEntity(client, entityLookup{
+			UUID:       item.EntityUuid,
+			EntityName: item.EntityName,
+			EntityType: item.EntityType,
+			VendorId:   item.VendorId,
+		})
+		if errDiag != nil {
+			setComplianceError(item, errDiag.Detail())
+			resp.Diagnostics.AddWarning("error while getting an entity", errDiag.Detail())
+			continue
+		} else if len(uuid) == 0 {
+			item.Status = types.StringValue(ComplianceEntityUnknown)
+			item.Message = types.StringValue(fmt.Sprintf("the entity of %s was not found in Turbonomic", item.ResourceAddress.ValueString()))
+			continue
+		}
+		item.EntityUuid = types.StringValue(uuid)
+
+		actions, errDiag := GetActions(client, WithEntityUuid(uuid), WithActionTypes([]string{"SCALE"}))
+		if errDiag != nil {
+			setComplianceError(item, errDiag.Detail())
+			resp.Diagnostics.AddWarning("error while getting an action", errDiag.Detail())
+			continue
+		}
+
+		if len(actions) > 0 && !checkedCapabilities {
+			resp.Diagnostics.Append(capabilityDiagnostics(d.serverInfo, actionExecutionCapabilities...)...)
+			checkedCapabilities = true
+		}
+		evaluateCompliance(item, actions)
+		tflog.Debug(ctx, fmt.Sprintf("compliance of %s: %s", item.ResourceAddress.ValueString(), item.Status.ValueString()))
+	}
+
+	state.Summary = summarizeCompliance(state.Items)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// resolveComplianceEntity returns the uuid of the entity of a resource, an entity_uuid is checked to
+// exist as it is not looked up, and an empty uuid is returned when no entity matches
+func resolveComplianceEntity(client turboclient.T8cClient, lookup entityLookup) (string, *diag.ErrorDiagnostic) {
+	if lookup.UUID.IsNull() {
+		return resolveEntityUuid(client, lookup)
+	}
+
+	entityReq := turboclient.EntityRequest{Uuid: lookup.UUID.ValueString()}
+	_, err := client.GetEntity(entityReq)
+	if isEntityNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		errDiag := diag.NewErrorDiagnostic("Unable to get entity from Turbonomic", err.Error())
+		return "", &errDiag
+	}
+	return lookup.UUID.ValueString(), nil
+}
+
+// turboAPIError is the body of an error response of the Turbonomic API
+type turboAPIError struct {
+	Type      string `json:"type"`
+	Exception string `json:"exception"`
+	Message   string `json:"message"`
+}
+
+// isEntityNotFound returns whether an error of the turbonomic client is the UnknownObjectException of
+// an entity that does not exist. The client does not expose the status of a failed response and
+// returns its body as the message, so any other error, even one that reads "not found", is a failure.
+func isEntityNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr turboAPIError
+	if json.Unmarshal([]byte(err.Error()), &apiErr) != nil {
+		return false
+	}
+	return apiErr.Type == "Error" && apiErr.Exception == "UnknownObjectException"
+}
+
+/*
+evaluateCompliance sets the status of a resource from the pending actions of its entity. The attribute
+of the resource selects the steps of the action that change it, a resource is compliant when its
+entity has no pending action, when no step changes its attribute or when its configured value is one
+of the values recommended by the steps, otherwise canExecuteAction tells a pending recommendation from
+one that the action mode or schedule prevents from executing.
+
+Parameters:
+  - item: The resource, updated with its status
+  - actions: The pending actions of the entity of the resource
+*/
+func evaluateCompliance(item *ComplianceItemModel, actions turboclient.ActionResults) {
+	item.CurrentValue = types.StringNull()
+	item.RecommendedValues = nil
+	item.ActionId = types.Int64Null()
+
+	if len(actions) == 0 {
+		item.Status = types.StringValue(ComplianceCompliant)
+		item.Message = types.StringValue("no pending Turbonomic recommendation")
+		return
+	}
+
+	steps := selectActionSteps(actionSteps(actions), item.Attribute.ValueString())
+	if len(steps) == 0 {
+		item.Status = types.StringValue(ComplianceCompliant)
+		item.Message = types.StringValue(fmt.Sprintf("the pending Turbonomic recommendation does not change %s", describeComplianceItem(item)))
+		return
+	}
+
+	recommended := recommendedValues(steps)
+	if steps[0].current != "" {
+		item.CurrentValue = types.StringValue(steps[0].current)
+	}
+	item.RecommendedValues = stringValues(recommended)
+	item.ActionId = types.Int64Value(actions[0].ActionID)
+
+	configured := item.ConfiguredValue.ValueString()
+	if !item.ConfiguredValue.IsNull() && slices.ContainsFunc(recommended, func(value string) bool {
+		return strings.EqualFold(value, configured)
+	}) {
+		item.Status = types.StringValue(ComplianceCompliant)
+		item.Message = types.StringValue(fmt.Sprintf("%s is configured with the recommended value %s", describeComplianceItem(item), configured))
+		return
+	}
+
+	if canExecute, executeMsg := canExecuteAction(actions); !canExecute {
+		item.Status = types.StringValue(ComplianceNotExecutable)
+		item.Message = types.StringValue(executeMsg)
+		return
+	}
+
+	item.Status = types.StringValue(ComplianceRecommendationPending)
+	if item.ConfiguredValue.IsNull() {
+		item.Message = types.StringValue(fmt.Sprintf("Turbonomic recommends %s for %s", strings.Join(recommended, ", "), describeComplianceItem(item)))
+	} else {
+		item.Message = types.StringValue(fmt.Sprintf("%s is %s, Turbonomic recommends %s", describeComplianceItem(item), configured, strings.Join(recommended, ", ")))
+	}
+}
+
+// actionStep is the change of a tier, or the resize of a commodity, made by an action or by one of its
+// compound actions
+type actionStep struct {
+	tierClass   string
+	commodities []string
+	current     string
+	recommended string
+}
+
+// newActionStep returns the step that changes the tiers of an action, or that resizes the reason
+// commodities of the action to the new value when it does not change a tier
+func newActionStep(currentTier, newTier, tierClass string, currentValue, newValue string, commodities []string) actionStep {
+	if newTier == "" {
+		return actionStep{commodities: commodities, current: currentValue, recommended: newValue}
+	}
+	return actionStep{tierClass: tierClass, current: currentTier, recommended: newTier}
+}
+
+// actionSteps returns the steps of the first action, followed by the steps of its compound actions
+func actionSteps(actions turboclient.ActionResults) []actionStep {
+	action := actions[0]
+	current := action.CurrentEntity.DisplayName
+	if current == "" {
+		current = action.CurrentValue
+	}
+	steps := []actionStep{newActionStep(current, action.NewEntity.DisplayName, action.NewEntity.ClassName,
+		current, action.NewValue, action.Risk.ReasonCommodities)}
+
+	for _, compound := range action.CompoundActions {
+		steps = append(steps, newActionStep(compound.CurrentEntity.DisplayName, compound.NewEntity.DisplayName,
+			compound.NewEntity.ClassName, compound.CurrentValue, compound.NewValue, compound.Risk.ReasonCommodities))
+	}
+	return steps
+}
+
+// selectActionSteps returns the steps that change the tier or the commodity of a resource attribute,
+// or every step when the attribute is not set or not known
+func selectActionSteps(steps []actionStep, attribute string) []actionStep {
+	targets, ok := complianceAttributeTargets[attribute]
+	if !ok {
+		return steps
+	}
+
+	selected := []actionStep{}
+	for _, step := range steps {
+		if slices.Contains(targets, step.tierClass) || slices.ContainsFunc(step.commodities, func(commodity string) bool {
+			return slices.Contains(targets, commodity)
+		}) {
+			selected = append(selected, step)
+		}
+	}
+	return selected
+}
+
+// recommendedValues returns the values recommended by the steps of an action
+func recommendedValues(steps []actionStep) []string {
+	values := []string{}
+	for _, step := range steps {
+		if step.recommended != "" && !slices.Contains(values, step.recommended) {
+			values = append(values, step.recommended)
+		}
+	}
+	return values
+}
+
+// describeComplianceItem returns the resource address of an item, followed by its attribute when set
+func describeComplianceItem(item *ComplianceItemModel) string {
+	if item.Attribute.ValueString() == "" {
+		return item.ResourceAddress.ValueString()
+	}
+	return item.ResourceAddress.ValueString() + "." + item.Attribute.ValueString()
+}
+
+// setComplianceError sets the status of a resource that could not be checked
+func setComplianceError(item *ComplianceItemModel, detail string) {
+	item.Status = types.StringValue(ComplianceError)
+	item.Message = types.StringValue(detail)
+}
+
+// summarizeCompliance counts the resources of each status
+func summarizeCompliance(items []ComplianceItemModel) ComplianceSummaryModel {
+	counts := map[string]int64{}
+	for _, item := range items {
+		counts[item.Status.ValueString()]++
+	}
+
+	return ComplianceSummaryModel{
+		Total:                 types.Int64Value(int64(len(items))),
+		Compliant:             types.Int64Value(counts[ComplianceCompliant]),
+		RecommendationPending: types.Int64Value(counts[ComplianceRecommendationPending]),
+		NotExecutable:         types.Int64Value(counts[ComplianceNotExecutable]),
+		EntityUnknown:         types.Int64Value(counts[ComplianceEntityUnknown]),
+		Error:                 types.Int64Value(counts[ComplianceError]),
+	}
+}
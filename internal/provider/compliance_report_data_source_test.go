@@ -0,0 +1,464 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	turboclient "github.com/IBM/turbonomic-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	complianceActionPendingResponse   = "action_pending_response.json"
+	complianceActionRecommendResponse = "action_recommend_response.json"
+	complianceReportDataSourceName    = "data.turbonomic_compliance_report.test"
+	deletedEntityUuid                 = "75930461864809"
+)
+
+// Test compliance report data source with a resource of each status found by uuid and by vendor id
+func TestComplianceReportDataSource(t *testing.T) {
+
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			ResponseBody: loadTestFile(t, entityTestDataBaseDir, entitySearchResponse),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodGet,
+			Path:         "/api/v3/entities/{id}",
+			ResponseBody: loadTestFile(t, entityTestDataBaseDir, entityGetResponse),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/entities/75930461864801/actions",
+			ResponseBody: loadTestFile(t, complianceReportTestDataBaseDir, complianceActionPendingResponse),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/entities/75930461864802/actions",
+			ResponseBody: loadTestFile(t, complianceReportTestDataBaseDir, complianceActionRecommendResponse),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/entities/{id}/actions",
+			ResponseBody: loadTestFile(t, entityActionSearchResponseEmpty),
+			ResponseCode: http.StatusOK,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	providerConfig := fmt.Sprintf(config, strings.TrimPrefix(mockServer.URL, "https://"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig +
+					`data "turbonomic_compliance_report" "test" {
+						items = [
+							{
+								resource_address = "aws_instance.payments_1"
+								entity_uuid      = "75930461864801"
+								attribute        = "instance_type"
+								configured_value = "t2.micro"
+							},
+							{
+								resource_address = "aws_instance.payments_2"
+								entity_uuid      = "75930461864802"
+								attribute        = "instance_type"
+								configured_value = "m5.large"
+							},
+							{
+								resource_address = "aws_instance.payments_3"
+								entity_uuid      = "75930461864803"
+							},
+							{
+								resource_address = "aws_instance.payments_1_copy"
+								vendor_id        = "i-0a1b2c3d4e5f60001"
+								attribute        = "instance_type"
+								configured_value = "T3.MICRO"
+							},
+						]
+				    }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.status", ComplianceRecommendationPending),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.message",
+						"aws_instance.payments_1.instance_type is t2.micro, Turbonomic recommends t3.micro"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.current_value", "t2.micro"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.recommended_values.0", "t3.micro"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.action_id", "638917183109201"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.1.status", ComplianceNotExecutable),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.1.message",
+						"actionMode is set to RECOMMEND, turbonomic action is not executable"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.2.status", ComplianceCompliant),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.2.recommended_values.#", "0"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.3.entity_uuid", entityUuid),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.3.status", ComplianceCompliant),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.total", "4"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.compliant", "2"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.recommendation_pending", "1"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.not_executable", "1"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.entity_unknown", "0"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.error", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Test compliance report data source where the entity of a resource does not exist, looked up by
+// name or given by a uuid
+func TestComplianceReportDataSourceEntityUnknown(t *testing.T) {
+
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			ResponseBody: loadTestFile(t, entityActionSearchResponseEmpty),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodGet,
+			Path:         "/api/v3/entities/" + deletedEntityUuid,
+			ResponseBody: `{"type":"Error","exception":"UnknownObjectException","message":"Entity not found: ` + deletedEntityUuid + `"}`,
+			ResponseCode: http.StatusNotFound,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/v3/entities/{id}/actions",
+			Times:  NotCalled,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	providerConfig := fmt.Sprintf(config, strings.TrimPrefix(mockServer.URL, "https://"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig +
+					`data "turbonomic_compliance_report" "test" {
+						items = [
+							{
+								resource_address = "aws_instance.web"
+								entity_name      = "` + nonExistingEntity + `"
+								entity_type      = "VirtualMachine"
+							},
+							{
+								resource_address = "aws_instance.deleted"
+								entity_uuid      = "` + deletedEntityUuid + `"
+							},
+						]
+				    }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.status", ComplianceEntityUnknown),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.message",
+						"the entity of aws_instance.web was not found in Turbonomic"),
+					resource.TestCheckNoResourceAttr(complianceReportDataSourceName, "items.0.entity_uuid"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.1.status", ComplianceEntityUnknown),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.1.message",
+						"the entity of aws_instance.deleted was not found in Turbonomic"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.1.entity_uuid", deletedEntityUuid),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.total", "2"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.entity_unknown", "2"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.error", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Test compliance report data source giving the error status to every resource when the Turbonomic instance is unreachable
+func TestComplianceReportDataSourceUnreachable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `provider "turbonomic" {
+						hostname          = "invalid-hostname"
+						username          = "testuser"
+						password          = "password"
+						skipverify        = true
+						allow_unreachable = true
+					}
+					data "turbonomic_compliance_report" "test" {
+						items = [
+							{
+								resource_address = "aws_instance.web"
+								entity_uuid      = "` + entityUuid + `"
+							},
+							{
+								resource_address = "aws_instance.api"
+								vendor_id        = "i-0a1b2c3d4e5f60001"
+							},
+						]
+				    }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.status", ComplianceError),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.0.message",
+						"the Turbonomic instance is unreachable, the resource was not checked"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "items.1.status", ComplianceError),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.total", "2"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.error", "2"),
+					resource.TestCheckResourceAttr(complianceReportDataSourceName, "summary.compliant", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Test that an entity_uuid is checked to exist and that only a not found response gives an unknown entity
+func TestResolveComplianceEntity(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedUuid string
+		expectError  bool
+	}{
+		{
+			name:         "Existing entity",
+			expectedUuid: entityUuid,
+		},
+		{
+			name: "Entity not found",
+			err:  errors.New(`{"type":"Error","exception":"UnknownObjectException","message":"Entity not found: 75930461864801"}`),
+		},
+		{
+			name:        "Request failed",
+			err:         errors.New(`{"type":"Error","exception":"Internal Server Error","message":"the repository is unavailable"}`),
+			expectError: true,
+		},
+		{
+			name:        "Unrelated not found error",
+			err:         errors.New(`{"type":"Error","exception":"Internal Server Error","message":"market not found"}`),
+			expectError: true,
+		},
+		{
+			name:        "Not found response of a proxy",
+			err:         errors.New("404 page not found"),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := new(MockT8cClient)
+			client.On("GetEntity", turboclient.EntityRequest{Uuid: entityUuid}).Return(&turboclient.EntityResults{UUID: entityUuid}, tc.err).Once()
+
+			uuid, errDiag := resolveComplianceEntity(client, entityLookup{UUID: types.StringValue(entityUuid)})
+			assert.Equal(t, tc.expectedUuid, uuid)
+			assert.Equal(t, tc.expectError, errDiag != nil)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEvaluateCompliance(t *testing.T) {
+	scaleAction := func(mode string) turboclient.ActionResults {
+		actions := turboclient.ActionResults{{
+			ActionID:               638917183109201,
+			ActionMode:             mode,
+			ActionStateDescription: "READY_ACCEPT_AND_EXECUTE",
+		}}
+		actions[0].CurrentEntity.DisplayName = "t2.micro"
+		actions[0].NewEntity.DisplayName = "t3.micro"
+		actions[0].NewEntity.ClassName = "ComputeTier"
+		return actions
+	}
+	compoundAction := scaleAction("MANUAL")
+	compoundAction[0].NewEntity.DisplayName = ""
+	compoundAction[0].NewEntity.ClassName = ""
+	compoundAction[0].CompoundActions = []turboCompoundAction{{}, {}, {}}
+	compoundAction[0].CompoundActions[0].NewEntity.DisplayName = "t3.micro"
+	compoundAction[0].CompoundActions[0].NewEntity.ClassName = "ComputeTier"
+	compoundAction[0].CompoundActions[1].CurrentEntity.DisplayName = "gp2"
+	compoundAction[0].CompoundActions[1].NewEntity.DisplayName = "gp3"
+	compoundAction[0].CompoundActions[1].NewEntity.ClassName = "StorageTier"
+	compoundAction[0].CompoundActions[2].CurrentValue = "100"
+	compoundAction[0].CompoundActions[2].NewValue = "3000"
+	compoundAction[0].CompoundActions[2].Risk.ReasonCommodities = []string{StorageAccess}
+
+	tests := []struct {
+		name             string
+		attribute        string
+		configuredValue  types.String
+		actions          turboclient.ActionResults
+		expectedStatus   string
+		expectedMsg      string
+		expectedValues   []string
+		expectedCurrent  types.String
+		expectedActionId types.Int64
+	}{
+		{
+			name:             "No pending action",
+			configuredValue:  types.StringValue("t2.micro"),
+			expectedStatus:   ComplianceCompliant,
+			expectedMsg:      "no pending Turbonomic recommendation",
+			expectedCurrent:  types.StringNull(),
+			expectedActionId: types.Int64Null(),
+		},
+		{
+			name:             "Configured with the recommended value",
+			attribute:        "instance_type",
+			configuredValue:  types.StringValue("t3.micro"),
+			actions:          scaleAction("MANUAL"),
+			expectedStatus:   ComplianceCompliant,
+			expectedMsg:      "aws_instance.web.instance_type is configured with the recommended value t3.micro",
+			expectedValues:   []string{"t3.micro"},
+			expectedCurrent:  types.StringValue("t2.micro"),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+		{
+			name:             "Configured with a value recommended by a compound action",
+			configuredValue:  types.StringValue("GP3"),
+			actions:          compoundAction,
+			expectedStatus:   ComplianceCompliant,
+			expectedMsg:      "aws_instance.web is configured with the recommended value GP3",
+			expectedValues:   []string{"t3.micro", "gp3", "3000"},
+			expectedCurrent:  types.StringValue("t2.micro"),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+		{
+			name:             "Attribute selects the tier of a compound action",
+			attribute:        "type",
+			configuredValue:  types.StringValue("gp2"),
+			actions:          compoundAction,
+			expectedStatus:   ComplianceRecommendationPending,
+			expectedMsg:      "aws_instance.web.type is gp2, Turbonomic recommends gp3",
+			expectedValues:   []string{"gp3"},
+			expectedCurrent:  types.StringValue("gp2"),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+		{
+			name:             "Value recommended for another attribute",
+			attribute:        "instance_type",
+			configuredValue:  types.StringValue("gp3"),
+			actions:          compoundAction,
+			expectedStatus:   ComplianceRecommendationPending,
+			expectedMsg:      "aws_instance.web.instance_type is gp3, Turbonomic recommends t3.micro",
+			expectedValues:   []string{"t3.micro"},
+			expectedCurrent:  types.StringNull(),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+		{
+			name:             "Attribute selects the commodity of a compound action",
+			attribute:        "iops",
+			configuredValue:  types.StringValue("3000"),
+			actions:          compoundAction,
+			expectedStatus:   ComplianceCompliant,
+			expectedMsg:      "aws_instance.web.iops is configured with the recommended value 3000",
+			expectedValues:   []string{"3000"},
+			expectedCurrent:  types.StringValue("100"),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+		{
+			name:             "Attribute not changed by the action",
+			attribute:        "iops",
+			configuredValue:  types.StringValue("100"),
+			actions:          scaleAction("AUTOMATIC"),
+			expectedStatus:   ComplianceCompliant,
+			expectedMsg:      "the pending Turbonomic recommendation does not change aws_instance.web.iops",
+			expectedCurrent:  types.StringNull(),
+			expectedActionId: types.Int64Null(),
+		},
+		{
+			name:             "Unknown attribute compared with every step",
+			attribute:        "tier",
+			configuredValue:  types.StringValue("gp3"),
+			actions:          compoundAction,
+			expectedStatus:   ComplianceCompliant,
+			expectedMsg:      "aws_instance.web.tier is configured with the recommended value gp3",
+			expectedValues:   []string{"t3.micro", "gp3", "3000"},
+			expectedCurrent:  types.StringValue("t2.micro"),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+		{
+			name:             "Recommendation pending",
+			attribute:        "instance_type",
+			configuredValue:  types.StringValue("t2.micro"),
+			actions:          scaleAction("AUTOMATIC"),
+			expectedStatus:   ComplianceRecommendationPending,
+			expectedMsg:      "aws_instance.web.instance_type is t2.micro, Turbonomic recommends t3.micro",
+			expectedValues:   []string{"t3.micro"},
+			expectedCurrent:  types.StringValue("t2.micro"),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+		{
+			name:             "Recommendation pending without configured value",
+			configuredValue:  types.StringNull(),
+			actions:          compoundAction,
+			expectedStatus:   ComplianceRecommendationPending,
+			expectedMsg:      "Turbonomic recommends t3.micro, gp3, 3000 for aws_instance.web",
+			expectedValues:   []string{"t3.micro", "gp3", "3000"},
+			expectedCurrent:  types.StringValue("t2.micro"),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+		{
+			name:             "RECOMMEND action mode",
+			configuredValue:  types.StringValue("t2.micro"),
+			actions:          scaleAction("RECOMMEND"),
+			expectedStatus:   ComplianceNotExecutable,
+			expectedMsg:      "actionMode is set to RECOMMEND, turbonomic action is not executable",
+			expectedValues:   []string{"t3.micro"},
+			expectedCurrent:  types.StringValue("t2.micro"),
+			expectedActionId: types.Int64Value(638917183109201),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			item := ComplianceItemModel{
+				ResourceAddress: types.StringValue("aws_instance.web"),
+				Attribute:       types.StringValue(tc.attribute),
+				ConfiguredValue: tc.configuredValue,
+			}
+			if tc.attribute == "" {
+				item.Attribute = types.StringNull()
+			}
+
+			evaluateCompliance(&item, tc.actions)
+
+			assert.Equal(t, tc.expectedStatus, item.Status.ValueString())
+			assert.Equal(t, tc.expectedMsg, item.Message.ValueString())
+			assert.Equal(t, tc.expectedCurrent, item.CurrentValue)
+			assert.Equal(t, tc.expectedActionId, item.ActionId)
+			if tc.expectedValues == nil {
+				assert.Nil(t, item.RecommendedValues)
+			} else {
+				assert.Equal(t, stringValues(tc.expectedValues), item.RecommendedValues)
+			}
+		})
+	}
+}
+
+func TestSummarizeCompliance(t *testing.T) {
+	var items []ComplianceItemModel
+	for _, status := range []string{ComplianceCompliant, ComplianceCompliant, ComplianceRecommendationPending,
+		ComplianceNotExecutable, ComplianceEntityUnknown, ComplianceError, ComplianceCompliant} {
+		items = append(items, ComplianceItemModel{Status: types.StringValue(status)})
+	}
+
+	assert.Equal(t, ComplianceSummaryModel{
+		Total:                 types.Int64Value(7),
+		Compliant:             types.Int64Value(3),
+		RecommendationPending: types.Int64Value(1),
+		NotExecutable:         types.Int64Value(1),
+		EntityUnknown:         types.Int64Value(1),
+		Error:                 types.Int64Value(1),
+	}, summarizeCompliance(items))
+	assert.Equal(t, types.Int64Value(0), summarizeCompliance(nil).Total)
+}
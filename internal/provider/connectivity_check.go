@@ -0,0 +1,251 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	AllowUnreachableEnvVar = "TURBO_ALLOW_UNREACHABLE"
+
+	loginPath       = "/api/v3/login"
+	versionInfoPath = "/api/v3/admin/versioninfo"
+)
+
+// Summaries of the connectivity check diagnostics
+const (
+	BadCredentialsSummary     = "invalid turbonomic api credentials"
+	UnreachableSummary        = "unable to reach the turbonomic api"
+	TLSFailureSummary         = "unable to verify the turbonomic api certificate"
+	UnexpectedResponseSummary = "unexpected turbonomic api response"
+)
+
+// connectivityError is a failure of the connectivity check, its summary tells apart bad
+// credentials, an unreachable host, a TLS failure and an unexpected response
+type connectivityError struct {
+	Summary string
+	Err     error
+}
+
+func (e *connectivityError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *connectivityError) Unwrap() error {
+	return e.Err
+}
+
+// serverVersion is the response of the Turbonomic version endpoint
+type serverVersion struct {
+	Version     string `json:"version"`
+	VersionInfo string `json:"versionInfo"`
+}
+
+// connectivityCredentials are the credentials used to log in by the connectivity check
+type connectivityCredentials struct {
+	Username string
+	Password string
+	OAuth    OAuthConfig
+}
+
+/*
+checkConnectivity logs in to Turbonomic and reads the version of the instance, so that the provider
+fails fast when the instance is unreachable or rejects the credentials.
+
+Parameters:
+  - ctx: The context of the requests
+  - httpClient: The HTTP client of the requests
+  - hostname: The hostname of the Turbonomic instance
+  - creds: The username and password, or the OAuth 2.0 client when the username is empty
+
+Returns:
+  - serverVersion: The version of the Turbonomic instance
+  - error: A *connectivityError that classifies the failure
+*/
+func checkConnectivity(ctx context.Context, httpClient *http.Client, hostname string, creds connectivityCredentials) (serverVersion, error) {
+	// the login session is kept in a cookie, use a client of the check only
+	checkClient := *httpClient
+	checkClient.Jar, _ = cookiejar.New(nil)
+
+	authorization, err := logIn(ctx, &checkClient, hostname, creds)
+	if err != nil {
+		return serverVersion{}, err
+	}
+	return readServerVersion(ctx, &checkClient, hostname, authorization)
+}
+
+// readServerVersion reads the version of the Turbonomic instance with the login of the HTTP client,
+// or with the Authorization header of an access token
+func readServerVersion(ctx context.Context, httpClient *http.Client, hostname string, authorization string) (serverVersion, error) {
+	var version serverVersion
+
+	versionUrl := url.URL{Scheme: "https", Host: hostname, Path: versionInfoPath}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, versionUrl.String(), nil)
+	if err != nil {
+		return version, &connectivityError{UnexpectedResponseSummary, err}
+	}
+	req.Header.Set("Accept", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	body, err := doConnectivityRequest(httpClient, req, "read the turbonomic version")
+	if err != nil {
+		return version, err
+	}
+	if err := json.Unmarshal(body, &version); err != nil {
+		return version, &connectivityError{UnexpectedResponseSummary,
+			fmt.Errorf("unable to parse the response of %s: %v", versionInfoPath, err)}
+	}
+
+	return version, nil
+}
+
+/*
+logIn logs in to Turbonomic with a username and password, which keeps the session in the cookie jar
+of the HTTP client, or requests an access token of the OAuth 2.0 client.
+
+Returns:
+  - string: The Authorization header of the following requests, empty for a login session
+  - error: A *connectivityError that classifies the failure
+*/
+func logIn(ctx context.Context, httpClient *http.Client, hostname string, creds connectivityCredentials) (string, error) {
+	if creds.Username == "" {
+		token, err := requestAccessToken(ctx, httpClient, hostname, creds.OAuth)
+		if err != nil {
+			return "", classifyConnectivityError(err)
+		}
+		return "Bearer " + token.AccessToken, nil
+	}
+
+	form := url.Values{"username": {creds.Username}, "password": {creds.Password}}
+	loginUrl := url.URL{Scheme: "https", Host: hostname, Path: loginPath}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, loginUrl.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", &connectivityError{UnexpectedResponseSummary, err}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	_, err = doConnectivityRequest(httpClient, req, "login as "+creds.Username)
+	return "", err
+}
+
+// doConnectivityRequest sends a request of the connectivity check and returns the body of a successful response
+func doConnectivityRequest(httpClient *http.Client, req *http.Request, operation string) ([]byte, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, classifyConnectivityError(fmt.Errorf("unable to %s: %w", operation, err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &connectivityError{UnreachableSummary, fmt.Errorf("unable to %s: %w", operation, err)}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, &connectivityError{BadCredentialsSummary,
+			fmt.Errorf("unable to %s: turbonomic responded with status %d", operation, resp.StatusCode)}
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, &connectivityError{UnexpectedResponseSummary,
+			fmt.Errorf("unable to %s: turbonomic responded with status %d: %s", operation, resp.StatusCode, strings.TrimSpace(string(body)))}
+	}
+	return body, nil
+}
+
+// classifyConnectivityError returns the connectivity error of a failed request, such as an
+// error of the turbonomic client or of the access token request
+func classifyConnectivityError(err error) *connectivityError {
+	var connErr *connectivityError
+	if errors.As(err, &connErr) {
+		return connErr
+	}
+
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	var verificationErr *tls.CertificateVerificationError
+	var recordHeaderErr tls.RecordHeaderError
+	switch {
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr), errors.As(err, &invalidCert),
+		errors.As(err, &verificationErr), errors.As(err, &recordHeaderErr):
+		return &connectivityError{TLSFailureSummary, err}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return &connectivityError{UnreachableSummary, err}
+	}
+
+	// the token endpoint rejects an invalid client with a bad request or unauthorized status
+	var rejectedErr *accessTokenRejectedError
+	if errors.As(err, &rejectedErr) {
+		switch rejectedErr.StatusCode {
+		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+			return &connectivityError{BadCredentialsSummary, err}
+		}
+	}
+
+	// the errors of the turbonomic client do not always wrap the error of the request
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "x509:") || strings.Contains(message, "tls:"):
+		return &connectivityError{TLSFailureSummary, err}
+	case strings.Contains(message, "no such host") || strings.Contains(message, "connection refused") ||
+		strings.Contains(message, "i/o timeout") || strings.Contains(message, "network is unreachable"):
+		return &connectivityError{UnreachableSummary, err}
+	}
+	return &connectivityError{UnexpectedResponseSummary, err}
+}
+
+// connectivityHints are the remediation hints of the connectivity check diagnostics
+var connectivityHints = map[string]string{
+	BadCredentialsSummary: "check the username and password, or the client_id, client_secret and role of the OAuth 2.0 client.",
+	UnreachableSummary: "check the hostname and the network access to the Turbonomic instance. set allow_unreachable = true " +
+		"to use the default values of the data sources when Turbonomic is unavailable.",
+	TLSFailureSummary: "check the certificate of the Turbonomic instance, add the certificate authorities that sign it " +
+		"to the trust store of the system, or set skipverify = true. ca_file only applies to turbonomic_access_token.",
+}
+
+/*
+connectivityDiagnostic returns the diagnostic of a failed connectivity check, an error unless
+allow_unreachable is set.
+
+Parameters:
+  - err: The error of the turbonomic client or of the connectivity check
+  - allowUnreachable: Whether the data sources can use their default values when Turbonomic is unavailable
+
+Returns:
+  - diag.Diagnostic: An error diagnostic, or a warning diagnostic when allowUnreachable is set
+*/
+func connectivityDiagnostic(err error, allowUnreachable bool) diag.Diagnostic {
+	connErr := classifyConnectivityError(err)
+
+	detail := connErr.Error()
+	if hint, ok := connectivityHints[connErr.Summary]; ok {
+		detail += "\n\n" + hint
+	}
+
+	if allowUnreachable {
+		return diag.NewWarningDiagnostic(connErr.Summary,
+			detail+"\n\nallow_unreachable is set, the data sources return their default values.")
+	}
+	return diag.NewErrorDiagnostic(connErr.Summary, detail)
+}
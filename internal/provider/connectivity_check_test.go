@@ -0,0 +1,139 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests the classification of the connectivity check failures
+func TestCheckConnectivity(t *testing.T) {
+	userCreds := connectivityCredentials{Username: "testuser", Password: "password"}
+	oauthCreds := connectivityCredentials{OAuth: OAuthConfig{ClientId: "12345", ClientSecret: "s3cr3t", Role: "OBSERVER"}}
+
+	tests := []struct {
+		name            string
+		routes          []MockRoute
+		creds           connectivityCredentials
+		skipverify      bool
+		hostname        string
+		expectedSummary string
+	}{
+		{
+			name: "username and password",
+			routes: []MockRoute{
+				{
+					Method: http.MethodPost, Path: loginPath, ResponseCode: http.StatusOK, ResponseBody: `{"status":"ok"}`,
+					Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"}, Times: 1,
+				},
+				{Method: http.MethodGet, Path: versionInfoPath, ResponseCode: http.StatusOK, ResponseBody: versionInfoRespTestData, Times: 1},
+			},
+			creds:      userCreds,
+			skipverify: true,
+		},
+		{
+			name: "oauth client",
+			routes: []MockRoute{
+				{Method: http.MethodPost, Path: accessTokenPath, ResponseCode: http.StatusOK, ResponseBody: accessTokenRespTestData, Times: 1},
+				{
+					Method: http.MethodGet, Path: versionInfoPath, ResponseCode: http.StatusOK, ResponseBody: versionInfoRespTestData,
+					Headers: map[string]string{"Authorization": "Bearer eyJhbGciOi"}, Times: 1,
+				},
+			},
+			creds:      oauthCreds,
+			skipverify: true,
+		},
+		{
+			name: "bad password",
+			routes: []MockRoute{
+				{Method: http.MethodPost, Path: loginPath, ResponseCode: http.StatusUnauthorized, Times: 1},
+				{Method: http.MethodGet, Path: versionInfoPath, Times: NotCalled},
+			},
+			creds:           userCreds,
+			skipverify:      true,
+			expectedSummary: BadCredentialsSummary,
+		},
+		{
+			name: "bad client secret",
+			routes: []MockRoute{
+				{Method: http.MethodPost, Path: accessTokenPath, ResponseCode: http.StatusUnauthorized, ResponseBody: `{"error":"invalid_client"}`},
+			},
+			creds:           oauthCreds,
+			skipverify:      true,
+			expectedSummary: BadCredentialsSummary,
+		},
+		{
+			name:            "unreachable host",
+			creds:           userCreds,
+			skipverify:      true,
+			hostname:        "invalid-hostname.invalid",
+			expectedSummary: UnreachableSummary,
+		},
+		{
+			name: "untrusted certificate",
+			routes: []MockRoute{
+				{Method: http.MethodPost, Path: loginPath, ResponseCode: http.StatusOK},
+			},
+			creds:           userCreds,
+			expectedSummary: TLSFailureSummary,
+		},
+		{
+			name: "version endpoint failure",
+			routes: []MockRoute{
+				{Method: http.MethodPost, Path: loginPath, ResponseCode: http.StatusOK},
+				{Method: http.MethodGet, Path: versionInfoPath, ResponseCode: http.StatusInternalServerError},
+			},
+			creds:           userCreds,
+			skipverify:      true,
+			expectedSummary: UnexpectedResponseSummary,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := mockTurboServer(t, tc.routes)
+			hostname := tc.hostname
+			if hostname == "" {
+				hostname = strings.TrimPrefix(server.URL, "https://")
+			}
+			httpClient, err := newHTTPClient(tc.skipverify, "")
+			assert.NoError(t, err)
+
+			version, err := checkConnectivity(context.Background(), httpClient, hostname, tc.creds)
+			if tc.expectedSummary != "" {
+				var connErr *connectivityError
+				assert.True(t, errors.As(err, &connErr))
+				assert.Equal(t, tc.expectedSummary, connErr.Summary)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "8.14.3", version.Version)
+		})
+	}
+}
+
+// Tests that allow_unreachable turns the connectivity errors into warnings
+func TestConnectivityDiagnostic(t *testing.T) {
+	err := &connectivityError{UnreachableSummary, errors.New("dial tcp: lookup turbo.example.com: no such host")}
+
+	errDiag := connectivityDiagnostic(err, false)
+	assert.Equal(t, diag.SeverityError, errDiag.Severity())
+	assert.Equal(t, UnreachableSummary, errDiag.Summary())
+	assert.Contains(t, errDiag.Detail(), "set allow_unreachable = true")
+
+	warnDiag := connectivityDiagnostic(err, true)
+	assert.Equal(t, diag.SeverityWarning, warnDiag.Severity())
+	assert.Contains(t, warnDiag.Detail(), "the data sources return their default values")
+
+	clientDiag := connectivityDiagnostic(errors.New("Post \"https://turbo/api/v3/login\": tls: failed to verify certificate: x509: certificate signed by unknown authority"), false)
+	assert.Equal(t, TLSFailureSummary, clientDiag.Summary())
+	assert.Contains(t, clientDiag.Detail(), "add the certificate authorities that sign it to the trust store of the system")
+}
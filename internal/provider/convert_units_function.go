@@ -0,0 +1,182 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+const (
+	// Unit dimensions
+	throughputDimension = "throughput"
+	sizeDimension       = "size"
+	cpuSpeedDimension   = "cpu speed"
+	cpuCoresDimension   = "cpu cores"
+
+	// Units used by the typed data sources
+	KibitPerSecUnit = "Kibit/s"
+	MiBPerSecUnit   = "MiB/s"
+	MBUnit          = "MB"
+	GiBUnit         = "GiB"
+)
+
+// unit is a measurement unit with its factor to the base unit of its dimension
+// (byte/s for throughput, byte for size, MHz for cpu speed and vCPU for cpu cores)
+type unit struct {
+	dimension string
+	factor    float64
+}
+
+// units maps the unit names, including the ones reported by Turbonomic, to their definition.
+// Turbonomic reports sizes in binary units, E.G: MB is 1024 KB.
+var units = map[string]unit{
+	"Kibit/s":   {throughputDimension, 128},
+	"Kbit/sec":  {throughputDimension, 128},
+	"KiB/s":     {throughputDimension, 1 << 10},
+	"KByte/sec": {throughputDimension, 1 << 10},
+	"MiB/s":     {throughputDimension, 1 << 20},
+	"KB":        {sizeDimension, 1 << 10},
+	"KiB":       {sizeDimension, 1 << 10},
+	"MB":        {sizeDimension, 1 << 20},
+	"MiB":       {sizeDimension, 1 << 20},
+	"GB":        {sizeDimension, 1 << 30},
+	"GiB":       {sizeDimension, 1 << 30},
+	"TiB":       {sizeDimension, 1 << 40},
+	"MHz":       {cpuSpeedDimension, 1},
+	"GHz":       {cpuSpeedDimension, 1000},
+	"vCPU":      {cpuCoresDimension, 1},
+	"mCores":    {cpuCoresDimension, 0.001},
+}
+
+/*
+convertUnits converts a value between two units of the same dimension. CPU speed and CPU cores
+are converted into each other with the speed of a single core.
+
+Parameters:
+  - value: The value to convert
+  - from: The unit of the value
+  - to: The unit to convert the value to
+  - cpuSpeed: The speed of a single core in MHz, only used between cpu speed and cpu cores
+
+Returns:
+  - float64: The converted value
+  - error: An error when a unit is unknown or the units cannot be converted into each other
+*/
+func convertUnits(value float64, from, to string, cpuSpeed float64) (float64, error) {
+	fromUnit, ok := units[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q, expected one of: %s", from, strings.Join(slices.Sorted(maps.Keys(units)), ", "))
+	}
+	toUnit, ok := units[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q, expected one of: %s", to, strings.Join(slices.Sorted(maps.Keys(units)), ", "))
+	}
+
+	base := value * fromUnit.factor
+	switch {
+	case fromUnit.dimension == toUnit.dimension:
+	case fromUnit.dimension == cpuCoresDimension && toUnit.dimension == cpuSpeedDimension:
+		if cpuSpeed <= 0 {
+			return 0, fmt.Errorf("converting %s to %s requires a positive cpu speed in MHz", from, to)
+		}
+		base *= cpuSpeed
+	case fromUnit.dimension == cpuSpeedDimension && toUnit.dimension == cpuCoresDimension:
+		if cpuSpeed <= 0 {
+			return 0, fmt.Errorf("converting %s to %s requires a positive cpu speed in MHz", from, to)
+		}
+		base /= cpuSpeed
+	default:
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", from, fromUnit.dimension, to, toUnit.dimension)
+	}
+
+	return base / toUnit.factor, nil
+}
+
+var _ function.Function = &ConvertUnitsFunction{}
+
+type ConvertUnitsFunction struct{}
+
+func NewConvertUnitsFunction() function.Function {
+	return &ConvertUnitsFunction{}
+}
+
+func (f *ConvertUnitsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "convert_units"
+}
+
+func (f *ConvertUnitsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert Turbonomic units to cloud provider units",
+		Description: "Converts a value reported by Turbonomic, such as the current_value and new_value of turbonomic_entity_actions, " +
+			"between the throughput units Kibit/s (Kbit/sec), KiB/s (KByte/sec) and MiB/s, the size units KB, MB, GB and TiB, " +
+			"and the cpu units MHz, GHz, vCPU and mCores. Sizes are binary, E.G: 1 MB is 1024 KB. " +
+			"Converting between MHz and vCPU requires the speed of a single core in MHz",
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:        "value",
+				Description: "value to convert",
+			},
+			function.StringParameter{
+				Name:        "from",
+				Description: "unit of the value, E.G: Kbit/sec",
+			},
+			function.StringParameter{
+				Name:        "to",
+				Description: "unit to convert the value to, E.G: MiB/s",
+			},
+		},
+		VariadicParameter: function.Float64Parameter{
+			Name:        "cpu_speed_mhz",
+			Description: "speed of a single core in MHz, required to convert between MHz and vCPU",
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+// Returns the value converted to the requested unit
+func (f *ConvertUnitsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value float64
+	var from, to string
+	var cpuSpeeds []float64
+
+	resp.Error = req.Arguments.Get(ctx, &value, &from, &to, &cpuSpeeds)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(cpuSpeeds) > 1 {
+		resp.Error = function.NewArgumentFuncError(3, "at most one cpu speed can be passed")
+		return
+	}
+
+	var cpuSpeed float64
+	if len(cpuSpeeds) == 1 {
+		cpuSpeed = cpuSpeeds[0]
+	}
+
+	result, err := convertUnits(value, from, to, cpuSpeed)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertUnits(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    float64
+		from     string
+		to       string
+		cpuSpeed float64
+		expected float64
+	}{
+		{name: "Kibit/s to MiB/s", value: 8192, from: "Kibit/s", to: "MiB/s", expected: 1},
+		{name: "Turbonomic Kbit/sec to MiB/s", value: 1024000, from: "Kbit/sec", to: "MiB/s", expected: 125},
+		{name: "MiB/s to Kibit/s", value: 250, from: "MiB/s", to: "Kibit/s", expected: 2048000},
+		{name: "KByte/sec to MiB/s", value: 2048, from: "KByte/sec", to: "MiB/s", expected: 2},
+		{name: "Kibit/s to KiB/s", value: 16, from: "Kibit/s", to: "KiB/s", expected: 2},
+		{name: "MB to GiB", value: 131072, from: "MB", to: "GiB", expected: 128},
+		{name: "GiB to MB", value: 1.5, from: "GiB", to: "MB", expected: 1536},
+		{name: "KB memory to GiB", value: 16777216, from: "KB", to: "GiB", expected: 16},
+		{name: "KB to MB", value: 512, from: "KB", to: "MB", expected: 0.5},
+		{name: "GiB to TiB", value: 2048, from: "GiB", to: "TiB", expected: 2},
+		{name: "MHz to GHz", value: 2500, from: "MHz", to: "GHz", expected: 2.5},
+		{name: "vCPU to mCores", value: 2, from: "vCPU", to: "mCores", expected: 2000},
+		{name: "MHz to vCPU", value: 5200, from: "MHz", to: "vCPU", cpuSpeed: 2600, expected: 2},
+		{name: "vCPU to MHz", value: 4, from: "vCPU", to: "MHz", cpuSpeed: 2600, expected: 10400},
+		{name: "Same unit", value: 42, from: "MB", to: "MB", expected: 42},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := convertUnits(tc.value, tc.from, tc.to, tc.cpuSpeed)
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.expected, result, 1e-9)
+		})
+	}
+}
+
+func TestConvertUnitsErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		cpuSpeed float64
+		errMsg   string
+	}{
+		{name: "Unknown from unit", from: "parsecs", to: "MB", errMsg: `unknown unit "parsecs"`},
+		{name: "Unknown to unit", from: "MB", to: "mb", errMsg: `unknown unit "mb"`},
+		{name: "Different dimensions", from: "MB", to: "MiB/s", errMsg: "cannot convert MB (size) to MiB/s (throughput)"},
+		{name: "Missing cpu speed", from: "MHz", to: "vCPU", errMsg: "requires a positive cpu speed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := convertUnits(1, tc.from, tc.to, tc.cpuSpeed)
+			assert.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestConvertUnitsFunctionRun(t *testing.T) {
+	run := func(value float64, from, to string, cpuSpeeds ...float64) function.RunResponse {
+		var elemTypes []attr.Type
+		var elems []attr.Value
+		for _, cpuSpeed := range cpuSpeeds {
+			elemTypes = append(elemTypes, types.Float64Type)
+			elems = append(elems, types.Float64Value(cpuSpeed))
+		}
+
+		req := function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{
+				types.Float64Value(value),
+				types.StringValue(from),
+				types.StringValue(to),
+				types.TupleValueMust(elemTypes, elems),
+			}),
+		}
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.Float64Unknown()),
+		}
+		NewConvertUnitsFunction().Run(context.Background(), req, &resp)
+		return resp
+	}
+
+	resp := run(1024000, "Kbit/sec", "MiB/s")
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, types.Float64Value(125), resp.Result.Value())
+
+	resp = run(5200, "MHz", "vCPU", 2600)
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, types.Float64Value(2), resp.Result.Value())
+
+	resp = run(5200, "MHz", "vCPU")
+	assert.NotNil(t, resp.Error)
+
+	resp = run(5200, "MHz", "vCPU", 2600, 3000)
+	assert.NotNil(t, resp.Error)
+}
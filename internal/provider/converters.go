@@ -0,0 +1,32 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The models filled from the go-client types are converted by the functions of converters_gen.go,
+// run go generate after changing one of them or after upgrading the go-client.
+//go:generate go run ../../tools/convgen -output converters_gen.go ActionModel=ActionResults EntityModel=EntityResults
+
+// normalizedJSON returns the JSON document of a response, or a null value when the response does not have it
+func normalizedJSON(raw json.RawMessage) jsontypes.Normalized {
+	if raw == nil {
+		return jsontypes.NewNormalizedNull()
+	}
+	return jsontypes.NewNormalizedValue(string(raw))
+}
+
+// stringValues converts a slice of strings, an absent slice is converted to an empty one
+func stringValues(values []string) []types.String {
+	converted := make([]types.String, len(values))
+	for i, value := range values {
+		converted[i] = types.StringValue(value)
+	}
+	return converted
+}
@@ -0,0 +1,315 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by convgen. DO NOT EDIT.
+
+package provider
+
+import (
+	"encoding/json"
+
+	turboclient "github.com/IBM/turbonomic-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// turboAction is the type of turboclient.ActionResults[]
+type turboAction = struct {
+	UUID                   string                `json:"uuid"`
+	ActionImpactID         int64                 `json:"actionImpactID"`
+	MarketID               int64                 `json:"marketID"`
+	CreateTime             string                `json:"createTime"`
+	Importance             float32               `json:"importance"`
+	ActionStateDescription string                `json:"actionStateDescription"`
+	DisplayName            string                `json:"displayName"`
+	ActionType             string                `json:"actionType"`
+	ActionState            string                `json:"actionState"`
+	ActionMode             string                `json:"actionMode"`
+	Details                string                `json:"details"`
+	Target                 turboActionTarget     `json:"target"`
+	CurrentEntity          turboActionEntity     `json:"currentEntity"`
+	NewEntity              turboActionEntity     `json:"newEntity"`
+	CurrentValue           string                `json:"currentValue"`
+	NewValue               string                `json:"newValue"`
+	ValueUnits             string                `json:"valueUnits"`
+	ResizeAttribute        string                `json:"resizeAttribute"`
+	Template               turboActionTemplate   `json:"template"`
+	Risk                   turboActionRisk       `json:"risk"`
+	Stats                  []turboActionStat     `json:"stats"`
+	CurrentLocation        turboActionLocation   `json:"currentLocation"`
+	NewLocation            turboActionLocation   `json:"newLocation"`
+	CompoundActions        []turboCompoundAction `json:"compoundActions"`
+	Source                 string                `json:"source"`
+	ActionID               int64                 `json:"actionID"`
+	ActionSchedule         struct {
+		UUID                               string `json:"uuid"`
+		DisplayName                        string `json:"displayName"`
+		NextOccurrence                     string `json:"nextOccurrence"`
+		NextOccurrenceTimestamp            int64  `json:"nextOccurrenceTimestamp"`
+		TimeZoneId                         string `json:"timeZoneId"`
+		Mode                               string `json:"mode"`
+		AcceptedByUserForMaintenanceWindow bool   `json:"acceptedByUserForMaintenanceWindow"`
+		RemaingTimeActiveInMs              int64  `json:"remaingTimeActiveInMs"`
+	} `json:"actionSchedule"`
+}
+
+// turboActionTarget is the type of turboclient.ActionResults[].Target
+type turboActionTarget = struct {
+	UUID            string              `json:"uuid"`
+	DisplayName     string              `json:"displayName"`
+	ClassName       string              `json:"className"`
+	EnvironmentType string              `json:"environmentType"`
+	DiscoveredBy    turboDiscoveredBy   `json:"discoveredBy"`
+	VendorIds       map[string]string   `json:"vendorIds"`
+	State           string              `json:"state"`
+	Aspects         json.RawMessage     `json:"aspects"`
+	Tags            map[string][]string `json:"tags"`
+}
+
+// turboActionEntity is the type of turboclient.ActionResults[].CurrentEntity
+type turboActionEntity = struct {
+	UUID            string            `json:"uuid"`
+	DisplayName     string            `json:"displayName"`
+	ClassName       string            `json:"className"`
+	EnvironmentType string            `json:"environmentType"`
+	DiscoveredBy    turboDiscoveredBy `json:"discoveredBy"`
+	VendorIds       map[string]string `json:"vendorIds"`
+	State           string            `json:"state"`
+}
+
+// turboActionTemplate is the type of turboclient.ActionResults[].Template
+type turboActionTemplate = struct {
+	UUID        string `json:"uuid"`
+	DisplayName string `json:"displayName"`
+	ClassName   string `json:"className"`
+	Discovered  bool   `json:"discovered"`
+	EnableMatch bool   `json:"enableMatch"`
+}
+
+// turboActionRisk is the type of turboclient.ActionResults[].Risk
+type turboActionRisk = struct {
+	SubCategory       string   `json:"subCategory"`
+	Description       string   `json:"description"`
+	Severity          string   `json:"severity"`
+	Importance        float32  `json:"importance"`
+	ReasonCommodities []string `json:"reasonCommodities"`
+}
+
+// turboActionStat is the type of turboclient.ActionResults[].Stats[]
+type turboActionStat = struct {
+	Name    string                  `json:"name"`
+	Filters []turboActionStatFilter `json:"filters"`
+	Units   string                  `json:"units"`
+	Value   float64                 `json:"value"`
+}
+
+// turboActionLocation is the type of turboclient.ActionResults[].CurrentLocation
+type turboActionLocation = struct {
+	UUID            string            `json:"uuid"`
+	DisplayName     string            `json:"displayName"`
+	ClassName       string            `json:"className"`
+	EnvironmentType string            `json:"environmentType"`
+	DiscoveredBy    turboDiscoveredBy `json:"discoveredBy"`
+	VendorIds       map[string]string `json:"vendorIds"`
+}
+
+// turboCompoundAction is the type of turboclient.ActionResults[].CompoundActions[]
+type turboCompoundAction = struct {
+	DisplayName     string            `json:"displayName"`
+	ActionType      string            `json:"actionType"`
+	ActionState     string            `json:"actionState"`
+	ActionMode      string            `json:"actionMode"`
+	Details         string            `json:"details"`
+	Target          turboActionTarget `json:"target"`
+	CurrentEntity   turboActionEntity `json:"currentEntity"`
+	NewEntity       turboActionEntity `json:"newEntity"`
+	CurrentValue    string            `json:"currentValue"`
+	NewValue        string            `json:"newValue"`
+	ValueUnits      string            `json:"valueUnits"`
+	ResizeAttribute string            `json:"resizeAttribute"`
+	Risk            turboActionRisk   `json:"risk"`
+}
+
+// turboDiscoveredBy is the type of turboclient.ActionResults[].Target.DiscoveredBy
+type turboDiscoveredBy = struct {
+	UUID              string `json:"uuid"`
+	DisplayName       string `json:"displayName"`
+	IsProbeRegistered bool   `json:"isProbeRegistered"`
+	Category          string `json:"category"`
+	Type              string `json:"type"`
+	Readonly          bool   `json:"readonly"`
+}
+
+// turboActionStatFilter is the type of turboclient.ActionResults[].Stats[].Filters[]
+type turboActionStatFilter = struct {
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	DisplayName string `json:"displayName"`
+}
+
+// turboEntityRelation is the type of turboclient.EntityResults.Providers[]
+type turboEntityRelation = struct {
+	UUID        string `json:"uuid"`
+	DisplayName string `json:"displayName"`
+	ClassName   string `json:"className"`
+}
+
+// copyActionModel copies a turboclient.ActionResults[] into an ActionModel
+func copyActionModel(src *turboAction, dst *ActionModel) {
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.ActionType = types.StringValue(src.ActionType)
+	dst.ActionState = types.StringValue(src.ActionState)
+	dst.ActionMode = types.StringValue(src.ActionMode)
+	dst.Details = types.StringValue(src.Details)
+	copyActionTargetModel(&src.Target, &dst.Target)
+	copyActionEntityModel(&src.CurrentEntity, &dst.CurrentEntity)
+	copyActionEntityModel(&src.NewEntity, &dst.NewEntity)
+	dst.CurrentValue = types.StringValue(src.CurrentValue)
+	dst.NewValue = types.StringValue(src.NewValue)
+	dst.ValueUnits = types.StringValue(src.ValueUnits)
+	dst.ResizeAttribute = types.StringValue(src.ResizeAttribute)
+	dst.UUID = types.StringValue(src.UUID)
+	dst.ActionImpactID = types.Int64Value(src.ActionImpactID)
+	dst.MarketID = types.Int64Value(src.MarketID)
+	dst.CreateTime = types.StringValue(src.CreateTime)
+	dst.Importance = types.Float32Value(src.Importance)
+	copyActionTemplateModel(&src.Template, &dst.Template)
+	copyActionRiskModel(&src.Risk, &dst.Risk)
+	dst.Stats = make([]ActionStatModel, len(src.Stats))
+	for i := range src.Stats {
+		copyActionStatModel(&src.Stats[i], &dst.Stats[i])
+	}
+	copyActionLocationModel(&src.CurrentLocation, &dst.CurrentLocation)
+	copyActionLocationModel(&src.NewLocation, &dst.NewLocation)
+	dst.CompoundActions = make([]CompoundActionModel, len(src.CompoundActions))
+	for i := range src.CompoundActions {
+		copyCompoundActionModel(&src.CompoundActions[i], &dst.CompoundActions[i])
+	}
+	dst.Source = types.StringValue(src.Source)
+	dst.ActionID = types.Int64Value(src.ActionID)
+}
+
+// copyActionTargetModel copies a turboclient.ActionResults[].Target into an ActionTargetModel
+func copyActionTargetModel(src *turboActionTarget, dst *ActionTargetModel) {
+	dst.UUID = types.StringValue(src.UUID)
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.ClassName = types.StringValue(src.ClassName)
+	dst.EnvironmentType = types.StringValue(src.EnvironmentType)
+	copyDiscoveredByModel(&src.DiscoveredBy, &dst.DiscoveredBy)
+	dst.VendorIds = src.VendorIds
+	dst.State = types.StringValue(src.State)
+	dst.Aspects = normalizedJSON(src.Aspects)
+	dst.Tags = src.Tags
+}
+
+// copyActionEntityModel copies a turboclient.ActionResults[].CurrentEntity into an ActionEntityModel
+func copyActionEntityModel(src *turboActionEntity, dst *ActionEntityModel) {
+	dst.UUID = types.StringValue(src.UUID)
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.ClassName = types.StringValue(src.ClassName)
+	dst.EnvironmentType = types.StringValue(src.EnvironmentType)
+	copyDiscoveredByModel(&src.DiscoveredBy, &dst.DiscoveredBy)
+	dst.VendorIds = src.VendorIds
+	dst.State = types.StringValue(src.State)
+}
+
+// copyActionTemplateModel copies a turboclient.ActionResults[].Template into an ActionTemplateModel
+func copyActionTemplateModel(src *turboActionTemplate, dst *ActionTemplateModel) {
+	dst.UUID = types.StringValue(src.UUID)
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.ClassName = types.StringValue(src.ClassName)
+	dst.Discovered = types.BoolValue(src.Discovered)
+	dst.EnableMatch = types.BoolValue(src.EnableMatch)
+}
+
+// copyActionRiskModel copies a turboclient.ActionResults[].Risk into an ActionRiskModel
+func copyActionRiskModel(src *turboActionRisk, dst *ActionRiskModel) {
+	dst.SubCategory = types.StringValue(src.SubCategory)
+	dst.Description = types.StringValue(src.Description)
+	dst.Severity = types.StringValue(src.Severity)
+	dst.Importance = types.Float32Value(src.Importance)
+	dst.ReasonCommodities = stringValues(src.ReasonCommodities)
+}
+
+// copyActionStatModel copies a turboclient.ActionResults[].Stats[] into an ActionStatModel
+func copyActionStatModel(src *turboActionStat, dst *ActionStatModel) {
+	dst.Name = types.StringValue(src.Name)
+	dst.Filters = make([]ActionStatFilterModel, len(src.Filters))
+	for i := range src.Filters {
+		copyActionStatFilterModel(&src.Filters[i], &dst.Filters[i])
+	}
+	dst.Units = types.StringValue(src.Units)
+	dst.Value = types.Float64Value(src.Value)
+}
+
+// copyActionLocationModel copies a turboclient.ActionResults[].CurrentLocation into an ActionLocationModel
+func copyActionLocationModel(src *turboActionLocation, dst *ActionLocationModel) {
+	dst.UUID = types.StringValue(src.UUID)
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.ClassName = types.StringValue(src.ClassName)
+	dst.EnvironmentType = types.StringValue(src.EnvironmentType)
+	copyDiscoveredByModel(&src.DiscoveredBy, &dst.DiscoveredBy)
+	dst.VendorIds = src.VendorIds
+}
+
+// copyCompoundActionModel copies a turboclient.ActionResults[].CompoundActions[] into a CompoundActionModel
+func copyCompoundActionModel(src *turboCompoundAction, dst *CompoundActionModel) {
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.ActionType = types.StringValue(src.ActionType)
+	dst.ActionState = types.StringValue(src.ActionState)
+	dst.ActionMode = types.StringValue(src.ActionMode)
+	dst.Details = types.StringValue(src.Details)
+	copyActionTargetModel(&src.Target, &dst.Target)
+	copyActionEntityModel(&src.CurrentEntity, &dst.CurrentEntity)
+	copyActionEntityModel(&src.NewEntity, &dst.NewEntity)
+	dst.CurrentValue = types.StringValue(src.CurrentValue)
+	dst.NewValue = types.StringValue(src.NewValue)
+	dst.ValueUnits = types.StringValue(src.ValueUnits)
+	dst.ResizeAttribute = types.StringValue(src.ResizeAttribute)
+	copyActionRiskModel(&src.Risk, &dst.Risk)
+}
+
+// copyDiscoveredByModel copies a turboclient.ActionResults[].Target.DiscoveredBy into a DiscoveredByModel
+func copyDiscoveredByModel(src *turboDiscoveredBy, dst *DiscoveredByModel) {
+	dst.UUID = types.StringValue(src.UUID)
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.IsProbeRegistered = types.BoolValue(src.IsProbeRegistered)
+	dst.Category = types.StringValue(src.Category)
+	dst.Type = types.StringValue(src.Type)
+	dst.Readonly = types.BoolValue(src.Readonly)
+}
+
+// copyActionStatFilterModel copies a turboclient.ActionResults[].Stats[].Filters[] into an ActionStatFilterModel
+func copyActionStatFilterModel(src *turboActionStatFilter, dst *ActionStatFilterModel) {
+	dst.Type = types.StringValue(src.Type)
+	dst.Value = types.StringValue(src.Value)
+	dst.DisplayName = types.StringValue(src.DisplayName)
+}
+
+// copyEntityModel copies a turboclient.EntityResults into an EntityModel
+// The fields without a source are left untouched: EntityName, EntityType, VendorId, EnvType
+func copyEntityModel(src *turboclient.EntityResults, dst *EntityModel) {
+	dst.UUID = types.StringValue(src.UUID)
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.ClassName = types.StringValue(src.ClassName)
+	dst.EnvironmentType = types.StringValue(src.EnvironmentType)
+	copyDiscoveredByModel(&src.DiscoveredBy, &dst.DiscoveredBy)
+	dst.VendorIds = src.VendorIds
+	dst.State = types.StringValue(src.State)
+	dst.Aspects = normalizedJSON(src.Aspects)
+	dst.Tags = src.Tags
+	dst.Providers = make([]EntityRelationModel, len(src.Providers))
+	for i := range src.Providers {
+		copyEntityRelationModel(&src.Providers[i], &dst.Providers[i])
+	}
+	dst.Consumers = make([]EntityRelationModel, len(src.Consumers))
+	for i := range src.Consumers {
+		copyEntityRelationModel(&src.Consumers[i], &dst.Consumers[i])
+	}
+}
+
+// copyEntityRelationModel copies a turboclient.EntityResults.Providers[] into an EntityRelationModel
+func copyEntityRelationModel(src *turboEntityRelation, dst *EntityRelationModel) {
+	dst.UUID = types.StringValue(src.UUID)
+	dst.DisplayName = types.StringValue(src.DisplayName)
+	dst.ClassName = types.StringValue(src.ClassName)
+}
@@ -0,0 +1,344 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+
+	turboclient "github.com/IBM/turbonomic-go-client"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// converterIterations is the number of random actions converted by the property tests
+	converterIterations = 500
+	// maxRandomLength is the maximum length of the random slices, maps and strings
+	maxRandomLength = 4
+)
+
+var (
+	attrValueType  = reflect.TypeOf((*attr.Value)(nil)).Elem()
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	randomRunes    = []rune("abcXYZ019 -_.:/\"\\{}é世\n")
+)
+
+// Tests that any action returned by Turbonomic survives a conversion into an ActionModel and back,
+// every field of the action that the model declares must keep its value
+func TestActionModelConversionRoundTrip(t *testing.T) {
+	modelType := reflect.TypeOf(ActionModel{})
+
+	for seed := uint64(1); seed <= converterIterations; seed++ {
+		r := rand.New(rand.NewPCG(seed, seed))
+		var actions turboclient.ActionResults
+		reflect.ValueOf(&actions).Elem().Set(randomValue(r, reflect.TypeOf(actions)))
+
+		for i, action := range actions {
+			var model ActionModel
+			copyActionModel(&action, &model)
+
+			expected := projectOnModel(reflect.ValueOf(action), modelType)
+			actual := modelToSource(reflect.ValueOf(model), reflect.TypeOf(action))
+			if !assert.Equal(t, expected.Interface(), actual.Interface(), "seed %d, action %d", seed, i) {
+				t.FailNow()
+			}
+		}
+	}
+}
+
+// Tests that any entity returned by Turbonomic survives a conversion into an EntityModel and back,
+// the input fields of the model are left untouched
+func TestEntityModelConversionRoundTrip(t *testing.T) {
+	modelType := reflect.TypeOf(EntityModel{})
+
+	for seed := uint64(1); seed <= converterIterations; seed++ {
+		r := rand.New(rand.NewPCG(seed, seed))
+		var entity turboclient.EntityResults
+		reflect.ValueOf(&entity).Elem().Set(randomValue(r, reflect.TypeOf(entity)))
+
+		model := EntityModel{EntityName: types.StringValue("input"), EnvType: types.StringValue("CLOUD")}
+		copyEntityModel(&entity, &model)
+
+		expected := projectOnModel(reflect.ValueOf(entity), modelType)
+		actual := modelToSource(reflect.ValueOf(model), reflect.TypeOf(entity))
+		if !assert.Equal(t, expected.Interface(), actual.Interface(), "seed %d", seed) ||
+			!assert.Equal(t, "input", model.EntityName.ValueString(), "seed %d", seed) ||
+			!assert.Equal(t, "CLOUD", model.EnvType.ValueString(), "seed %d", seed) {
+			t.FailNow()
+		}
+	}
+}
+
+// Tests that an empty or null aspects document is converted to a null value rather than an invalid one
+func TestActionModelConversionNullAspects(t *testing.T) {
+	actions := make(turboclient.ActionResults, 2)
+	actions[1].Target.Aspects = json.RawMessage(`{"virtualMachineAspect":{"os":"LINUX"}}`)
+
+	var empty, withAspects ActionModel
+	copyActionModel(&actions[0], &empty)
+	copyActionModel(&actions[1], &withAspects)
+
+	assert.True(t, empty.Target.Aspects.IsNull())
+	assert.Equal(t, `{"virtualMachineAspect":{"os":"LINUX"}}`, withAspects.Target.Aspects.ValueString())
+	assert.Empty(t, empty.CompoundActions)
+	assert.Empty(t, empty.Risk.ReasonCommodities)
+}
+
+// Tests that every field of the action model is copied from a field of the Turbonomic action, the generated
+// copyActionModel leaves the fields of the root model without a source untouched and always empty
+func TestActionModelFieldsHaveSource(t *testing.T) {
+	var actions turboclient.ActionResults
+	assertFieldsHaveSource(t, "action", reflect.TypeOf(ActionModel{}), reflect.TypeOf(actions).Elem())
+}
+
+// Tests that the tfsdk tags of the models of the data sources filled by the generated converters match their schemas
+func TestConvertedModelsMatchSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		dataSource datasource.DataSource
+		model      any
+	}{
+		{name: "entity actions", dataSource: NewEntityActionsDataSource(), model: EntityActionsModel{}},
+		{name: "entity", dataSource: NewEntityDataSource(), model: EntityModel{}},
+		{name: "compliance report", dataSource: NewComplianceReportDataSource(), model: ComplianceReportModel{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &datasource.SchemaResponse{}
+			tc.dataSource.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+			require.False(t, resp.Diagnostics.HasError())
+
+			assertModelMatchesSchema(t, "", reflect.TypeOf(tc.model), resp.Schema.Attributes)
+		})
+	}
+}
+
+// Tests that the drift check reports the tags without attributes and the attributes without tags
+func TestAssertModelMatchesSchema(t *testing.T) {
+	type model struct {
+		Name   types.String `tfsdk:"name"`
+		Nested struct {
+			Value types.Int64 `tfsdk:"value"`
+		} `tfsdk:"nested"`
+	}
+	attributes := map[string]schema.Attribute{
+		"name":   schema.StringAttribute{Computed: true},
+		"nested": schema.SingleNestedAttribute{Computed: true, Attributes: map[string]schema.Attribute{}},
+		"extra":  schema.BoolAttribute{Computed: true},
+	}
+
+	recorder := &errorRecorder{}
+	assertModelMatchesSchema(recorder, "", reflect.TypeOf(model{}), attributes)
+
+	assert.ElementsMatch(t, []string{
+		"nested.value: the tfsdk tag has no matching schema attribute",
+		"extra: the schema attribute has no matching tfsdk tag",
+	}, recorder.errors)
+}
+
+// randomValue returns a random value of a type of the Turbonomic client
+func randomValue(r *rand.Rand, typ reflect.Type) reflect.Value {
+	value := reflect.New(typ).Elem()
+
+	switch {
+	case typ == rawMessageType:
+		if r.IntN(3) > 0 {
+			document := map[string]any{}
+			for range r.IntN(maxRandomLength) {
+				document[randomString(r)] = []any{randomString(r), r.NormFloat64(), r.IntN(2) == 0, nil}
+			}
+			raw, _ := json.Marshal(document)
+			value.SetBytes(raw)
+		}
+	case typ.Kind() == reflect.String:
+		value.SetString(randomString(r))
+	case typ.Kind() == reflect.Int, typ.Kind() == reflect.Int64:
+		value.SetInt(r.Int64() - r.Int64())
+	case typ.Kind() == reflect.Float32, typ.Kind() == reflect.Float64:
+		value.SetFloat(r.NormFloat64() * 1e6)
+	case typ.Kind() == reflect.Bool:
+		value.SetBool(r.IntN(2) == 0)
+	case typ.Kind() == reflect.Struct:
+		for i := range typ.NumField() {
+			if typ.Field(i).IsExported() {
+				value.Field(i).Set(randomValue(r, typ.Field(i).Type))
+			}
+		}
+	case typ.Kind() == reflect.Slice:
+		if length := r.IntN(maxRandomLength); length > 0 {
+			value.Set(reflect.MakeSlice(typ, length, length))
+			for i := range length {
+				value.Index(i).Set(randomValue(r, typ.Elem()))
+			}
+		}
+	case typ.Kind() == reflect.Map:
+		if length := r.IntN(maxRandomLength); length > 0 {
+			value.Set(reflect.MakeMap(typ))
+			for range length {
+				value.SetMapIndex(randomValue(r, typ.Key()), randomValue(r, typ.Elem()))
+			}
+		}
+	case typ.Kind() == reflect.Pointer:
+		if r.IntN(2) == 0 {
+			value.Set(reflect.New(typ.Elem()))
+			value.Elem().Set(randomValue(r, typ.Elem()))
+		}
+	}
+	return value
+}
+
+func randomString(r *rand.Rand) string {
+	var sb strings.Builder
+	for range r.IntN(2 * maxRandomLength) {
+		sb.WriteRune(randomRunes[r.IntN(len(randomRunes))])
+	}
+	return sb.String()
+}
+
+// projectOnModel returns a copy of a Turbonomic value that only keeps the fields declared by a model,
+// with the empty slices set to nil
+func projectOnModel(src reflect.Value, model reflect.Type) reflect.Value {
+	projected := reflect.New(src.Type()).Elem()
+
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := range src.NumField() {
+			field := src.Type().Field(i)
+			modelField, ok := model.FieldByName(field.Name)
+			if !ok || !field.IsExported() {
+				continue
+			}
+			projected.Field(i).Set(projectOnModel(src.Field(i), modelField.Type))
+		}
+	case reflect.Slice:
+		if src.Len() == 0 {
+			return projected
+		}
+		if src.Type() == rawMessageType || src.Type().Elem().Kind() != reflect.Struct {
+			projected.Set(src)
+			return projected
+		}
+		projected.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := range src.Len() {
+			projected.Index(i).Set(projectOnModel(src.Index(i), model.Elem()))
+		}
+	default:
+		projected.Set(src)
+	}
+	return projected
+}
+
+// modelToSource converts a model filled by a generated converter back into the Turbonomic type it was copied from
+func modelToSource(model reflect.Value, src reflect.Type) reflect.Value {
+	value := reflect.New(src).Elem()
+
+	switch v := model.Interface().(type) {
+	case types.String:
+		value.SetString(v.ValueString())
+	case types.Int64:
+		value.SetInt(v.ValueInt64())
+	case types.Float64:
+		value.SetFloat(v.ValueFloat64())
+	case types.Float32:
+		value.SetFloat(float64(v.ValueFloat32()))
+	case types.Bool:
+		value.SetBool(v.ValueBool())
+	case jsontypes.Normalized:
+		if !v.IsNull() {
+			value.SetBytes([]byte(v.ValueString()))
+		}
+	default:
+		switch model.Kind() {
+		case reflect.Struct:
+			for i := range src.NumField() {
+				if modelField := model.FieldByName(src.Field(i).Name); modelField.IsValid() {
+					value.Field(i).Set(modelToSource(modelField, src.Field(i).Type))
+				}
+			}
+		case reflect.Slice:
+			if model.Len() > 0 {
+				value.Set(reflect.MakeSlice(src, model.Len(), model.Len()))
+				for i := range model.Len() {
+					value.Index(i).Set(modelToSource(model.Index(i), src.Elem()))
+				}
+			}
+		case reflect.Map:
+			value.Set(model)
+		}
+	}
+	return value
+}
+
+// assertFieldsHaveSource checks that every field of a model has a field of the same name in the source type
+func assertFieldsHaveSource(t *testing.T, path string, model, src reflect.Type) {
+	for i := range model.NumField() {
+		field := model.Field(i)
+		srcField, ok := src.FieldByName(field.Name)
+		if !ok {
+			t.Errorf("%s.%s: the model field has no source field in %s", path, field.Name, src)
+			continue
+		}
+
+		modelType, srcType := field.Type, srcField.Type
+		if modelType.Kind() == reflect.Slice && srcType.Kind() == reflect.Slice {
+			modelType, srcType = modelType.Elem(), srcType.Elem()
+		}
+		if modelType.Kind() == reflect.Struct && !modelType.Implements(attrValueType) && srcType.Kind() == reflect.Struct {
+			assertFieldsHaveSource(t, path+"."+field.Name, modelType, srcType)
+		}
+	}
+}
+
+// assertModelMatchesSchema checks that the tfsdk tags of a model and the attributes of its schema match
+func assertModelMatchesSchema(t interface{ Errorf(string, ...any) }, path string, model reflect.Type, attributes map[string]schema.Attribute) {
+	tags := map[string]bool{}
+	for i := range model.NumField() {
+		field := model.Field(i)
+		tag := field.Tag.Get("tfsdk")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		tags[tag] = true
+
+		attribute, ok := attributes[tag]
+		if !ok {
+			t.Errorf("%s%s: the tfsdk tag has no matching schema attribute", path, tag)
+			continue
+		}
+
+		nestedType := field.Type
+		if nestedType.Kind() == reflect.Slice {
+			nestedType = nestedType.Elem()
+		}
+		nested, isNested := attribute.(schema.NestedAttribute)
+		isStruct := nestedType.Kind() == reflect.Struct && !nestedType.Implements(attrValueType)
+		switch {
+		case isStruct && isNested:
+			nestedAttributes := map[string]schema.Attribute{}
+			for name, nestedAttribute := range nested.GetNestedObject().GetAttributes() {
+				nestedAttributes[name] = nestedAttribute.(schema.Attribute)
+			}
+			assertModelMatchesSchema(t, path+tag+".", nestedType, nestedAttributes)
+		case isStruct != isNested:
+			t.Errorf("%s%s: the field of type %s does not match the schema attribute %T", path, tag, field.Type, attribute)
+		}
+	}
+
+	for name := range attributes {
+		if !tags[name] {
+			t.Errorf("%s%s: the schema attribute has no matching tfsdk tag", path, name)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/IBM/terraform-provider-turbonomic/pkg/logger"
+)
+
+const (
+	// Environment variables of the credential sources
+	PasswordFileEnvVar      = "TURBO_PASSWORD_FILE"
+	ClientSecretFileEnvVar  = "TURBO_CLIENT_SECRET_FILE"
+	CredentialProcessEnvVar = "TURBO_CREDENTIAL_PROCESS"
+
+	// credentialProcessVersion is the only supported version of the credential process output
+	credentialProcessVersion = 1
+	credentialProcessTimeout = time.Minute
+
+	// maxCredentialProcessErrorSize is the size of the standard error of a failed credential
+	// process kept in the diagnostics
+	maxCredentialProcessErrorSize = 200
+)
+
+// credentialProcessOutput is the JSON document that a credential process writes on its standard
+// output, the fields follow the naming of the AWS credential_process output
+type credentialProcessOutput struct {
+	Version      int    `json:"Version"`
+	Username     string `json:"Username,omitempty"`
+	Password     string `json:"Password,omitempty"`
+	ClientId     string `json:"ClientId,omitempty"`
+	ClientSecret string `json:"ClientSecret,omitempty"`
+	Role         string `json:"Role,omitempty"`
+}
+
+/*
+resolveSecret returns a secret set inline or read from a file. The provider configuration takes
+precedence over the environment variables, and at each level the inline value takes precedence
+over the file.
+
+Parameters:
+  - value: The inline value of the provider configuration, E.G: password
+  - file: The file of the provider configuration, E.G: password_file
+  - valueEnvVar: The environment variable of the inline value, E.G: TURBO_PASSWORD
+  - fileEnvVar: The environment variable of the file, E.G: TURBO_PASSWORD_FILE
+  - fileAttribute: The name of the file attribute, used in the diagnostics
+
+Returns:
+  - string: The secret, empty when it is not set
+  - diag.Diagnostic: An error diagnostic when the file cannot be read
+*/
+func resolveSecret(value, file types.String, valueEnvVar, fileEnvVar, fileAttribute string) (string, diag.Diagnostic) {
+	switch {
+	case !value.IsNull():
+		return value.ValueString(), nil
+	case !file.IsNull():
+		secret, err := readSecretFile(file.ValueString())
+		if err != nil {
+			return "", diag.NewAttributeErrorDiagnostic(path.Root(fileAttribute),
+				"unable to read turbonomic api "+fileAttribute, err.Error())
+		}
+		return secret, nil
+	case os.Getenv(valueEnvVar) != "":
+		return os.Getenv(valueEnvVar), nil
+	case os.Getenv(fileEnvVar) != "":
+		secret, err := readSecretFile(os.Getenv(fileEnvVar))
+		if err != nil {
+			return "", diag.NewErrorDiagnostic("unable to read turbonomic api "+fileAttribute,
+				fmt.Sprintf("%s environment variable: %v", fileEnvVar, err))
+		}
+		return secret, nil
+	}
+	return "", nil
+}
+
+// readSecretFile reads a secret from a file, such as the files mounted by Vault Agent or
+// Kubernetes, without the trailing new line
+func readSecretFile(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the secret file: %v", err)
+	}
+
+	secret := strings.TrimRight(string(content), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("the secret file %s is empty", name)
+	}
+	return secret, nil
+}
+
+/*
+runCredentialProcess runs an external command that writes the credentials as JSON on its standard
+output, E.G: {"Version": 1, "ClientId": "...", "ClientSecret": "...", "Role": "OBSERVER"}.
+The command is run by the shell of the operating system.
+
+Parameters:
+  - ctx: The context of the command
+  - command: The command line to run
+
+Returns:
+  - credentialProcessOutput: The credentials written by the command
+  - error: An error when the command fails or writes an invalid output, it never contains the output
+*/
+func runCredentialProcess(ctx context.Context, command string) (credentialProcessOutput, error) {
+	var output credentialProcessOutput
+
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return output, fmt.Errorf("the credential process failed: %v: %s", err, credentialProcessError(stderr.String()))
+	}
+
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return output, fmt.Errorf("the credential process did not write a valid JSON document on its standard output")
+	}
+	if output.Version != credentialProcessVersion {
+		return output, fmt.Errorf("unsupported credential process output version %d, expected %d", output.Version, credentialProcessVersion)
+	}
+	if len(StringsWithValues(output.Username, output.ClientId)) == 0 {
+		return output, fmt.Errorf("the credential process output must contain either Username or ClientId")
+	}
+	return output, nil
+}
+
+// credentialProcessError returns the first line of the standard error of a failed credential process,
+// truncated, and masked when it mentions a secret as the process may print the credentials it failed on
+func credentialProcessError(stderr string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(stderr), "\n")
+	line = strings.TrimSpace(line)
+
+	if logger.IsSensitiveKey(line) {
+		return logger.Redacted
+	}
+	if len(line) > maxCredentialProcessErrorSize {
+		return line[:maxCredentialProcessErrorSize] + "...(truncated)"
+	}
+	return line
+}
@@ -0,0 +1,179 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeSecretFile writes a secret file in a temporary directory of the test
+func writeSecretFile(t *testing.T, content string) string {
+	name := filepath.Join(t.TempDir(), "secret")
+	assert.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	return name
+}
+
+// Tests the precedence of the inline secrets, the secret files and the environment variables
+func TestResolveSecret(t *testing.T) {
+	configFile := writeSecretFile(t, "from-config-file\n")
+	envFile := writeSecretFile(t, "from-env-file\r\n")
+
+	tests := []struct {
+		name          string
+		value         types.String
+		file          types.String
+		env           map[string]string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "inline value",
+			value:    types.StringValue("from-config"),
+			file:     types.StringNull(),
+			env:      map[string]string{"TURBO_PASSWORD": "from-env"},
+			expected: "from-config",
+		},
+		{
+			name:     "config file over environment",
+			value:    types.StringNull(),
+			file:     types.StringValue(configFile),
+			env:      map[string]string{"TURBO_PASSWORD": "from-env"},
+			expected: "from-config-file",
+		},
+		{
+			name:     "environment value over environment file",
+			value:    types.StringNull(),
+			file:     types.StringNull(),
+			env:      map[string]string{"TURBO_PASSWORD": "from-env", PasswordFileEnvVar: envFile},
+			expected: "from-env",
+		},
+		{
+			name:     "environment file",
+			value:    types.StringNull(),
+			file:     types.StringNull(),
+			env:      map[string]string{PasswordFileEnvVar: envFile},
+			expected: "from-env-file",
+		},
+		{
+			name:  "not set",
+			value: types.StringNull(),
+			file:  types.StringNull(),
+		},
+		{
+			name:          "missing file",
+			value:         types.StringNull(),
+			file:          types.StringValue(filepath.Join(t.TempDir(), "missing")),
+			expectedError: "unable to read turbonomic api password_file",
+		},
+		{
+			name:          "empty file",
+			value:         types.StringNull(),
+			file:          types.StringValue(writeSecretFile(t, "\n")),
+			expectedError: "unable to read turbonomic api password_file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("TURBO_PASSWORD", "")
+			t.Setenv(PasswordFileEnvVar, "")
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			secret, errDiag := resolveSecret(tc.value, tc.file, "TURBO_PASSWORD", PasswordFileEnvVar, "password_file")
+			if len(tc.expectedError) > 0 {
+				assert.NotNil(t, errDiag)
+				assert.Equal(t, tc.expectedError, errDiag.Summary())
+				return
+			}
+			assert.Nil(t, errDiag)
+			assert.Equal(t, tc.expected, secret)
+		})
+	}
+}
+
+// Tests the credentials read from the output of a credential process
+func TestRunCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands require a POSIX shell")
+	}
+
+	tests := []struct {
+		name          string
+		command       string
+		expected      credentialProcessOutput
+		expectedError string
+	}{
+		{
+			name:    "oauth client",
+			command: `echo '{"Version": 1, "ClientId": "12345", "ClientSecret": "s3cr3t", "Role": "OBSERVER"}'`,
+			expected: credentialProcessOutput{
+				Version:      1,
+				ClientId:     "12345",
+				ClientSecret: "s3cr3t",
+				Role:         "OBSERVER",
+			},
+		},
+		{
+			name:    "username and password",
+			command: `printf '{"Version": 1, "Username": "admin", "Password": "s3cr3t"}'`,
+			expected: credentialProcessOutput{
+				Version:  1,
+				Username: "admin",
+				Password: "s3cr3t",
+			},
+		},
+		{
+			name:          "failed command",
+			command:       `echo "vault is sealed" >&2; exit 2`,
+			expectedError: "the credential process failed: exit status 2: vault is sealed",
+		},
+		{
+			name:          "failed command with secret",
+			command:       `echo "login failed for password hunter2" >&2; echo "retrying" >&2; exit 1`,
+			expectedError: "the credential process failed: exit status 1: ***",
+		},
+		{
+			name:          "failed command with long error",
+			command:       `printf 'x%.0s' $(seq 300) >&2; exit 1`,
+			expectedError: "the credential process failed: exit status 1: " + strings.Repeat("x", maxCredentialProcessErrorSize) + "...(truncated)",
+		},
+		{
+			name:          "invalid output",
+			command:       `echo "password=s3cr3t"`,
+			expectedError: "the credential process did not write a valid JSON document on its standard output",
+		},
+		{
+			name:          "unsupported version",
+			command:       `echo '{"Version": 2, "Username": "admin"}'`,
+			expectedError: "unsupported credential process output version 2, expected 1",
+		},
+		{
+			name:          "no credentials",
+			command:       `echo '{"Version": 1}'`,
+			expectedError: "the credential process output must contain either Username or ClientId",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			creds, err := runCredentialProcess(context.Background(), tc.command)
+			if len(tc.expectedError) > 0 {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, creds)
+		})
+	}
+}
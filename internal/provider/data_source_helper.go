@@ -5,7 +5,9 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -14,11 +16,23 @@ import (
 
 // Constants for entity types and filter types
 const (
-	VirtualVolumeEntityType  = "VirtualVolume"
-	DatabaseServerEntityType = "DatabaseServer"
-	RelationFilterType       = "relation"
+	VirtualVolumeEntityType   = "VirtualVolume"
+	DatabaseServerEntityType  = "DatabaseServer"
+	BusinessAccountEntityType = "BusinessAccount"
+	RegionEntityType          = "Region"
+	RelationFilterType        = "relation"
 )
 
+// entitySearchPageSize is the number of entities requested in each page of a search
+const entitySearchPageSize = 500
+
+// entityNameFilterTypes are the filter types of the search criteria that match the display name
+// of an entity type. The name pattern of the other entity types is only matched by the provider.
+var entityNameFilterTypes = map[string]string{
+	"VirtualMachine":         "vmsByName",
+	DatabaseServerEntityType: "databaseServerByName",
+}
+
 type SearchRequestWithOptions struct {
 	turboclient.SearchRequest
 	showVendorID bool
@@ -62,6 +76,12 @@ func ShowVendorIdString(ok bool) EntityOption {
 	}
 }
 
+func WithCaseSensitive(caseSensitive bool) EntityOption {
+	return func(o *SearchRequestWithOptions) {
+		o.CaseSensitive = caseSensitive
+	}
+}
+
 /*
 fetches turbonomic entities by given entity-name and entity-type and environment type. If there are multiple matches or no matches it returns error diagnostic object.
 returns zero or one matching entity or throws an error
@@ -111,6 +131,279 @@ func getVendorIdsString(showVendorID bool, entity turboclient.SearchResults) str
 	return ""
 }
 
+// EntityFilterRequest describes an entity search where the entity type, environment, cloud
+// type, scope and, for the entity types with a name filter type, the name pattern are resolved
+// by Turbonomic, and the name pattern and tags are matched against the returned entities
+type EntityFilterRequest struct {
+	turboclient.SearchDTO
+	scopes      []string
+	namePattern *regexp.Regexp
+	tags        map[string]string
+}
+
+type EntityFilterOption func(*EntityFilterRequest)
+
+func WithFilterEntityType(entityType string) EntityFilterOption {
+	return func(o *EntityFilterRequest) {
+		o.ClassName = entityType
+	}
+}
+
+func WithFilterEnvironmentType(env string) EntityFilterOption {
+	return func(o *EntityFilterRequest) {
+		o.EnvironmentType = env
+	}
+}
+
+func WithFilterCloudType(cloud string) EntityFilterOption {
+	return func(o *EntityFilterRequest) {
+		o.CloudType = cloud
+	}
+}
+
+// WithScopes limits the search to the entities that belong to every given scope uuid,
+// for example a business account and a region
+func WithScopes(uuids ...string) EntityFilterOption {
+	return func(o *EntityFilterRequest) {
+		o.scopes = append(o.scopes, uuids...)
+	}
+}
+
+// WithNamePattern keeps only the entities whose display name matches the pattern
+func WithNamePattern(pattern *regexp.Regexp) EntityFilterOption {
+	return func(o *EntityFilterRequest) {
+		o.namePattern = pattern
+	}
+}
+
+// WithTagFilters keeps only the entities that have every given tag key set to the given value
+func WithTagFilters(tags map[string]string) EntityFilterOption {
+	return func(o *EntityFilterRequest) {
+		o.tags = tags
+	}
+}
+
+/*
+SearchEntitiesByFilter searches for entities in Turbonomic by entity type, environment, cloud type
+and scopes, and filters the results by name pattern and tags. Unlike GetEntitiesByName, multiple
+matches are not treated as an error.
+
+Returns:
+  - turboclient.SearchResults: The search results containing all matching entities
+  - *diag.ErrorDiagnostic: An error diagnostic if the operation fails
+*/
+func SearchEntitiesByFilter(client turboclient.T8cClient, options ...EntityFilterOption) (turboclient.SearchResults, *diag.ErrorDiagnostic) {
+	if client == nil {
+		errDiag := diag.NewErrorDiagnostic("Internal error", "Internal error occurred while fetching entities")
+		return nil, &errDiag
+	}
+
+	opts := EntityFilterRequest{
+		SearchDTO: turboclient.SearchDTO{
+			LogicalOperator: "AND",
+		},
+	}
+
+	for _, o := range options {
+		o(&opts)
+	}
+
+	if len(opts.ClassName) == 0 {
+		errDiag := diag.NewErrorDiagnostic("Internal error", "Empty entity type specified")
+		return nil, &errDiag
+	}
+
+	if criteria, ok := nameCriteria(opts.ClassName, opts.namePattern); ok {
+		opts.CriteriaList = append(opts.CriteriaList, criteria)
+	}
+
+	// turbonomic returns the union of the entities in a multi-uuid scope,
+	// so each scope is searched on its own and the results are intersected
+	var entities turboclient.SearchResults
+	if len(opts.scopes) == 0 {
+		var err error
+		entities, err = searchAllEntities(client, opts.SearchDTO)
+		if err != nil {
+			errDiag := diag.NewErrorDiagnostic("Unable to search Turbonomic", err.Error())
+			return nil, &errDiag
+		}
+	}
+
+	for i, scope := range opts.scopes {
+		searchDTO := opts.SearchDTO
+		searchDTO.Scope = []string{scope}
+
+		scoped, err := searchAllEntities(client, searchDTO)
+		if err != nil {
+			errDiag := diag.NewErrorDiagnostic("Unable to search Turbonomic", err.Error())
+			return nil, &errDiag
+		}
+
+		if i == 0 {
+			entities = scoped
+			continue
+		}
+
+		scopedUuids := make(map[string]bool, len(scoped))
+		for _, entity := range scoped {
+			scopedUuids[entity.UUID] = true
+		}
+		inScope := turboclient.SearchResults{}
+		for _, entity := range entities {
+			if scopedUuids[entity.UUID] {
+				inScope = append(inScope, entity)
+			}
+		}
+		entities = inScope
+	}
+
+	// the tags are not part of the search criteria, and the name pattern is checked again since
+	// Turbonomic evaluates it with its own regex syntax
+	matches := turboclient.SearchResults{}
+	for _, entity := range entities {
+		if opts.namePattern != nil && !opts.namePattern.MatchString(entity.DisplayName) {
+			continue
+		}
+		if !hasTags(entity.Tags, opts.tags) {
+			continue
+		}
+		matches = append(matches, entity)
+	}
+
+	return matches, nil
+}
+
+/*
+searchAllEntities pages through the entities of a search. The cursor of a page is the position of
+its first entity, so the pages are requested until one returns fewer entities than the page size.
+A page that only repeats the entities already returned also ends the search.
+
+Parameters:
+  - client: The turbonomic client
+  - searchDTO: The search criteria
+
+Returns:
+  - turboclient.SearchResults: The entities of every page
+  - error: The error of the first page that failed
+*/
+func searchAllEntities(client turboclient.T8cClient, searchDTO turboclient.SearchDTO) (turboclient.SearchResults, error) {
+	entities := turboclient.SearchResults{}
+	seen := map[string]bool{}
+	for cursor := 0; ; cursor += entitySearchPageSize {
+		page, err := client.SearchEntities(searchDTO, turboclient.CommonReqParams{
+			Cursor: strconv.Itoa(cursor),
+			Limit:  entitySearchPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, entity := range page {
+			if seen[entity.UUID] {
+				continue
+			}
+			seen[entity.UUID] = true
+			entities = append(entities, entity)
+			added++
+		}
+
+		if len(page) < entitySearchPageSize || added == 0 {
+			return entities, nil
+		}
+	}
+}
+
+// nameCriteria returns the search criteria of a name pattern, when the entity type has a name
+// filter type. Turbonomic matches the whole name, so the pattern is wrapped to match anywhere in it
+// as the provider does, and the case insensitive flag of the pattern becomes the case sensitivity.
+func nameCriteria(entityType string, pattern *regexp.Regexp) (turboclient.Criteria, bool) {
+	filterType, ok := entityNameFilterTypes[entityType]
+	if pattern == nil || !ok {
+		return turboclient.Criteria{}, false
+	}
+
+	expr, caseInsensitive := strings.CutPrefix(pattern.String(), "(?i)")
+	return turboclient.Criteria{
+		ExpType:       "RXEQ",
+		ExpVal:        ".*(?:" + expr + ").*",
+		FilterType:    filterType,
+		CaseSensitive: !caseInsensitive,
+	}, true
+}
+
+// hasTags checks that every filter key is present in the entity tags with the filter value
+func hasTags(entityTags map[string][]string, filters map[string]string) bool {
+	for key, value := range filters {
+		if !slices.Contains(entityTags[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// EntityNamePattern returns the pattern used to match entity names. The name is matched
+// literally, the regex as is; nil is returned when neither is set.
+func EntityNamePattern(name string, nameRegex string, caseInsensitive bool) (*regexp.Regexp, error) {
+	expr := nameRegex
+	if len(name) != 0 {
+		expr = "^" + regexp.QuoteMeta(name) + "$"
+	}
+
+	if len(expr) == 0 {
+		return nil, nil
+	}
+
+	if caseInsensitive {
+		expr = "(?i)" + expr
+	}
+
+	return regexp.Compile(expr)
+}
+
+/*
+ResolveEntityScopes looks up the Turbonomic uuids of the business account with the given
+cloud account id and of the region with the given name. Empty values are skipped.
+
+Returns:
+  - []string: The uuids to be used as search scopes
+  - *diag.ErrorDiagnostic: An error diagnostic if a scope cannot be found
+*/
+func ResolveEntityScopes(client turboclient.T8cClient, accountId string, region string) ([]string, *diag.ErrorDiagnostic) {
+	var scopes []string
+
+	if len(accountId) != 0 {
+		accounts, errDiag := GetEntitiesByVendorId(client,
+			WithVendorId(accountId),
+			WithEntityTypeForVendorId(BusinessAccountEntityType))
+		if errDiag != nil {
+			return nil, errDiag
+		} else if len(accounts) == 0 {
+			errDiag := diag.NewErrorDiagnostic("Unable to resolve account",
+				fmt.Sprintf("business account with id %s not found in Turbonomic instance", accountId))
+			return nil, &errDiag
+		}
+		scopes = append(scopes, accounts[0].UUID)
+	}
+
+	if len(region) != 0 {
+		regions, errDiag := GetEntitiesByName(client,
+			WithEntityName(region),
+			WithEntityType(RegionEntityType),
+			WithCaseSensitive(false))
+		if errDiag != nil {
+			return nil, errDiag
+		} else if len(regions) == 0 {
+			errDiag := diag.NewErrorDiagnostic("Unable to resolve region",
+				fmt.Sprintf("region %s not found in Turbonomic instance", region))
+			return nil, &errDiag
+		}
+		scopes = append(scopes, regions[0].UUID)
+	}
+
+	return scopes, nil
+}
+
 type EntityOptionWithVendorId func(*turboclient.SearchRequestByVendorId)
 
 func WithVendorId(vendorId string) EntityOptionWithVendorId {
@@ -159,6 +452,45 @@ func GetEntitiesByVendorId(client turboclient.T8cClient, options ...EntityOption
 	return entity, nil
 }
 
+/*
+GetEntityDetails fetches a Turbonomic entity by uuid together with its tags.
+Returns:
+  - *turboclient.EntityResults: The entity, including its aspects and supply chain relationships
+  - map[string][]string: The tags of the entity
+  - *diag.ErrorDiagnostic: An error diagnostic if the operation fails
+*/
+func GetEntityDetails(client turboclient.T8cClient, uuid string) (*turboclient.EntityResults, map[string][]string, *diag.ErrorDiagnostic) {
+	if client == nil {
+		errDiag := diag.NewErrorDiagnostic("Internal error", "Internal error occurred while fetching entity")
+		return nil, nil, &errDiag
+	}
+
+	if len(uuid) == 0 {
+		errDiag := diag.NewErrorDiagnostic("Internal error", "Empty entity uuid specified")
+		return nil, nil, &errDiag
+	}
+
+	entityReq := turboclient.EntityRequest{Uuid: uuid}
+	entity, err := client.GetEntity(entityReq)
+	if err != nil {
+		errDiag := diag.NewErrorDiagnostic("Unable to get entity from Turbonomic", err.Error())
+		return nil, nil, &errDiag
+	}
+
+	entityTags, err := client.GetEntityTags(entityReq)
+	if err != nil {
+		errDiag := diag.NewErrorDiagnostic("Unable to get entity tags from Turbonomic", err.Error())
+		return nil, nil, &errDiag
+	}
+
+	tags := make(map[string][]string, len(entityTags))
+	for _, tag := range entityTags {
+		tags[tag.Key] = tag.Values
+	}
+
+	return entity, tags, nil
+}
+
 type ActionOption func(*turboclient.ActionsRequest)
 
 func WithEntityUuid(uuid string) ActionOption {
@@ -235,11 +567,12 @@ func GetFilteredEntityActions(client turboclient.T8cClient, entityUuid string, a
 		return nil, &errDiag
 	}
 
-	actions, err := client.GetActionsByUUID(turboclient.ActionsRequest{
+	actionsReq := turboclient.ActionsRequest{
 		Uuid:        entityUuid,
 		ActionState: actionState,
 		ActionType:  actionType,
-	})
+	}
+	actions, err := client.GetActionsByUUID(actionsReq)
 	if err != nil {
 		errDiag := diag.NewErrorDiagnostic("Unable to retrieve actions from Turbonomic", err.Error())
 		return nil, &errDiag
@@ -326,6 +659,14 @@ func WithStatistics(statistics []turboclient.StatisticRequest) StatsOption {
 	}
 }
 
+// WithStartDate sets the start date for the stats query
+// e.g., "-7d" for seven days ago
+func WithStartDate(startDate string) StatsOption {
+	return func(o *turboclient.StatsRequest) {
+		o.StartDate = startDate
+	}
+}
+
 // WithEndDate sets the end date for the stats query
 // e.g., "+10m" for 10 minutes from now
 func WithEndDate(endDate string) StatsOption {
@@ -367,6 +708,7 @@ Parameters:
 Options:
   - WithEntityUUID: Sets the entity UUID in the request
   - WithStatistics: Sets the statistics to retrieve
+  - WithStartDate: Sets the start date for the stats query (e.g., "-7d" for seven days ago)
   - WithEndDate: Sets the end date for the stats query (e.g., "+10m" for 10 minutes from now)
   - WithEntityTypeStats: Sets statistics based on entity type
 
@@ -426,50 +768,6 @@ func GetStats(
 	return stats, nil
 }
 
-func TagEntity(client *turboclient.Client, uuid string) error {
-	// tag VM entity with "optimized by" tag if not already tagged
-	if len(uuid) > 0 {
-		entityTagsReq := turboclient.EntityRequest{
-			Uuid: uuid}
-
-		entityTags, err := client.GetEntityTags(entityTagsReq)
-		if err != nil {
-			return fmt.Errorf("unable to retrieve entity tags from turbonomic: %v", err)
-		}
-
-		var alreadyTagged bool
-		for _, item := range entityTags {
-			if item.Key == OptimizedByTagName {
-				if slices.Contains(item.Values, OptimizedByTagValue) {
-					alreadyTagged = true
-					break
-				}
-			}
-		}
-
-		if !alreadyTagged {
-			tagEntityReq := turboclient.TagEntityRequest{
-				Uuid: uuid,
-				Tags: []turboclient.Tag{
-					{
-						Key:    OptimizedByTagName,
-						Values: []string{OptimizedByTagValue},
-					},
-				},
-			}
-
-			_, err := client.TagEntity(tagEntityReq)
-			if err != nil {
-				if strings.Contains(err.Error(), TagAlreadyExistsErrorMsg) {
-					return nil
-				}
-				return fmt.Errorf("unable to tag an entity in turbonomic: %v", err)
-			}
-		}
-	}
-	return nil
-}
-
 // Nullable is an interface for types that can be null
 type Nullable interface {
 	IsNull() bool
@@ -530,7 +828,6 @@ checks if the window is open for execution.
 
 Parameters:
   - actions: The Turbonomic client to use for API calls
-  - options: A variadic list of StatsOption functions to configure the request
 
 Returns:
   - bool: whether or not the action can be executed right now
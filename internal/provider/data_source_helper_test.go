@@ -5,8 +5,10 @@ package provider
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -120,6 +122,282 @@ func TestGetEntitiesByName(t *testing.T) {
 	})
 }
 
+func TestSearchEntitiesByFilter(t *testing.T) {
+	entityType := "VirtualMachine"
+	entities := turboclient.SearchResults{
+		{UUID: "uuid1", DisplayName: "payments-api-1", Tags: map[string][]string{"team": {"payments"}}},
+		{UUID: "uuid2", DisplayName: "payments-api-2", Tags: map[string][]string{"team": {"payments", "shared"}}},
+		{UUID: "uuid3", DisplayName: "Billing-API", Tags: map[string][]string{"team": {"billing"}}},
+	}
+
+	t.Run("Client is nil", func(t *testing.T) {
+		_, errDiag := SearchEntitiesByFilter(nil, WithFilterEntityType(entityType))
+		assert.NotNil(t, errDiag)
+	})
+
+	t.Run("Entity type is empty", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		_, errDiag := SearchEntitiesByFilter(mockClient, WithFilterEnvironmentType("CLOUD"))
+		assert.NotNil(t, errDiag)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Search error", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("SearchEntities", mock.Anything, mock.Anything).Return(turboclient.SearchResults{}, assert.AnError).Once()
+
+		_, errDiag := SearchEntitiesByFilter(mockClient, WithFilterEntityType(entityType))
+		assert.NotNil(t, errDiag)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Filter by name pattern and tags", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("SearchEntities", mock.MatchedBy(func(dto turboclient.SearchDTO) bool {
+			return dto.ClassName == entityType && dto.EnvironmentType == "CLOUD" && dto.LogicalOperator == "AND"
+		}), mock.Anything).Return(entities, nil).Twice()
+
+		pattern, err := EntityNamePattern("", "^payments-", false)
+		assert.NoError(t, err)
+		matches, errDiag := SearchEntitiesByFilter(mockClient,
+			WithFilterEntityType(entityType),
+			WithFilterEnvironmentType("CLOUD"),
+			WithNamePattern(pattern),
+			WithTagFilters(map[string]string{"team": "shared"}))
+		assert.Nil(t, errDiag)
+		assert.Len(t, matches, 1)
+		assert.Equal(t, "uuid2", matches[0].UUID)
+
+		pattern, err = EntityNamePattern("billing-api", "", true)
+		assert.NoError(t, err)
+		matches, errDiag = SearchEntitiesByFilter(mockClient,
+			WithFilterEntityType(entityType),
+			WithFilterEnvironmentType("CLOUD"),
+			WithNamePattern(pattern))
+		assert.Nil(t, errDiag)
+		assert.Len(t, matches, 1)
+		assert.Equal(t, "uuid3", matches[0].UUID)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Scopes are intersected", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("SearchEntities", mock.MatchedBy(func(dto turboclient.SearchDTO) bool {
+			return slices.Equal(dto.Scope, []string{"account"})
+		}), mock.Anything).Return(entities, nil).Once()
+		mockClient.On("SearchEntities", mock.MatchedBy(func(dto turboclient.SearchDTO) bool {
+			return slices.Equal(dto.Scope, []string{"region"})
+		}), mock.Anything).Return(entities[1:], nil).Once()
+
+		matches, errDiag := SearchEntitiesByFilter(mockClient, WithFilterEntityType(entityType), WithScopes("account", "region"))
+		assert.Nil(t, errDiag)
+		assert.Equal(t, entities[1:], matches)
+
+		mockClient.AssertExpectations(t)
+	})
+}
+
+// Tests that the search pages through the cursor until a page is not full
+func TestSearchEntitiesByFilterPages(t *testing.T) {
+	entityPage := func(first, count int) turboclient.SearchResults {
+		page := make(turboclient.SearchResults, count)
+		for i := range page {
+			page[i].UUID = fmt.Sprintf("uuid%d", first+i)
+			page[i].DisplayName = fmt.Sprintf("vm-%d", first+i)
+		}
+		return page
+	}
+	pageParams := func(cursor string) turboclient.CommonReqParams {
+		return turboclient.CommonReqParams{Cursor: cursor, Limit: entitySearchPageSize}
+	}
+
+	t.Run("Multiple pages", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("SearchEntities", mock.Anything, pageParams("0")).Return(entityPage(0, entitySearchPageSize), nil).Once()
+		mockClient.On("SearchEntities", mock.Anything, pageParams("500")).Return(entityPage(500, entitySearchPageSize), nil).Once()
+		mockClient.On("SearchEntities", mock.Anything, pageParams("1000")).Return(entityPage(1000, 2), nil).Once()
+
+		matches, errDiag := SearchEntitiesByFilter(mockClient, WithFilterEntityType("VirtualMachine"))
+		assert.Nil(t, errDiag)
+		assert.Len(t, matches, 2*entitySearchPageSize+2)
+		assert.Equal(t, "uuid0", matches[0].UUID)
+		assert.Equal(t, "uuid1001", matches[len(matches)-1].UUID)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Page ignoring the cursor", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("SearchEntities", mock.Anything, mock.Anything).Return(entityPage(0, entitySearchPageSize), nil).Twice()
+
+		matches, errDiag := SearchEntitiesByFilter(mockClient, WithFilterEntityType("VirtualMachine"))
+		assert.Nil(t, errDiag)
+		assert.Len(t, matches, entitySearchPageSize)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Error on a later page", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("SearchEntities", mock.Anything, pageParams("0")).Return(entityPage(0, entitySearchPageSize), nil).Once()
+		mockClient.On("SearchEntities", mock.Anything, pageParams("500")).Return(turboclient.SearchResults{}, assert.AnError).Once()
+
+		_, errDiag := SearchEntitiesByFilter(mockClient, WithFilterEntityType("VirtualMachine"))
+		assert.NotNil(t, errDiag)
+
+		mockClient.AssertExpectations(t)
+	})
+}
+
+// Tests that the name pattern is sent in the search criteria of the entity types with a name filter type
+func TestSearchEntitiesByFilterNameCriteria(t *testing.T) {
+	pattern, err := EntityNamePattern("", "^payments-", true)
+	assert.NoError(t, err)
+
+	mockClient := new(MockT8cClient)
+	mockClient.On("SearchEntities", mock.MatchedBy(func(dto turboclient.SearchDTO) bool {
+		return dto.ClassName == "VirtualMachine" && slices.Equal(dto.CriteriaList, []turboclient.Criteria{{
+			ExpType:       "RXEQ",
+			ExpVal:        ".*(?:^payments-).*",
+			FilterType:    "vmsByName",
+			CaseSensitive: false,
+		}})
+	}), mock.Anything).Return(turboclient.SearchResults{}, nil).Once()
+	mockClient.On("SearchEntities", mock.MatchedBy(func(dto turboclient.SearchDTO) bool {
+		return dto.ClassName == VirtualVolumeEntityType && len(dto.CriteriaList) == 0
+	}), mock.Anything).Return(turboclient.SearchResults{}, nil).Once()
+
+	_, errDiag := SearchEntitiesByFilter(mockClient, WithFilterEntityType("VirtualMachine"), WithNamePattern(pattern))
+	assert.Nil(t, errDiag)
+	_, errDiag = SearchEntitiesByFilter(mockClient, WithFilterEntityType(VirtualVolumeEntityType), WithNamePattern(pattern))
+	assert.Nil(t, errDiag)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestEntityNamePattern(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		entityName      string
+		nameRegex       string
+		caseInsensitive bool
+		matches         []string
+		noMatches       []string
+		expectErr       bool
+	}{
+		{
+			name:       "Literal name",
+			entityName: "vm.1",
+			matches:    []string{"vm.1"},
+			noMatches:  []string{"vmx1", "VM.1", "vm.10"},
+		},
+		{
+			name:            "Case insensitive name",
+			entityName:      "vm.1",
+			caseInsensitive: true,
+			matches:         []string{"vm.1", "VM.1"},
+			noMatches:       []string{"vm.10"},
+		},
+		{
+			name:      "Regex",
+			nameRegex: "^web-[0-9]+$",
+			matches:   []string{"web-1", "web-22"},
+			noMatches: []string{"WEB-1", "web-a"},
+		},
+		{
+			name:      "Invalid regex",
+			nameRegex: "web-(",
+			expectErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pattern, err := EntityNamePattern(tc.entityName, tc.nameRegex, tc.caseInsensitive)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			for _, name := range tc.matches {
+				assert.True(t, pattern.MatchString(name), name)
+			}
+			for _, name := range tc.noMatches {
+				assert.False(t, pattern.MatchString(name), name)
+			}
+		})
+	}
+
+	pattern, err := EntityNamePattern("", "", false)
+	assert.NoError(t, err)
+	assert.Nil(t, pattern)
+}
+
+func TestResolveEntityScopes(t *testing.T) {
+	t.Run("No scopes", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		scopes, errDiag := ResolveEntityScopes(mockClient, "", "")
+		assert.Nil(t, errDiag)
+		assert.Empty(t, scopes)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Account and region", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("SearchEntityByVendorId", mock.MatchedBy(func(req turboclient.SearchRequestByVendorId) bool {
+			return req.EntityType == BusinessAccountEntityType
+		})).Return(turboclient.SearchResults{{UUID: "account"}}, nil).Once()
+		mockClient.On("SearchEntityByName", mock.MatchedBy(func(req turboclient.SearchRequest) bool {
+			return req.EntityType == RegionEntityType && !req.CaseSensitive
+		})).Return(turboclient.SearchResults{{UUID: "region"}}, nil).Once()
+
+		scopes, errDiag := ResolveEntityScopes(mockClient, "123456789012", "us-east-1")
+		assert.Nil(t, errDiag)
+		assert.Equal(t, []string{"account", "region"}, scopes)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Unknown region", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("SearchEntityByName", mock.Anything).Return(turboclient.SearchResults{}, nil).Once()
+
+		_, errDiag := ResolveEntityScopes(mockClient, "", "mars-north-1")
+		assert.NotNil(t, errDiag)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestGetEntityDetails(t *testing.T) {
+	t.Run("Client is nil", func(t *testing.T) {
+		_, _, errDiag := GetEntityDetails(nil, "uuid")
+		assert.NotNil(t, errDiag)
+	})
+
+	t.Run("Get entity error", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		mockClient.On("GetEntity", mock.Anything).Return((*turboclient.EntityResults)(nil), assert.AnError).Once()
+
+		_, _, errDiag := GetEntityDetails(mockClient, "uuid")
+		assert.NotNil(t, errDiag)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Entity with tags", func(t *testing.T) {
+		mockClient := new(MockT8cClient)
+		expected := &turboclient.EntityResults{UUID: "uuid", DisplayName: "vm"}
+		mockClient.On("GetEntity", turboclient.EntityRequest{Uuid: "uuid"}).Return(expected, nil).Once()
+		mockClient.On("GetEntityTags", turboclient.EntityRequest{Uuid: "uuid"}).Return([]turboclient.Tag{
+			{Key: "team", Values: []string{"payments"}},
+		}, nil).Once()
+
+		entity, tags, errDiag := GetEntityDetails(mockClient, "uuid")
+		assert.Nil(t, errDiag)
+		assert.Equal(t, expected, entity)
+		assert.Equal(t, map[string][]string{"team": {"payments"}}, tags)
+		mockClient.AssertExpectations(t)
+	})
+}
+
 func TestGetActions(t *testing.T) {
 	entityUUID := "exampleUuid"
 	actionTypes := []string{"exampleAction"}
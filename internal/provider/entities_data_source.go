@@ -0,0 +1,228 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+var (
+	_ datasource.DataSource              = &entitiesDataSource{}
+	_ datasource.DataSourceWithConfigure = &entitiesDataSource{}
+
+	cloudTypes = []string{"AWS", "AZURE", "GCP", "IBM", "UNKNOWN"}
+)
+
+func NewEntitiesDataSource() datasource.DataSource {
+	return &entitiesDataSource{}
+}
+
+// entitiesDataSource defines the data source implementation.
+type entitiesDataSource struct {
+	client   *turboclient.Client
+	requests *clientRequests
+}
+
+// EntitiesModel describes the data source data model.
+type EntitiesModel struct {
+	EntityType      types.String         `tfsdk:"entity_type"`
+	EnvType         types.String         `tfsdk:"environment_type"`
+	CloudType       types.String         `tfsdk:"cloud_type"`
+	NameRegex       types.String         `tfsdk:"name_regex"`
+	CaseInsensitive types.Bool           `tfsdk:"case_insensitive"`
+	TagFilters      types.Map            `tfsdk:"tag_filters"`
+	Entities        []EntitySummaryModel `tfsdk:"entities"`
+}
+
+// EntitySummaryModel describes a single entity returned by the search.
+type EntitySummaryModel struct {
+	Uuid        types.String `tfsdk:"uuid"`
+	DisplayName types.String `tfsdk:"display_name"`
+	VendorIds   types.Map    `tfsdk:"vendor_ids"`
+	State       types.String `tfsdk:"state"`
+	Tags        types.Map    `tfsdk:"tags"`
+	Template    types.String `tfsdk:"template"`
+}
+
+func (d *entitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entities"
+}
+
+func (d *entitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The following example demonstrates the syntax for the `turbonomic_entities` data source. This can be used to list the entities discovered by Turbonomic",
+		Attributes: map[string]schema.Attribute{
+			"entity_type": schema.StringAttribute{
+				MarkdownDescription: "case insensitive type of the entities",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(slices.Collect(maps.Values(entityTypes))...),
+				},
+			},
+			"environment_type": schema.StringAttribute{
+				MarkdownDescription: "filter the entities by environment type",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(environmentTypes...),
+				},
+			},
+			"cloud_type": schema.StringAttribute{
+				MarkdownDescription: "filter the entities by cloud type",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(cloudTypes...),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "regular expression (RE2 syntax) matched against the entity name, " +
+					"and sent to Turbonomic to narrow the search of the virtual machines and database servers",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"case_insensitive": schema.BoolAttribute{
+				MarkdownDescription: "match name_regex ignoring case, defaults to false",
+				Optional:            true,
+			},
+			"tag_filters": schema.MapAttribute{
+				MarkdownDescription: "only return entities that have all of the given tags, E.G: { team = \"payments\" }",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"entities": schema.ListNestedAttribute{
+				MarkdownDescription: "list of matching entities",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "Turbonomic UUID of the entity",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "a user readable name of the entity",
+							Computed:            true,
+						},
+						"vendor_ids": schema.MapAttribute{
+							MarkdownDescription: "the mapping of target identifier to vendor-provided identity of this entity on the remote target",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "state of the entity",
+							Computed:            true,
+						},
+						"tags": schema.MapAttribute{
+							MarkdownDescription: "tags are the metadata defined in name/value pairs. Each name can have multiple values.",
+							Computed:            true,
+							ElementType:         types.ListType{ElemType: types.StringType},
+						},
+						"template": schema.StringAttribute{
+							MarkdownDescription: "name of the template of the entity, such as the instance type of a VM",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *entitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected: *TurbonomicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+}
+
+func (d *entitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state EntitiesModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	client := d.requests.client(ctx, d.client)
+
+	namePattern, err := EntityNamePattern("", state.NameRegex.ValueString(), state.CaseInsensitive.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+		return
+	}
+
+	tags := map[string]string{}
+	resp.Diagnostics.Append(state.TagFilters.ElementsAs(ctx, &tags, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entities, errDiag := SearchEntitiesByFilter(client,
+		WithFilterEntityType(entityTypes[strings.ToLower(state.EntityType.ValueString())]),
+		WithFilterEnvironmentType(strings.ToUpper(state.EnvType.ValueString())),
+		WithFilterCloudType(strings.ToUpper(state.CloudType.ValueString())),
+		WithNamePattern(namePattern),
+		WithTagFilters(tags))
+	if errDiag != nil {
+		tflog.Error(ctx, errDiag.Detail())
+		resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("entities found: %d\n", len(entities)))
+
+	state.Entities = []EntitySummaryModel{}
+	for _, entity := range entities {
+		vendorIds, diags := types.MapValueFrom(ctx, types.StringType, entity.VendorIds)
+		resp.Diagnostics.Append(diags...)
+
+		entityTags, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, entity.Tags)
+		resp.Diagnostics.Append(diags...)
+
+		template := types.StringNull()
+		if len(entity.Template.DisplayName) != 0 {
+			template = types.StringValue(entity.Template.DisplayName)
+		}
+
+		state.Entities = append(state.Entities, EntitySummaryModel{
+			Uuid:        types.StringValue(entity.UUID),
+			DisplayName: types.StringValue(entity.DisplayName),
+			VendorIds:   vendorIds,
+			State:       types.StringValue(entity.State),
+			Tags:        entityTags,
+			Template:    template,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	entitiesSearchResponse = "entities_search_VM_success.json"
+	entitiesDataSourceName = "data.turbonomic_entities.test"
+)
+
+// Test entities data source listing the entities with the given filters
+func TestEntitiesDataSource(t *testing.T) {
+
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			ResponseBody: loadTestFile(t, entitiesTestDataBaseDir, entitiesSearchResponse),
+			ResponseCode: http.StatusOK,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	providerConfig := fmt.Sprintf(config, strings.TrimPrefix(mockServer.URL, "https://"))
+
+	for _, tc := range []struct {
+		name    string
+		filters string
+		checks  []resource.TestCheckFunc
+	}{
+		{
+			name:    "All entities",
+			filters: `cloud_type = "aws"`,
+			checks: []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.#", "3"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.uuid", "75930461864801"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.display_name", "payments-api-1"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.state", "ACTIVE"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.template", "m5.large"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.vendor_ids.aws-123456789012", "i-0a1b2c3d4e5f60001"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.tags.team.0", "payments"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.2.display_name", "payments-batch"),
+			},
+		},
+		{
+			name: "Tag and name filters",
+			filters: `tag_filters      = { team = "payments" }
+								name_regex       = "API-2$"
+								case_insensitive = true`,
+			checks: []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.#", "1"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.uuid", "75930461864802"),
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.template", "m5.xlarge"),
+			},
+		},
+		{
+			name:    "No match",
+			filters: `tag_filters = { team = "unknown" }`,
+			checks: []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.#", "0"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig +
+							`data "turbonomic_entities" "test" {
+								entity_type = "virtualmachine"
+								` + tc.filters + `
+						    }`,
+						Check: resource.ComposeAggregateTestCheckFunc(tc.checks...),
+					},
+				},
+			})
+		})
+	}
+}
+
+// Test entities data source returning the entities of every page of the search
+func TestEntitiesDataSourcePages(t *testing.T) {
+	searchPage := func(first, count int) string {
+		entities := make([]map[string]any, count)
+		for i := range entities {
+			entities[i] = map[string]any{
+				"uuid":        fmt.Sprintf("7593046186%04d", first+i),
+				"displayName": fmt.Sprintf("vm-%d", first+i),
+				"className":   "VirtualMachine",
+				"state":       "ACTIVE",
+			}
+		}
+		body, err := json.Marshal(entities)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			Query:        map[string]string{"cursor": "0", "limit": "500"},
+			ResponseBody: searchPage(0, entitySearchPageSize),
+			ResponseCode: http.StatusOK,
+			MinTimes:     1,
+		},
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			Query:        map[string]string{"cursor": "500", "limit": "500"},
+			ResponseBody: searchPage(entitySearchPageSize, 2),
+			ResponseCode: http.StatusOK,
+			MinTimes:     1,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, strings.TrimPrefix(mockServer.URL, "https://")) +
+					`data "turbonomic_entities" "test" {
+						entity_type = "virtualmachine"
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.#", "502"),
+					resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.0.display_name", "vm-0"),
+					resource.TestCheckResourceAttr(entitiesDataSourceName, "entities.501.display_name", "vm-501"),
+				),
+			},
+		},
+	})
+}
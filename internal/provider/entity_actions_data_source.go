@@ -5,18 +5,19 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"maps"
-	"reflect"
 	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -24,8 +25,9 @@ import (
 )
 
 var (
-	_ datasource.DataSource              = &entityActionsDataSource{}
-	_ datasource.DataSourceWithConfigure = &entityActionsDataSource{}
+	_ datasource.DataSource                     = &entityActionsDataSource{}
+	_ datasource.DataSourceWithConfigure        = &entityActionsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &entityActionsDataSource{}
 
 	actionTypes      = []string{"START", "MOVE", "SCALE", "ALLOCATE", "SUSPEND", "PROVISION", "RECONFIGURE", "RESIZE", "DELETE", "RIGHT_SIZE", "BUY_RI"}
 	environmentTypes = []string{"HYBRID", "CLOUD", "ONPREM", "UNKNOWN"}
@@ -82,204 +84,145 @@ var (
 )
 
 type EntityActionsModel struct {
-	EntityUuid  types.String  `tfsdk:"entity_uuid"`
-	EntityName  types.String  `tfsdk:"entity_name"`
-	EntityType  types.String  `tfsdk:"entity_type"`
-	ActionTypes types.List    `tfsdk:"action_types"`
-	EnvType     types.String  `tfsdk:"environment_type"`
-	States      types.List    `tfsdk:"states"`
-	Actions     []ActionModel `tfsdk:"actions"`
+	EntityUuid           types.String  `tfsdk:"entity_uuid"`
+	EntityUuids          types.List    `tfsdk:"entity_uuids"`
+	EntityName           types.String  `tfsdk:"entity_name"`
+	NameRegex            types.String  `tfsdk:"name_regex"`
+	CaseInsensitive      types.Bool    `tfsdk:"case_insensitive"`
+	TagFilters           types.Map     `tfsdk:"tag_filters"`
+	AccountId            types.String  `tfsdk:"account_id"`
+	Region               types.String  `tfsdk:"region"`
+	AllMatches           types.Bool    `tfsdk:"all_matches"`
+	DisableEntityTagging types.Bool    `tfsdk:"disable_entity_tagging"`
+	EntityType           types.String  `tfsdk:"entity_type"`
+	ActionTypes          types.List    `tfsdk:"action_types"`
+	EnvType              types.String  `tfsdk:"environment_type"`
+	States               types.List    `tfsdk:"states"`
+	Actions              []ActionModel `tfsdk:"actions"`
 }
 
+// ActionModel describes an action of the data source. The fields are named after the fields of
+// turboclient.ActionResults, the conversion between both is generated in converters_gen.go.
 type ActionModel struct {
+	DisplayName     types.String          `tfsdk:"display_name"`
+	ActionType      types.String          `tfsdk:"action_type"`
+	ActionState     types.String          `tfsdk:"action_state"`
+	ActionMode      types.String          `tfsdk:"action_mode"`
+	Details         types.String          `tfsdk:"details"`
+	Target          ActionTargetModel     `tfsdk:"target"`
+	CurrentEntity   ActionEntityModel     `tfsdk:"current_entity"`
+	NewEntity       ActionEntityModel     `tfsdk:"new_entity"`
+	CurrentValue    types.String          `tfsdk:"current_value"`
+	NewValue        types.String          `tfsdk:"new_value"`
+	ValueUnits      types.String          `tfsdk:"value_units"`
+	ResizeAttribute types.String          `tfsdk:"resize_attribute"`
+	UUID            types.String          `tfsdk:"uuid"`
+	ActionImpactID  types.Int64           `tfsdk:"action_impact_id"`
+	MarketID        types.Int64           `tfsdk:"market_id"`
+	CreateTime      types.String          `tfsdk:"create_time"`
+	Importance      types.Float32         `tfsdk:"importance"`
+	Template        ActionTemplateModel   `tfsdk:"template"`
+	Risk            ActionRiskModel       `tfsdk:"risk"`
+	Stats           []ActionStatModel     `tfsdk:"stats"`
+	CurrentLocation ActionLocationModel   `tfsdk:"current_location"`
+	NewLocation     ActionLocationModel   `tfsdk:"new_location"`
+	CompoundActions []CompoundActionModel `tfsdk:"compound_actions"`
+	Source          types.String          `tfsdk:"source"`
+	ActionID        types.Int64           `tfsdk:"action_id"`
+}
+
+// DiscoveredByModel describes the target that discovered an entity
+type DiscoveredByModel struct {
+	UUID              types.String `tfsdk:"uuid"`
+	DisplayName       types.String `tfsdk:"display_name"`
+	IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
+	Category          types.String `tfsdk:"category"`
+	Type              types.String `tfsdk:"type"`
+	Readonly          types.Bool   `tfsdk:"read_only"`
+}
+
+// ActionTargetModel describes the entity an action applies to
+type ActionTargetModel struct {
+	UUID            types.String         `tfsdk:"uuid"`
+	DisplayName     types.String         `tfsdk:"display_name"`
+	ClassName       types.String         `tfsdk:"class_name"`
+	EnvironmentType types.String         `tfsdk:"environment_type"`
+	DiscoveredBy    DiscoveredByModel    `tfsdk:"discovered_by"`
+	VendorIds       map[string]string    `tfsdk:"vendor_ids"`
+	State           types.String         `tfsdk:"state"`
+	Aspects         jsontypes.Normalized `tfsdk:"aspects"`
+	Tags            map[string][]string  `tfsdk:"tags"`
+}
+
+// ActionEntityModel describes the current or new tier of an action
+type ActionEntityModel struct {
+	UUID            types.String      `tfsdk:"uuid"`
+	DisplayName     types.String      `tfsdk:"display_name"`
+	ClassName       types.String      `tfsdk:"class_name"`
+	EnvironmentType types.String      `tfsdk:"environment_type"`
+	DiscoveredBy    DiscoveredByModel `tfsdk:"discovered_by"`
+	VendorIds       map[string]string `tfsdk:"vendor_ids"`
+	State           types.String      `tfsdk:"state"`
+}
+
+// ActionLocationModel describes the current or new region of an action
+type ActionLocationModel struct {
+	UUID            types.String      `tfsdk:"uuid"`
+	DisplayName     types.String      `tfsdk:"display_name"`
+	ClassName       types.String      `tfsdk:"class_name"`
+	EnvironmentType types.String      `tfsdk:"environment_type"`
+	DiscoveredBy    DiscoveredByModel `tfsdk:"discovered_by"`
+	VendorIds       map[string]string `tfsdk:"vendor_ids"`
+}
+
+// ActionTemplateModel describes the template of an action
+type ActionTemplateModel struct {
+	UUID        types.String `tfsdk:"uuid"`
+	DisplayName types.String `tfsdk:"display_name"`
+	ClassName   types.String `tfsdk:"class_name"`
+	Discovered  types.Bool   `tfsdk:"discovered"`
+	EnableMatch types.Bool   `tfsdk:"enable_match"`
+}
+
+// ActionRiskModel describes the risk an action addresses
+type ActionRiskModel struct {
+	SubCategory       types.String   `tfsdk:"sub_category"`
+	Description       types.String   `tfsdk:"description"`
+	Severity          types.String   `tfsdk:"severity"`
+	Importance        types.Float32  `tfsdk:"importance"`
+	ReasonCommodities []types.String `tfsdk:"reason_commodities"`
+}
+
+// ActionStatModel describes a statistic of an action
+type ActionStatModel struct {
+	Name    types.String            `tfsdk:"name"`
+	Filters []ActionStatFilterModel `tfsdk:"filters"`
+	Units   types.String            `tfsdk:"units"`
+	Value   types.Float64           `tfsdk:"value"`
+}
+
+// ActionStatFilterModel describes a filter of an action statistic
+type ActionStatFilterModel struct {
+	Type        types.String `tfsdk:"type"`
+	Value       types.String `tfsdk:"value"`
 	DisplayName types.String `tfsdk:"display_name"`
-	ActionType  types.String `tfsdk:"action_type"`
-	ActionState types.String `tfsdk:"action_state"`
-	ActionMode  types.String `tfsdk:"action_mode"`
-	Details     types.String `tfsdk:"details"`
-	Target      struct {
-		UUID            types.String `tfsdk:"uuid"`
-		DisplayName     types.String `tfsdk:"display_name"`
-		ClassName       types.String `tfsdk:"class_name"`
-		EnvironmentType types.String `tfsdk:"environment_type"`
-		DiscoveredBy    struct {
-			UUID              types.String `tfsdk:"uuid"`
-			DisplayName       types.String `tfsdk:"display_name"`
-			IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
-			Category          types.String `tfsdk:"category"`
-			Type              types.String `tfsdk:"type"`
-			Readonly          types.Bool   `tfsdk:"read_only"`
-		} `tfsdk:"discovered_by"`
-		VendorIds map[string]string    `tfsdk:"vendor_ids"`
-		State     types.String         `tfsdk:"state"`
-		Aspects   jsontypes.Normalized `tfsdk:"aspects"`
-		Tags      map[string][]string  `tfsdk:"tags"`
-	} `tfsdk:"target"`
-	CurrentEntity struct {
-		UUID            types.String `tfsdk:"uuid"`
-		DisplayName     types.String `tfsdk:"display_name"`
-		ClassName       types.String `tfsdk:"class_name"`
-		EnvironmentType types.String `tfsdk:"environment_type"`
-		DiscoveredBy    struct {
-			UUID              types.String `tfsdk:"uuid"`
-			DisplayName       types.String `tfsdk:"display_name"`
-			IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
-			Category          types.String `tfsdk:"category"`
-			Type              types.String `tfsdk:"type"`
-			Readonly          types.Bool   `tfsdk:"read_only"`
-		} `tfsdk:"discovered_by"`
-		VendorIds map[string]string `tfsdk:"vendor_ids"`
-		State     types.String      `tfsdk:"state"`
-	} `tfsdk:"current_entity"`
-	NewEntity struct {
-		UUID            types.String `tfsdk:"uuid"`
-		DisplayName     types.String `tfsdk:"display_name"`
-		ClassName       types.String `tfsdk:"class_name"`
-		EnvironmentType types.String `tfsdk:"environment_type"`
-		DiscoveredBy    struct {
-			UUID              types.String `tfsdk:"uuid"`
-			DisplayName       types.String `tfsdk:"display_name"`
-			IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
-			Category          types.String `tfsdk:"category"`
-			Type              types.String `tfsdk:"type"`
-			Readonly          types.Bool   `tfsdk:"read_only"`
-		} `tfsdk:"discovered_by"`
-		VendorIds map[string]string `tfsdk:"vendor_ids"`
-		State     types.String      `tfsdk:"state"`
-	} `tfsdk:"new_entity"`
-	CurrentValue    types.String  `tfsdk:"current_value"`
-	NewValue        types.String  `tfsdk:"new_value"`
-	ValueUnits      types.String  `tfsdk:"value_units"`
-	ResizeAttribute types.String  `tfsdk:"resize_attribute"`
-	UUID            types.String  `tfsdk:"uuid"`
-	ActionImpactID  types.Int64   `tfsdk:"action_impact_id"`
-	MarketID        types.Int64   `tfsdk:"market_id"`
-	CreateTime      types.String  `tfsdk:"create_time"`
-	Importance      types.Float32 `tfsdk:"importance"`
-	Template        struct {
-		UUID        types.String `tfsdk:"uuid"`
-		DisplayName types.String `tfsdk:"display_name"`
-		ClassName   types.String `tfsdk:"class_name"`
-		Discovered  types.Bool   `tfsdk:"discovered"`
-		EnableMatch types.Bool   `tfsdk:"enable_match"`
-	} `tfsdk:"template"`
-	Risk struct {
-		SubCategory       types.String   `tfsdk:"sub_category"`
-		Description       types.String   `tfsdk:"description"`
-		Severity          types.String   `tfsdk:"severity"`
-		Importance        types.Float32  `tfsdk:"importance"`
-		ReasonCommodities []types.String `tfsdk:"reason_commodities"`
-	} `tfsdk:"risk"`
-	Stats []struct {
-		Name    types.String `tfsdk:"name"`
-		Filters []struct {
-			Type        types.String `tfsdk:"type"`
-			Value       types.String `tfsdk:"value"`
-			DisplayName types.String `tfsdk:"display_name"`
-		} `tfsdk:"filters"`
-		Units types.String  `tfsdk:"units"`
-		Value types.Float64 `tfsdk:"value"`
-	} `tfsdk:"stats"`
-	CurrentLocation struct {
-		UUID            types.String `tfsdk:"uuid"`
-		DisplayName     types.String `tfsdk:"display_name"`
-		ClassName       types.String `tfsdk:"class_name"`
-		EnvironmentType types.String `tfsdk:"environment_type"`
-		DiscoveredBy    struct {
-			UUID              types.String `tfsdk:"uuid"`
-			DisplayName       types.String `tfsdk:"display_name"`
-			IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
-			Category          types.String `tfsdk:"category"`
-			Type              types.String `tfsdk:"type"`
-			Readonly          types.Bool   `tfsdk:"read_only"`
-		} `tfsdk:"discovered_by"`
-		VendorIds map[string]string `tfsdk:"vendor_ids"`
-	} `tfsdk:"current_location"`
-	NewLocation struct {
-		UUID            types.String `tfsdk:"uuid"`
-		DisplayName     types.String `tfsdk:"display_name"`
-		ClassName       types.String `tfsdk:"class_name"`
-		EnvironmentType types.String `tfsdk:"environment_type"`
-		DiscoveredBy    struct {
-			UUID              types.String `tfsdk:"uuid"`
-			DisplayName       types.String `tfsdk:"display_name"`
-			IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
-			Category          types.String `tfsdk:"category"`
-			Type              types.String `tfsdk:"type"`
-			Readonly          types.Bool   `tfsdk:"read_only"`
-		} `tfsdk:"discovered_by"`
-		VendorIds map[string]string `tfsdk:"vendor_ids"`
-	} `tfsdk:"new_location"`
-	CompoundActions []struct {
-		DisplayName types.String `tfsdk:"display_name"`
-		ActionType  types.String `tfsdk:"action_type"`
-		ActionState types.String `tfsdk:"action_state"`
-		ActionMode  types.String `tfsdk:"action_mode"`
-		Details     types.String `tfsdk:"details"`
-		Target      struct {
-			UUID            types.String `tfsdk:"uuid"`
-			DisplayName     types.String `tfsdk:"display_name"`
-			ClassName       types.String `tfsdk:"class_name"`
-			EnvironmentType types.String `tfsdk:"environment_type"`
-			DiscoveredBy    struct {
-				UUID              types.String `tfsdk:"uuid"`
-				DisplayName       types.String `tfsdk:"display_name"`
-				IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
-				Category          types.String `tfsdk:"category"`
-				Type              types.String `tfsdk:"type"`
-				Readonly          types.Bool   `tfsdk:"read_only"`
-			} `tfsdk:"discovered_by"`
-			VendorIds map[string]string    `tfsdk:"vendor_ids"`
-			State     types.String         `tfsdk:"state"`
-			Aspects   jsontypes.Normalized `tfsdk:"aspects"`
-			Tags      map[string][]string  `tfsdk:"tags"`
-		} `tfsdk:"target"`
-		CurrentEntity struct {
-			UUID            types.String `tfsdk:"uuid"`
-			DisplayName     types.String `tfsdk:"display_name"`
-			ClassName       types.String `tfsdk:"class_name"`
-			EnvironmentType types.String `tfsdk:"environment_type"`
-			DiscoveredBy    struct {
-				UUID              types.String `tfsdk:"uuid"`
-				DisplayName       types.String `tfsdk:"display_name"`
-				IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
-				Category          types.String `tfsdk:"category"`
-				Type              types.String `tfsdk:"type"`
-				Readonly          types.Bool   `tfsdk:"read_only"`
-			} `tfsdk:"discovered_by"`
-			VendorIds map[string]string `tfsdk:"vendor_ids"`
-			State     types.String      `tfsdk:"state"`
-		} `tfsdk:"current_entity"`
-		NewEntity struct {
-			UUID            types.String `tfsdk:"uuid"`
-			DisplayName     types.String `tfsdk:"display_name"`
-			ClassName       types.String `tfsdk:"class_name"`
-			EnvironmentType types.String `tfsdk:"environment_type"`
-			DiscoveredBy    struct {
-				UUID              types.String `tfsdk:"uuid"`
-				DisplayName       types.String `tfsdk:"display_name"`
-				IsProbeRegistered types.Bool   `tfsdk:"is_probe_registered"`
-				Category          types.String `tfsdk:"category"`
-				Type              types.String `tfsdk:"type"`
-				Readonly          types.Bool   `tfsdk:"read_only"`
-			} `tfsdk:"discovered_by"`
-			VendorIds map[string]string `tfsdk:"vendor_ids"`
-			State     types.String      `tfsdk:"state"`
-		} `tfsdk:"new_entity"`
-		CurrentValue    types.String `tfsdk:"current_value"`
-		NewValue        types.String `tfsdk:"new_value"`
-		ValueUnits      types.String `tfsdk:"value_units"`
-		ResizeAttribute types.String `tfsdk:"resize_attribute"`
-		Risk            struct {
-			SubCategory       types.String   `tfsdk:"sub_category"`
-			Description       types.String   `tfsdk:"description"`
-			Severity          types.String   `tfsdk:"severity"`
-			Importance        types.Float32  `tfsdk:"importance"`
-			ReasonCommodities []types.String `tfsdk:"reason_commodities"`
-		} `tfsdk:"risk"`
-	} `tfsdk:"compound_actions"`
-	Source   types.String `tfsdk:"source"`
-	ActionID types.Int64  `tfsdk:"action_id"`
+}
+
+// CompoundActionModel describes an action that is part of a compound action
+type CompoundActionModel struct {
+	DisplayName     types.String      `tfsdk:"display_name"`
+	ActionType      types.String      `tfsdk:"action_type"`
+	ActionState     types.String      `tfsdk:"action_state"`
+	ActionMode      types.String      `tfsdk:"action_mode"`
+	Details         types.String      `tfsdk:"details"`
+	Target          ActionTargetModel `tfsdk:"target"`
+	CurrentEntity   ActionEntityModel `tfsdk:"current_entity"`
+	NewEntity       ActionEntityModel `tfsdk:"new_entity"`
+	CurrentValue    types.String      `tfsdk:"current_value"`
+	NewValue        types.String      `tfsdk:"new_value"`
+	ValueUnits      types.String      `tfsdk:"value_units"`
+	ResizeAttribute types.String      `tfsdk:"resize_attribute"`
+	Risk            ActionRiskModel   `tfsdk:"risk"`
 }
 
 func NewEntityActionsDataSource() datasource.DataSource {
@@ -287,7 +230,10 @@ func NewEntityActionsDataSource() datasource.DataSource {
 }
 
 type entityActionsDataSource struct {
-	client *turboclient.Client
+	client     *turboclient.Client
+	requests   *clientRequests
+	tagConfig  TagConfig
+	serverInfo ServerInfo
 }
 
 func (d *entityActionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -299,16 +245,57 @@ func (d *entityActionsDataSource) Schema(ctx context.Context, req datasource.Sch
 		Description: "The following example demonstrates the syntax for the `turbonomic_entity_actions` data source. This can be used to access the DTO of actions",
 		Attributes: map[string]schema.Attribute{
 			"entity_uuid": schema.StringAttribute{
-				MarkdownDescription: "Turbonomic UUID of the entity",
+				MarkdownDescription: "Turbonomic UUID of the entity; only set when exactly one entity matches the search",
+				Computed:            true,
+			},
+			"entity_uuids": schema.ListAttribute{
+				MarkdownDescription: "Turbonomic UUIDs of all the entities that match the search",
+				ElementType:         types.StringType,
 				Computed:            true,
 			},
 			"entity_name": schema.StringAttribute{
-				MarkdownDescription: "case sensitive name of the entity",
-				Required:            true,
+				MarkdownDescription: "name of the entity; case sensitive unless case_insensitive is set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "regular expression (RE2 syntax) matched against the entity name, can't be combined with entity_name, " +
+					"and sent to Turbonomic to narrow the search of the virtual machines and database servers",
+				Optional: true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"case_insensitive": schema.BoolAttribute{
+				MarkdownDescription: "match entity_name and name_regex ignoring case, defaults to false",
+				Optional:            true,
+			},
+			"tag_filters": schema.MapAttribute{
+				MarkdownDescription: "only match entities that have all of the given tags, E.G: { team = \"payments\" }",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "only match entities that belong to the cloud account (business account) with the given id",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "only match entities that are located in the region with the given name, E.G: aws-US East (N. Virginia)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"all_matches": schema.BoolAttribute{
+				MarkdownDescription: "return the actions of every matching entity instead of failing when more than one entity matches, defaults to false",
+				Optional:            true,
+			},
+			"disable_entity_tagging": disableEntityTaggingAttribute,
 			"entity_type": schema.StringAttribute{
 				MarkdownDescription: "case insensitive type of the entity",
 				Required:            true,
@@ -621,35 +608,51 @@ func (d *entityActionsDataSource) Schema(ctx context.Context, req datasource.Sch
 	}
 }
 
+func (d *entityActionsDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("entity_name"), path.MatchRoot("name_regex"), path.MatchRoot("tag_filters"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("entity_name"), path.MatchRoot("name_regex"),
+		),
+	}
+}
+
 func (d *entityActionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*turboclient.Client)
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected: *turboclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected: *TurbonomicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+	d.tagConfig = providerData.TagConfig
+	d.serverInfo = providerData.ServerInfo
 }
 
 func (d *entityActionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state EntityActionsModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
-	enName, enTyp, envType := state.EntityName.ValueString(), state.EntityType.ValueString(), strings.ToUpper(state.EnvType.ValueString())
+	enTyp, envType := state.EntityType.ValueString(), strings.ToUpper(state.EnvType.ValueString())
 
 	if d.client == nil {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 		return
 	}
 
+	client := d.requests.client(ctx, d.client)
+
 	var actTypes, actStates []string
 	err := state.ActionTypes.ElementsAs(ctx, &actTypes, true)
 	if err.HasError() {
@@ -676,62 +679,164 @@ func (d *entityActionsDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	entity, errDiag := GetEntitiesByName(d.client, WithEntityName(enName), WithEntityType(entityTypes[strings.ToLower(enTyp)]), WithEnvironmentType(envType))
+	entities, errDiag := d.searchEntities(ctx, state, entityTypes[strings.ToLower(enTyp)], envType)
 	if errDiag != nil {
 		tflog.Error(ctx, errDiag.Detail())
 		resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
 		return
-	} else if len(entity) == 0 {
-		errDetail := fmt.Sprintf("entity %s of type %s not found in Turbonomic instance", enName, enTyp)
+	} else if len(entities) == 0 {
+		errDetail := fmt.Sprintf("entity with %s of type %s not found in Turbonomic instance", describeEntitySearch(state), enTyp)
 		tflog.Debug(ctx, errDetail)
 		resp.Diagnostics.AddWarning("error while getting the entity", errDetail)
 
 		state.EntityType = types.StringNull()
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 		return
+	} else if len(entities) > 1 && !state.AllMatches.ValueBool() {
+		var names []string
+		for _, entity := range entities {
+			names = append(names, fmt.Sprintf("%s (%s)", entity.DisplayName, entity.UUID))
+		}
+		errDetail := fmt.Sprintf("%d entities of type %s match the search: %s; set all_matches to true to return the actions of every entity",
+			len(entities), enTyp, strings.Join(names, ", "))
+		tflog.Error(ctx, errDetail)
+		resp.Diagnostics.AddError(fmt.Sprintf("Multiple Entities with provided %s found", strings.Join(entitySearchLookups(state), ", ")), errDetail)
+		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("entity id found: %s\n", entity[0].UUID))
-	state.EntityUuid = types.StringValue(entity[0].UUID)
+	var uuids, noActions []string
+	for _, entity := range entities {
+		tflog.Debug(ctx, fmt.Sprintf("entity id found: %s\n", entity.UUID))
+		uuids = append(uuids, entity.UUID)
+	}
 
-	actions, errDiag := GetFilteredEntityActions(d.client, entity[0].UUID,
-		convertSliceToUppercase(actTypes),
-		convertSliceToUppercase(actStates))
+	if len(entities) == 1 {
+		state.EntityUuid = types.StringValue(entities[0].UUID)
+	}
+	entityUuids, diags := types.ListValueFrom(ctx, types.StringType, uuids)
+	resp.Diagnostics.Append(diags...)
+	state.EntityUuids = entityUuids
+
+	for _, uuid := range uuids {
+		actions, errDiag := GetFilteredEntityActions(client, uuid,
+			convertSliceToUppercase(actTypes),
+			convertSliceToUppercase(actStates))
+
+		if errDiag != nil {
+			tflog.Error(ctx, errDiag.Detail())
+			resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
+			return
+		} else if len(actions) == 0 {
+			noActions = append(noActions, uuid)
+		}
 
-	if errDiag != nil {
-		tflog.Error(ctx, errDiag.Detail())
-		resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
-		return
-	} else if len(actions) == 0 {
-		errDetail := fmt.Sprintf("no matching action found for entity id: %s", entity[0].UUID)
-		tflog.Debug(ctx, errDetail)
-		resp.Diagnostics.AddWarning("error while getting the actions for the entity", errDetail)
+		tflog.Debug(ctx, fmt.Sprintf("actions found for entity %s: %d\n", uuid, len(actions)))
+
+		for i := range actions {
+			var tfAction ActionModel
+			copyActionModel(&actions[i], &tfAction)
+			state.Actions = append(state.Actions, tfAction)
+		}
 
-		if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
+		if err := TagEntity(client, uuid, d.tagConfig.WithOverride(state.DisableEntityTagging)); err != nil {
 			resp.Diagnostics.AddError("error while tagging an entity", err.Error())
 		}
+	}
 
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-		return
+	if len(state.Actions) != 0 {
+		resp.Diagnostics.Append(capabilityDiagnostics(d.serverInfo, actionExecutionCapabilities...)...)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("actions found: %d\n", len(actions)))
+	if len(noActions) != 0 {
+		errDetail := fmt.Sprintf("no matching action found for entity id: %s", strings.Join(noActions, ", "))
+		tflog.Debug(ctx, errDetail)
+		resp.Diagnostics.AddWarning("error while getting the actions for the entity", errDetail)
+	}
 
-	for _, action := range actions {
-		var tfAction ActionModel
-		if err := copyStructFields(ctx, action, &tfAction); err != nil {
-			resp.Diagnostics.AddError("error coverting actions", err.Error())
-		}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+}
 
-		state.Actions = append(state.Actions, tfAction)
+// searchEntities finds the entities matching the data source filters. A plain entity_name lookup
+// keeps using the name search, any other filter goes through SearchEntitiesByFilter
+func (d *entityActionsDataSource) searchEntities(ctx context.Context, state EntityActionsModel, entityType string, envType string) (turboclient.SearchResults, *diag.ErrorDiagnostic) {
+	client := d.requests.client(ctx, d.client)
+	caseInsensitive := state.CaseInsensitive.ValueBool()
+
+	if state.NameRegex.IsNull() && state.TagFilters.IsNull() && state.AccountId.IsNull() &&
+		state.Region.IsNull() && !state.AllMatches.ValueBool() {
+		return GetEntitiesByName(client,
+			WithEntityName(state.EntityName.ValueString()),
+			WithEntityType(entityType),
+			WithEnvironmentType(envType),
+			WithCaseSensitive(!caseInsensitive))
 	}
 
-	if err := TagEntity(d.client, state.EntityUuid.ValueString()); err != nil {
-		resp.Diagnostics.AddError("error while tagging an entity", err.Error())
+	namePattern, err := EntityNamePattern(state.EntityName.ValueString(), state.NameRegex.ValueString(), caseInsensitive)
+	if err != nil {
+		errDiag := diag.NewErrorDiagnostic("Invalid name_regex", err.Error())
+		return nil, &errDiag
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tags := map[string]string{}
+	if diags := state.TagFilters.ElementsAs(ctx, &tags, true); diags.HasError() {
+		errDiag := diag.NewErrorDiagnostic("Invalid tag_filters", diags.Errors()[0].Detail())
+		return nil, &errDiag
+	}
+
+	scopes, errDiag := ResolveEntityScopes(client, state.AccountId.ValueString(), state.Region.ValueString())
+	if errDiag != nil {
+		return nil, errDiag
+	}
+
+	return SearchEntitiesByFilter(client,
+		WithFilterEntityType(entityType),
+		WithFilterEnvironmentType(envType),
+		WithScopes(scopes...),
+		WithNamePattern(namePattern),
+		WithTagFilters(tags))
+}
+
+// entitySearchLookups returns the arguments that the entities were searched by
+func entitySearchLookups(state EntityActionsModel) []string {
+	var lookups []string
+	if !state.EntityName.IsNull() {
+		lookups = append(lookups, "entity_name")
+	}
+	if !state.NameRegex.IsNull() {
+		lookups = append(lookups, "name_regex")
+	}
+	if !state.TagFilters.IsNull() {
+		lookups = append(lookups, "tag_filters")
+	}
+	if !state.AccountId.IsNull() {
+		lookups = append(lookups, "account_id")
+	}
+	if !state.Region.IsNull() {
+		lookups = append(lookups, "region")
+	}
+	if len(lookups) == 0 {
+		lookups = append(lookups, "entity_type")
+	}
+	return lookups
+}
+
+// describeEntitySearch returns the arguments and the values that the entities were searched by, E.G: name_regex ^api-[0-9]+$
+func describeEntitySearch(state EntityActionsModel) string {
+	values := map[string]string{
+		"entity_name": state.EntityName.ValueString(),
+		"name_regex":  state.NameRegex.ValueString(),
+		"tag_filters": state.TagFilters.String(),
+		"account_id":  state.AccountId.ValueString(),
+		"region":      state.Region.ValueString(),
+		"entity_type": state.EntityType.ValueString(),
+	}
 
+	var described []string
+	for _, lookup := range entitySearchLookups(state) {
+		described = append(described, lookup+" "+values[lookup])
+	}
+	return strings.Join(described, ", ")
 }
 
 func insertEntitySchema(includeState bool, includeAspects bool, includeTags bool) map[string]schema.Attribute {
@@ -814,158 +919,3 @@ func insertEntitySchema(includeState bool, includeAspects bool, includeTags bool
 
 	return attribs
 }
-
-// Copy Struct copies the elements from one struct to another
-func copyStructFields(ctx context.Context, src interface{}, dst interface{}) error {
-	srcVal := reflect.ValueOf(src)
-	srcTyp := reflect.TypeOf(src)
-
-	dstVal := reflect.ValueOf(dst)
-	dstTyp := reflect.TypeOf(dst)
-
-	if srcTyp.Kind() == reflect.Pointer {
-		srcVal = srcVal.Elem()
-	}
-
-	if dstTyp.Kind() == reflect.Pointer {
-		dstTyp = dstTyp.Elem()
-		dstVal = dstVal.Elem()
-	}
-
-	if srcVal.Kind() != reflect.Struct || dstVal.Kind() != reflect.Struct {
-		return fmt.Errorf("both source and destination must be structs")
-	}
-
-	for i := 0; i < dstTyp.NumField(); i++ {
-		dstField := dstTyp.Field(i)
-		dstValue := dstVal.Field(i)
-
-		msg := fmt.Sprintf("setting field: %s of Type: %s with PkgPath of: %s\n", dstField.Name, dstField.Type, dstField.PkgPath)
-		tflog.Trace(ctx, msg)
-
-		srcFieldValue := srcVal.FieldByName(dstField.Name)
-		if !srcFieldValue.IsValid() {
-			msg := fmt.Sprintf("field Name: %s, is invalid: field value: %s\n", dstField.Name, srcFieldValue)
-			tflog.Debug(ctx, msg)
-			continue
-		}
-		if !dstValue.CanSet() {
-			msg := fmt.Sprintf("field Name: %s, cannot be set: field value: %s\n", dstField.Name, srcFieldValue)
-			tflog.Error(ctx, msg)
-			continue
-		}
-		if err := copyField(ctx, srcFieldValue, dstValue); err != nil {
-			msg := fmt.Sprintf("error converting field: %s of type: %s: %v", dstField.Name, dstField.Type, err)
-			tflog.Error(ctx, msg)
-		}
-
-	}
-	return nil
-}
-
-// copyField copies a single field based on the source type
-func copyField(ctx context.Context, srcValue, dstValue reflect.Value) error {
-
-	msg := fmt.Sprintf("Field Kind: %s", srcValue.Kind())
-	tflog.Trace(ctx, msg)
-	switch srcValue.Kind() {
-	case reflect.String:
-		dstValue.Set(reflect.ValueOf(types.StringValue(srcValue.String())))
-	case reflect.Int64, reflect.Int:
-		dstValue.Set(reflect.ValueOf(types.Int64Value(srcValue.Int())))
-	case reflect.Float64:
-		dstValue.Set(reflect.ValueOf(types.Float64Value(srcValue.Float())))
-	case reflect.Float32:
-		dstValue.Set(reflect.ValueOf(types.Float32Value(float32(srcValue.Float()))))
-	case reflect.Bool:
-		dstValue.Set(reflect.ValueOf(types.BoolValue(srcValue.Bool())))
-	case reflect.Struct:
-		dstPtr := dstValue.Addr()
-		if err := copyStructFields(ctx, srcValue.Interface(), dstPtr.Interface()); err != nil {
-			return err
-		}
-	case reflect.Slice:
-		srcType := srcValue.Type()
-
-		// json.RawMessage is a slice under the hook so need to handle it separately
-		if srcType == reflect.TypeOf(json.RawMessage{}) {
-			dstValue.Set(reflect.ValueOf(getRawJson(srcValue)))
-			return nil
-		}
-		msg := fmt.Sprintf("Slice Field Kind: %s", srcType.Elem().Kind())
-		tflog.Trace(ctx, msg)
-
-		switch srcType.Elem().Kind() {
-		case reflect.Struct:
-			newSlice, err := getNewSliceStruct(ctx, srcValue, dstValue.Type())
-			if err != nil {
-				return err
-			}
-			dstValue.Set(newSlice)
-
-		case reflect.String:
-			newSlice, err := getNewSliceString(srcValue, dstValue.Type())
-			if err != nil {
-				return err
-			}
-			dstValue.Set(newSlice)
-		}
-
-	case reflect.Map:
-		mapValue := srcValue.Type().Elem()
-
-		switch mapValue.Kind() {
-		case reflect.String, reflect.Slice:
-			dstValue.Set(reflect.ValueOf(srcValue.Interface()))
-		default:
-			msg := fmt.Sprintf("unsupported map field type: %s", srcValue.Kind())
-			tflog.Warn(ctx, msg)
-			return nil
-		}
-
-	default:
-		msg := fmt.Sprintf("unsupported field type: %s", srcValue.Kind())
-		tflog.Warn(ctx, msg)
-		return nil
-	}
-	return nil
-}
-
-// Returns jsontypes.Normalized from a reflect.Value
-func getRawJson(val reflect.Value) jsontypes.Normalized {
-	raw := val.Interface().(json.RawMessage)
-	if raw != nil {
-		return jsontypes.NewNormalizedValue(string(raw))
-	}
-	return jsontypes.NewNormalizedNull()
-}
-
-// Returns a reflect.Value containing a copy of the provided slice of structs,
-// with each element formatted according to Terraform's type conventions.
-func getNewSliceStruct(ctx context.Context, src reflect.Value, dst reflect.Type) (reflect.Value, error) {
-	newSlice := reflect.MakeSlice(dst, src.Len(), src.Cap())
-
-	for i := 0; i < src.Len(); i++ {
-		elmSrcValue := src.Index(i)
-		elmDesttype := dst.Elem()
-		dstPtr := reflect.New(elmDesttype)
-		if err := copyStructFields(ctx, elmSrcValue.Interface(), dstPtr.Interface()); err != nil {
-			return reflect.Value{}, err
-		}
-		dstValue := dstPtr.Elem()
-		newSlice.Index(i).Set(dstValue)
-	}
-	return newSlice, nil
-}
-
-// Returns a reflect.Value containing a copy of the provided slice of strings,
-// with each string formatted using Terraform's types.String convention.
-func getNewSliceString(src reflect.Value, dst reflect.Type) (reflect.Value, error) {
-	newSlice := reflect.MakeSlice(dst, src.Len(), src.Cap())
-
-	for i := 0; i < src.Len(); i++ {
-		elmSrcValue := src.Index(i)
-		newSlice.Index(i).Set(reflect.ValueOf(types.StringValue(elmSrcValue.String())))
-	}
-	return newSlice, nil
-}
@@ -10,7 +10,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 const (
@@ -72,6 +75,10 @@ const (
 	reconfigureActionReasonCommodities0   = "VMem"
 	reconfigureActionReasonCommodities1   = "VCPU"
 	reconfigureActionDiscoveredByType     = "Dynatrace"
+
+	entityActionTaggedSearchResponse = "entity_action_search_VM_tagged.json"
+	taggedEntity0Uuid                = "75930461864801"
+	taggedEntity1Uuid                = "75930461864802"
 )
 
 // Test entity action data source where the entity does not exist
@@ -765,3 +772,124 @@ func TestEntityActionsDataSourceTagEntityAlreadyTaggedNotDiscovered(t *testing.T
 		})
 	}
 }
+
+// Test entity action data source matching several entities by tag and name regex
+func TestEntityActionDataSourceAllMatches(t *testing.T) {
+
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			ResponseBody: loadTestFile(t, entityActionDir, entityActionTaggedSearchResponse),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/entities/{id}/actions",
+			ResponseBody: loadTestFile(t, entityActionDir, entityActionMultiActionResponse),
+			ResponseCode: http.StatusOK,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	providerConfig := fmt.Sprintf(config, strings.TrimPrefix(mockServer.URL, "https://"))
+
+	for _, tc := range []struct {
+		name        string
+		filters     string
+		expectError *regexp.Regexp
+	}{
+		{
+			name: "Tag filters with all matches",
+			filters: `tag_filters = { team = "payments" }
+								all_matches = true`,
+		},
+		{
+			name: "Name regex with all matches",
+			filters: `name_regex  = "^PAYMENTS-API-[0-9]+$"
+								case_insensitive = true
+								all_matches = true`,
+		},
+		{
+			name:        "Multiple matches without all matches",
+			filters:     `tag_filters = { team = "payments" }`,
+			expectError: regexp.MustCompile("Multiple Entities with provided tag_filters found"),
+		},
+		{
+			name:        "Invalid name regex",
+			filters:     `name_regex = "payments-("`,
+			expectError: regexp.MustCompile("Invalid name_regex"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			step := resource.TestStep{
+				Config: providerConfig +
+					`data "turbonomic_entity_actions" "test" {
+								entity_type = "` + multiActionEntityType + `"
+								` + tc.filters + `
+						    }`,
+				ExpectError: tc.expectError,
+			}
+
+			if tc.expectError == nil {
+				step.Check = resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.turbonomic_entity_actions.test", "entity_uuid"),
+					resource.TestCheckResourceAttr("data.turbonomic_entity_actions.test", "entity_uuids.#", "2"),
+					resource.TestCheckResourceAttr("data.turbonomic_entity_actions.test", "entity_uuids.0", taggedEntity0Uuid),
+					resource.TestCheckResourceAttr("data.turbonomic_entity_actions.test", "entity_uuids.1", taggedEntity1Uuid),
+					resource.TestCheckResourceAttr("data.turbonomic_entity_actions.test", "actions.#", "4"),
+					resource.TestCheckResourceAttr("data.turbonomic_entity_actions.test", "actions.0.uuid", multiAction0Uuid),
+					resource.TestCheckResourceAttr("data.turbonomic_entity_actions.test", "actions.1.uuid", multiAction1Uuid),
+				)
+			}
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+// Tests that the errors of the entity search name the arguments of the lookup
+func TestDescribeEntitySearch(t *testing.T) {
+	tests := []struct {
+		name            string
+		state           EntityActionsModel
+		expectedLookups []string
+		expectedSearch  string
+	}{
+		{
+			name: "Tag filters",
+			state: EntityActionsModel{
+				TagFilters: types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("payments")}),
+			},
+			expectedLookups: []string{"tag_filters"},
+			expectedSearch:  `tag_filters {"team":"payments"}`,
+		},
+		{
+			name: "Name regex in a region",
+			state: EntityActionsModel{
+				NameRegex: types.StringValue("^payments-api-[0-9]+$"),
+				Region:    types.StringValue("us-east-1"),
+			},
+			expectedLookups: []string{"name_regex", "region"},
+			expectedSearch:  "name_regex ^payments-api-[0-9]+$, region us-east-1",
+		},
+		{
+			name: "Entity type only",
+			state: EntityActionsModel{
+				EntityType: types.StringValue("VirtualMachine"),
+			},
+			expectedLookups: []string{"entity_type"},
+			expectedSearch:  "entity_type VirtualMachine",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedLookups, entitySearchLookups(tc.state))
+			assert.Equal(t, tc.expectedSearch, describeEntitySearch(tc.state))
+		})
+	}
+}
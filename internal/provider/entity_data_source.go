@@ -0,0 +1,279 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	turboclient "github.com/IBM/turbonomic-go-client"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource                     = &entityDataSource{}
+	_ datasource.DataSourceWithConfigure        = &entityDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &entityDataSource{}
+)
+
+func NewEntityDataSource() datasource.DataSource {
+	return &entityDataSource{}
+}
+
+// entityDataSource defines the data source implementation.
+type entityDataSource struct {
+	client   *turboclient.Client
+	requests *clientRequests
+}
+
+// EntityModel describes the data source data model. The computed fields are named after
+// the fields of turboclient.EntityResults so that they can be filled by the generated copyEntityModel.
+type EntityModel struct {
+	UUID            types.String          `tfsdk:"entity_uuid"`
+	EntityName      types.String          `tfsdk:"entity_name"`
+	EntityType      types.String          `tfsdk:"entity_type"`
+	VendorId        types.String          `tfsdk:"vendor_id"`
+	EnvType         types.String          `tfsdk:"environment_type"`
+	DisplayName     types.String          `tfsdk:"display_name"`
+	ClassName       types.String          `tfsdk:"class_name"`
+	EnvironmentType types.String          `tfsdk:"entity_environment_type"`
+	DiscoveredBy    DiscoveredByModel     `tfsdk:"discovered_by"`
+	VendorIds       map[string]string     `tfsdk:"vendor_ids"`
+	State           types.String          `tfsdk:"state"`
+	Aspects         jsontypes.Normalized  `tfsdk:"aspects"`
+	Tags            map[string][]string   `tfsdk:"tags"`
+	Providers       []EntityRelationModel `tfsdk:"providers"`
+	Consumers       []EntityRelationModel `tfsdk:"consumers"`
+}
+
+// EntityRelationModel describes a provider or consumer of the entity in the supply chain.
+type EntityRelationModel struct {
+	UUID        types.String `tfsdk:"uuid"`
+	DisplayName types.String `tfsdk:"display_name"`
+	ClassName   types.String `tfsdk:"class_name"`
+}
+
+func (d *entityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity"
+}
+
+func (d *entityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	relationAttributes := map[string]schema.Attribute{
+		"uuid": schema.StringAttribute{
+			MarkdownDescription: "Turbonomic UUID of the related entity",
+			Computed:            true,
+		},
+		"display_name": schema.StringAttribute{
+			MarkdownDescription: "a user readable name of the related entity",
+			Computed:            true,
+		},
+		"class_name": schema.StringAttribute{
+			MarkdownDescription: "type of the related entity",
+			Computed:            true,
+		},
+	}
+
+	attribs := insertEntitySchema(true, true, true)
+	delete(attribs, "uuid")
+	delete(attribs, "environment_type")
+
+	attribs["entity_uuid"] = schema.StringAttribute{
+		MarkdownDescription: "Turbonomic UUID of the entity",
+		Optional:            true,
+		Computed:            true,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+	attribs["entity_name"] = schema.StringAttribute{
+		MarkdownDescription: "case sensitive name of the entity, requires entity_type",
+		Optional:            true,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+	attribs["vendor_id"] = schema.StringAttribute{
+		MarkdownDescription: "vendor-provided identity of the entity on the remote target, E.G: the AWS instance id",
+		Optional:            true,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+	attribs["entity_type"] = schema.StringAttribute{
+		MarkdownDescription: "case insensitive type of the entity",
+		Optional:            true,
+		Validators: []validator.String{
+			stringvalidator.OneOfCaseInsensitive(slices.Collect(maps.Values(entityTypes))...),
+		},
+	}
+	attribs["environment_type"] = schema.StringAttribute{
+		MarkdownDescription: "filter the entity search by environment type",
+		Optional:            true,
+		Validators: []validator.String{
+			stringvalidator.OneOfCaseInsensitive(environmentTypes...),
+		},
+	}
+	attribs["entity_environment_type"] = schema.StringAttribute{
+		MarkdownDescription: "environment type of the entity",
+		Computed:            true,
+	}
+	attribs["providers"] = schema.ListNestedAttribute{
+		MarkdownDescription: "entities that provide resources to the entity in the supply chain, such as the volumes attached to a VM",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: relationAttributes,
+		},
+	}
+	attribs["consumers"] = schema.ListNestedAttribute{
+		MarkdownDescription: "entities that consume resources from the entity in the supply chain",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: relationAttributes,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "The following example demonstrates the syntax for the `turbonomic_entity` data source. This can be used to look up a single entity with its aspects, tags and supply chain relationships",
+		Attributes:  attribs,
+	}
+}
+
+func (d *entityDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("entity_uuid"), path.MatchRoot("entity_name"), path.MatchRoot("vendor_id"),
+		),
+		datasourcevalidator.RequiredTogether(
+			path.MatchRoot("entity_name"), path.MatchRoot("entity_type"),
+		),
+	}
+}
+
+func (d *entityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected: *TurbonomicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+}
+
+func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state EntityModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	client := d.requests.client(ctx, d.client)
+
+	uuid, errDiag := resolveEntityUuid(client, entityLookup{
+		UUID:       state.UUID,
+		EntityName: state.EntityName,
+		EntityType: state.EntityType,
+		VendorId:   state.VendorId,
+		EnvType:    state.EnvType,
+	})
+	if errDiag != nil {
+		tflog.Error(ctx, errDiag.Detail())
+		resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
+		return
+	} else if len(uuid) == 0 {
+		searchName := state.EntityName.ValueString()
+		if !state.VendorId.IsNull() {
+			searchName = state.VendorId.ValueString()
+		}
+		errDetail := fmt.Sprintf("entity %s of type %s not found in Turbonomic instance", searchName, state.EntityType.ValueString())
+		tflog.Error(ctx, errDetail)
+		resp.Diagnostics.AddError("Entity not found", errDetail)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("entity id found: %s\n", uuid))
+
+	entity, tags, errDiag := GetEntityDetails(client, uuid)
+	if errDiag != nil {
+		tflog.Error(ctx, errDiag.Detail())
+		resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
+		return
+	}
+
+	copyEntityModel(entity, &state)
+	state.Tags = tags
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// entityLookup identifies an entity by uuid, by vendor id or by name and type
+type entityLookup struct {
+	UUID       types.String
+	EntityName types.String
+	EntityType types.String
+	VendorId   types.String
+	EnvType    types.String
+}
+
+// resolveEntityUuid returns the uuid of the entity that is looked up by uuid, name or vendor id,
+// an empty uuid is returned when no entity matches
+func resolveEntityUuid(client turboclient.T8cClient, lookup entityLookup) (string, *diag.ErrorDiagnostic) {
+	if !lookup.UUID.IsNull() {
+		return lookup.UUID.ValueString(), nil
+	}
+
+	var entities turboclient.SearchResults
+	var errDiag *diag.ErrorDiagnostic
+	entityType := entityTypes[strings.ToLower(lookup.EntityType.ValueString())]
+
+	if !lookup.VendorId.IsNull() {
+		entities, errDiag = GetEntitiesByVendorId(client,
+			WithVendorId(lookup.VendorId.ValueString()),
+			WithEntityTypeForVendorId(entityType))
+		if errDiag == nil && len(entities) > 1 {
+			diagErr := diag.NewErrorDiagnostic("Multiple Entities with provided vendor id found",
+				fmt.Sprintf("Multiple Entities with the vendor id %s found in Turbonomic instance, please include entity_type in the search.",
+					lookup.VendorId.ValueString()))
+			errDiag = &diagErr
+		}
+	} else {
+		entities, errDiag = GetEntitiesByName(client,
+			WithEntityName(lookup.EntityName.ValueString()),
+			WithEntityType(entityType),
+			WithEnvironmentType(strings.ToUpper(lookup.EnvType.ValueString())),
+			ShowVendorIdString(true))
+	}
+
+	if errDiag != nil || len(entities) == 0 {
+		return "", errDiag
+	}
+
+	return entities[0].UUID, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	entitySearchResponse = "entity_search_VM_success.json"
+	entityGetResponse    = "entity_get_VM_success.json"
+	entityDataSourceName = "data.turbonomic_entity.test"
+	entityUuid           = "75930461864801"
+)
+
+// Test entity data source looking up the entity by uuid, name and vendor id
+func TestEntityDataSource(t *testing.T) {
+
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			ResponseBody: loadTestFile(t, entityTestDataBaseDir, entitySearchResponse),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodGet,
+			Path:         "/api/v3/entities/{id}",
+			ResponseBody: loadTestFile(t, entityTestDataBaseDir, entityGetResponse),
+			ResponseCode: http.StatusOK,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	providerConfig := fmt.Sprintf(config, strings.TrimPrefix(mockServer.URL, "https://"))
+
+	for _, tc := range []struct {
+		name   string
+		lookup string
+	}{
+		{
+			name:   "By uuid",
+			lookup: `entity_uuid = "` + entityUuid + `"`,
+		},
+		{
+			name: "By name",
+			lookup: `entity_name = "payments-api-1"
+								entity_type = "VirtualMachine"`,
+		},
+		{
+			name:   "By vendor id",
+			lookup: `vendor_id = "i-0a1b2c3d4e5f60001"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig +
+							`data "turbonomic_entity" "test" {
+								` + tc.lookup + `
+						    }`,
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr(entityDataSourceName, "entity_uuid", entityUuid),
+							resource.TestCheckResourceAttr(entityDataSourceName, "display_name", "payments-api-1"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "class_name", "VirtualMachine"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "entity_environment_type", "CLOUD"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "discovered_by.type", "AWS"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "vendor_ids.aws-123456789012", "i-0a1b2c3d4e5f60001"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "tags.Turbo_Owner.0", "Turbonomic_Appinfra_Integrations"),
+							resource.TestCheckResourceAttrSet(entityDataSourceName, "aspects"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "providers.#", "2"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "providers.0.class_name", "VirtualVolume"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "providers.1.display_name", "aws-US East (N. Virginia)"),
+							resource.TestCheckResourceAttr(entityDataSourceName, "consumers.0.uuid", "75930461865000"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+// Test entity data source where the entity does not exist
+func TestEntityDataSourceNotFound(t *testing.T) {
+
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			ResponseBody: loadTestFile(t, entityActionSearchResponseEmpty),
+			ResponseCode: http.StatusOK,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	providerConfig := fmt.Sprintf(config, strings.TrimPrefix(mockServer.URL, "https://"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig +
+					`data "turbonomic_entity" "test" {
+						entity_name = "` + nonExistingEntity + `"
+						entity_type = "VirtualMachine"
+				    }`,
+				ExpectError: regexp.MustCompile("Entity not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,310 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+const (
+	// Aggregation constants
+	AggregationAvg = "avg"
+	AggregationMax = "max"
+	AggregationP95 = "p95"
+
+	defaultStatsStartDate = "-1d"
+)
+
+var (
+	_ datasource.DataSource              = &entityStatsDataSource{}
+	_ datasource.DataSourceWithConfigure = &entityStatsDataSource{}
+
+	aggregations = []string{AggregationAvg, AggregationMax, AggregationP95}
+)
+
+func NewEntityStatsDataSource() datasource.DataSource {
+	return &entityStatsDataSource{}
+}
+
+// entityStatsDataSource defines the data source implementation.
+type entityStatsDataSource struct {
+	client   *turboclient.Client
+	requests *clientRequests
+}
+
+// EntityStatsModel describes the data source data model.
+type EntityStatsModel struct {
+	EntityUuid  types.String      `tfsdk:"entity_uuid"`
+	Commodities types.List        `tfsdk:"commodities"`
+	StartDate   types.String      `tfsdk:"start_date"`
+	EndDate     types.String      `tfsdk:"end_date"`
+	Aggregation types.String      `tfsdk:"aggregation"`
+	Stats       []EntityStatModel `tfsdk:"stats"`
+}
+
+// EntityStatModel describes the time series and summary of a single commodity.
+type EntityStatModel struct {
+	Name        types.String     `tfsdk:"name"`
+	Units       types.String     `tfsdk:"units"`
+	Value       types.Float64    `tfsdk:"value"`
+	Capacity    types.Float64    `tfsdk:"capacity"`
+	Utilization types.Float64    `tfsdk:"utilization"`
+	Points      []StatPointModel `tfsdk:"points"`
+}
+
+// StatPointModel describes a single sample of a commodity time series.
+type StatPointModel struct {
+	Date     types.String  `tfsdk:"date"`
+	Value    types.Float64 `tfsdk:"value"`
+	Capacity types.Float64 `tfsdk:"capacity"`
+}
+
+func (d *entityStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_stats"
+}
+
+func (d *entityStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The following example demonstrates the syntax for the `turbonomic_entity_stats` data source. This can be used to read the historical utilization of the commodities of an entity",
+		Attributes: map[string]schema.Attribute{
+			"entity_uuid": schema.StringAttribute{
+				MarkdownDescription: "Turbonomic UUID of the entity",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"commodities": schema.ListAttribute{
+				MarkdownDescription: "names of the commodities sold by the entity, E.G: VCPU, VMem, StorageAccess",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				MarkdownDescription: "start of the time window, either a date or a time relative to now, E.G: -7d; defaults to " + defaultStatsStartDate,
+				Optional:            true,
+			},
+			"end_date": schema.StringAttribute{
+				MarkdownDescription: "end of the time window, either a date or a time relative to now; defaults to now",
+				Optional:            true,
+			},
+			"aggregation": schema.StringAttribute{
+				MarkdownDescription: "aggregation of the snapshots of the time window into the summary value, one of avg, max or p95; defaults to avg. " +
+					"p95 is the 95th percentile, by nearest rank, of the average values of the snapshots that Turbonomic returns, not of the raw samples",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(aggregations...),
+				},
+			},
+			"stats": schema.ListNestedAttribute{
+				MarkdownDescription: "time series and summary of each commodity",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "name of the commodity",
+							Computed:            true,
+						},
+						"units": schema.StringAttribute{
+							MarkdownDescription: "units of the values",
+							Computed:            true,
+						},
+						"value": schema.Float64Attribute{
+							MarkdownDescription: "aggregated value over the time window",
+							Computed:            true,
+						},
+						"capacity": schema.Float64Attribute{
+							MarkdownDescription: "latest capacity of the commodity",
+							Computed:            true,
+						},
+						"utilization": schema.Float64Attribute{
+							MarkdownDescription: "aggregated value as a percentage of the capacity",
+							Computed:            true,
+						},
+						"points": schema.ListNestedAttribute{
+							MarkdownDescription: "samples of the commodity over the time window",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"date": schema.StringAttribute{
+										MarkdownDescription: "date of the sample",
+										Computed:            true,
+									},
+									"value": schema.Float64Attribute{
+										MarkdownDescription: "value of the sample, the peak value when aggregation is max and the average otherwise",
+										Computed:            true,
+									},
+									"capacity": schema.Float64Attribute{
+										MarkdownDescription: "capacity of the commodity at the sample date",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *entityStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected: *TurbonomicProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.requests = providerData.Requests
+}
+
+func (d *entityStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state EntityStatsModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	client := d.requests.client(ctx, d.client)
+
+	var commodities []string
+	resp.Diagnostics.Append(state.Commodities.ElementsAs(ctx, &commodities, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stats, errDiag := GetStats(client,
+		WithEntityUUID(state.EntityUuid.ValueString()),
+		WithStatistics(CreateStatisticRequests(commodities, "", RelationFilterType, FilterSold)),
+		WithStartDate(applyDefaultIfEmptyGeneric(state.StartDate, types.StringValue(defaultStatsStartDate)).ValueString()),
+		WithEndDate(state.EndDate.ValueString()))
+	if errDiag != nil {
+		tflog.Error(ctx, errDiag.Detail())
+		resp.Diagnostics.AddError(errDiag.Summary(), errDiag.Detail())
+		return
+	}
+
+	aggregation := applyDefaultIfEmptyGeneric(state.Aggregation, types.StringValue(AggregationAvg)).ValueString()
+	state.Stats = []EntityStatModel{}
+	for _, commodity := range commodities {
+		stat, found := summarizeStatistic(stats, commodity, aggregation)
+		if !found {
+			errDetail := fmt.Sprintf("no samples of commodity %s found for entity id: %s", commodity, state.EntityUuid.ValueString())
+			tflog.Debug(ctx, errDetail)
+			resp.Diagnostics.AddWarning("error while getting the stats for the entity", errDetail)
+			continue
+		}
+		state.Stats = append(state.Stats, stat)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// summarizeStatistic builds the time series of the named commodity from the historical and current
+// samples and aggregates it into a single value. Projected samples are ignored.
+func summarizeStatistic(stats turboclient.StatsResponse, name string, aggregation string) (EntityStatModel, bool) {
+	stat := EntityStatModel{
+		Name:        types.StringValue(name),
+		Units:       types.StringNull(),
+		Value:       types.Float64Null(),
+		Capacity:    types.Float64Null(),
+		Utilization: types.Float64Null(),
+		Points:      []StatPointModel{},
+	}
+
+	var values []float64
+	var capacity float64
+	for _, snapshot := range stats {
+		if strings.EqualFold(snapshot.Epoch, EpochProjected) {
+			continue
+		}
+
+		for _, statistic := range snapshot.Statistics {
+			if !strings.EqualFold(statistic.Name, name) {
+				continue
+			}
+
+			value := statistic.Values.Avg
+			if aggregation == AggregationMax {
+				value = statistic.Values.Max
+			}
+
+			values = append(values, value)
+			capacity = statistic.Capacity.Avg
+			if len(statistic.Units) != 0 {
+				stat.Units = types.StringValue(statistic.Units)
+			}
+			stat.Points = append(stat.Points, StatPointModel{
+				Date:     types.StringValue(snapshot.Date),
+				Value:    types.Float64Value(value),
+				Capacity: types.Float64Value(statistic.Capacity.Avg),
+			})
+			break
+		}
+	}
+
+	if len(values) == 0 {
+		return stat, false
+	}
+
+	value := aggregateValues(values, aggregation)
+	stat.Value = types.Float64Value(value)
+	stat.Capacity = types.Float64Value(capacity)
+	if capacity > 0 {
+		stat.Utilization = types.Float64Value(value / capacity * 100)
+	}
+
+	return stat, true
+}
+
+// aggregateValues reduces the values of the snapshots to their average, maximum or 95th percentile,
+// the percentile uses the nearest-rank method
+func aggregateValues(values []float64, aggregation string) float64 {
+	switch aggregation {
+	case AggregationMax:
+		return slices.Max(values)
+	case AggregationP95:
+		sorted := slices.Clone(values)
+		slices.Sort(sorted)
+		rank := int(math.Ceil(0.95 * float64(len(sorted))))
+		return sorted[max(rank, 1)-1]
+	default:
+		var total float64
+		for _, value := range values {
+			total += value
+		}
+		return total / float64(len(values))
+	}
+}
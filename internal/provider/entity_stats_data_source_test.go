@@ -0,0 +1,146 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+const (
+	entityStatsResponse       = "entity_stats_VM_history.json"
+	entityStatsDataSourceName = "data.turbonomic_entity_stats.test"
+)
+
+// Test entity stats data source aggregating the historical samples of the commodities
+func TestEntityStatsDataSource(t *testing.T) {
+
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/stats/{id}",
+			ResponseBody: loadTestFile(t, entityStatsTestDataBaseDir, entityStatsResponse),
+			ResponseCode: http.StatusOK,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	providerConfig := fmt.Sprintf(config, strings.TrimPrefix(mockServer.URL, "https://"))
+
+	for _, tc := range []struct {
+		name            string
+		aggregation     string
+		vcpuValue       string
+		vcpuUtilization string
+		vmemValue       string
+		vcpuPoint1Value string
+	}{
+		{
+			name:            "Default aggregation",
+			vcpuValue:       "20",
+			vcpuUtilization: "20",
+			vmemValue:       "1500",
+			vcpuPoint1Value: "30",
+		},
+		{
+			name:            "Max aggregation",
+			aggregation:     `aggregation = "max"`,
+			vcpuValue:       "60",
+			vcpuUtilization: "60",
+			vmemValue:       "4096",
+			vcpuPoint1Value: "60",
+		},
+		{
+			name:            "P95 aggregation",
+			aggregation:     `aggregation = "p95"`,
+			vcpuValue:       "30",
+			vcpuUtilization: "30",
+			vmemValue:       "2000",
+			vcpuPoint1Value: "30",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig +
+							`data "turbonomic_entity_stats" "test" {
+								entity_uuid = "75930461864801"
+								commodities = ["VCPU", "VMem"]
+								start_date  = "-3d"
+								` + tc.aggregation + `
+						    }`,
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.#", "2"),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.0.name", "VCPU"),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.0.units", "MHz"),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.0.value", tc.vcpuValue),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.0.capacity", "100"),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.0.utilization", tc.vcpuUtilization),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.0.points.#", "3"),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.0.points.1.date", "2026-10-13T00:00:00Z"),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.0.points.1.value", tc.vcpuPoint1Value),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.1.name", "VMem"),
+							resource.TestCheckResourceAttr(entityStatsDataSourceName, "stats.1.value", tc.vmemValue),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestSummarizeStatistic(t *testing.T) {
+	var stats turboclient.StatsResponse
+	err := json.Unmarshal([]byte(loadTestFile(t, entityStatsTestDataBaseDir, entityStatsResponse)), &stats)
+	assert.NoError(t, err)
+
+	stat, found := summarizeStatistic(stats, "vmem", AggregationAvg)
+	assert.True(t, found)
+	assert.Equal(t, "vmem", stat.Name.ValueString())
+	assert.Equal(t, "KB", stat.Units.ValueString())
+	assert.Equal(t, 1500.0, stat.Value.ValueFloat64())
+	assert.Equal(t, 8192.0, stat.Capacity.ValueFloat64())
+	assert.Len(t, stat.Points, 3)
+
+	_, found = summarizeStatistic(stats, "StorageAmount", AggregationAvg)
+	assert.False(t, found)
+
+	_, found = summarizeStatistic(nil, "VCPU", AggregationAvg)
+	assert.False(t, found)
+}
+
+func TestAggregateValues(t *testing.T) {
+	values := make([]float64, 0, 20)
+	for i := 20; i > 0; i-- {
+		values = append(values, float64(i))
+	}
+
+	for _, tc := range []struct {
+		name        string
+		values      []float64
+		aggregation string
+		expected    float64
+	}{
+		{name: "Average", values: values, aggregation: AggregationAvg, expected: 10.5},
+		{name: "Max", values: values, aggregation: AggregationMax, expected: 20},
+		{name: "P95", values: values, aggregation: AggregationP95, expected: 19},
+		{name: "P95 single value", values: []float64{7}, aggregation: AggregationP95, expected: 7},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, aggregateValues(tc.values, tc.aggregation))
+		})
+	}
+
+	// the input is not reordered by the percentile
+	assert.Equal(t, 20.0, values[0])
+}
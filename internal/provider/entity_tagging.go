@@ -0,0 +1,262 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+const (
+	// Environment variables for the entity tagging configuration
+	TagKeyEnvVar               = "TURBO_TAG_KEY"
+	TagValueEnvVar             = "TURBO_TAG_VALUE"
+	ExtraTagsEnvVar            = "TURBO_EXTRA_TAGS"
+	DisableEntityTaggingEnvVar = "TURBO_DISABLE_ENTITY_TAGGING"
+	ProvenanceTagsEnvVar       = "TURBO_PROVENANCE_TAGS"
+	WorkspaceLabelEnvVar       = "TURBO_WORKSPACE_LABEL"
+
+	// Keys of the provenance tags
+	ActionIdTagName  = "turbonomic_action_id"
+	AppliedAtTagName = "turbonomic_applied_at"
+	WorkspaceTagName = "turbonomic_workspace"
+)
+
+// TagConfig describes the tags written on the Turbonomic entities read by the data sources
+// and returned by the get_tags function
+type TagConfig struct {
+	Key       string
+	Value     string
+	ExtraTags map[string]string
+	Disabled  bool
+
+	// Provenance enables the action id and applied at tags, Workspace is the caller
+	// supplied workspace or run label
+	Provenance bool
+	Workspace  string
+	ActionId   string
+	AppliedAt  string
+}
+
+/*
+TagConfigFromEnv returns the tagging configuration set by the TURBO_TAG_KEY, TURBO_TAG_VALUE,
+TURBO_EXTRA_TAGS, TURBO_DISABLE_ENTITY_TAGGING, TURBO_PROVENANCE_TAGS and TURBO_WORKSPACE_LABEL
+environment variables, falling back to the turbonomic_optimized_by tag.
+
+TURBO_EXTRA_TAGS is a comma separated list of key=value pairs.
+*/
+func TagConfigFromEnv() (TagConfig, error) {
+	tagConfig := TagConfig{
+		Key:       OptimizedByTagName,
+		Value:     OptimizedByTagValue,
+		ExtraTags: map[string]string{},
+	}
+
+	if key := os.Getenv(TagKeyEnvVar); len(key) != 0 {
+		tagConfig.Key = key
+	}
+
+	if value := os.Getenv(TagValueEnvVar); len(value) != 0 {
+		tagConfig.Value = value
+	}
+
+	if extraTags := os.Getenv(ExtraTagsEnvVar); len(extraTags) != 0 {
+		parsed, err := parseExtraTags(ExtraTagsEnvVar, extraTags)
+		if err != nil {
+			return tagConfig, err
+		}
+		tagConfig.ExtraTags = parsed
+	}
+
+	if disabled := os.Getenv(DisableEntityTaggingEnvVar); len(disabled) != 0 {
+		value, err := strconv.ParseBool(disabled)
+		if err != nil {
+			return tagConfig, fmt.Errorf("invalid %s value %q: %v", DisableEntityTaggingEnvVar, disabled, err)
+		}
+		tagConfig.Disabled = value
+	}
+
+	if provenance := os.Getenv(ProvenanceTagsEnvVar); len(provenance) != 0 {
+		value, err := strconv.ParseBool(provenance)
+		if err != nil {
+			return tagConfig, fmt.Errorf("invalid %s value %q: %v", ProvenanceTagsEnvVar, provenance, err)
+		}
+		tagConfig.Provenance = value
+	}
+
+	tagConfig.Workspace = os.Getenv(WorkspaceLabelEnvVar)
+
+	return tagConfig, nil
+}
+
+// parseExtraTags parses a comma separated list of key=value pairs, source names the setting in the errors
+func parseExtraTags(source string, extraTags string) (map[string]string, error) {
+	tags := map[string]string{}
+	for _, pair := range strings.Split(extraTags, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || len(key) == 0 {
+			return nil, fmt.Errorf("invalid %s entry %q, expected key=value", source, pair)
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
+// Tags returns the tag key and value together with the extra and provenance tags
+func (c TagConfig) Tags() map[string]string {
+	tags := maps.Clone(c.ExtraTags)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	tags[c.Key] = c.Value
+
+	if len(c.Workspace) != 0 {
+		tags[WorkspaceTagName] = c.Workspace
+	}
+	if len(c.ActionId) != 0 {
+		tags[ActionIdTagName] = c.ActionId
+	}
+	if len(c.AppliedAt) != 0 {
+		tags[AppliedAtTagName] = c.AppliedAt
+	}
+	return tags
+}
+
+// WithOverride returns the configuration with the disable_entity_tagging value of a data source
+// applied, a null value keeps the provider setting
+func (c TagConfig) WithOverride(disable types.Bool) TagConfig {
+	if !disable.IsNull() && !disable.IsUnknown() {
+		c.Disabled = disable.ValueBool()
+	}
+	return c
+}
+
+// WithAction returns the configuration with the provenance tags of the action whose values are
+// returned by a data source, nothing is added when the provenance tags are not enabled
+func (c TagConfig) WithAction(actions turboclient.ActionResults) TagConfig {
+	if !c.Provenance || len(actions) == 0 {
+		return c
+	}
+	c.ActionId = strconv.FormatInt(actions[0].ActionID, 10)
+	c.AppliedAt = time.Now().UTC().Format(time.RFC3339)
+	return c
+}
+
+// disableEntityTaggingAttribute is the per data source override of the provider disable_entity_tagging attribute
+var disableEntityTaggingAttribute = schema.BoolAttribute{
+	MarkdownDescription: "skip tagging the Turbonomic entity read by this data source; overrides the provider disable_entity_tagging attribute",
+	Optional:            true,
+}
+
+// actionIdAttribute is the id of the action the new values of a typed data source are taken from
+var actionIdAttribute = schema.Int64Attribute{
+	MarkdownDescription: "id of the Turbonomic action the new values are taken from, null when no action applies",
+	Computed:            true,
+}
+
+/*
+TagEntity tags the Turbonomic entity with the configured tags whose keys it does not have yet.
+Nothing is written when tagging is disabled.
+
+Turbonomic rejects a tag with a key that already exists, so the tags of the entity keep their
+value, except for the provenance tags written by the provider: they are deleted and written again
+when the entity is sized from another action or read from another workspace.
+*/
+func TagEntity(client turboclient.T8cClient, uuid string, tagConfig TagConfig) error {
+	if len(uuid) == 0 || tagConfig.Disabled {
+		return nil
+	}
+
+	entityTagsReq := turboclient.EntityRequest{
+		Uuid: uuid}
+
+	entityTags, err := client.GetEntityTags(entityTagsReq)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve entity tags from turbonomic: %v", err)
+	}
+
+	tags, replaced := entityTagChanges(entityTags, tagConfig.Tags())
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if len(replaced) != 0 {
+		deleter, ok := client.(entityTagDeleter)
+		if !ok {
+			return fmt.Errorf("unable to replace the provenance tags %v of an entity in turbonomic: the client cannot delete tags", replaced)
+		}
+		for _, key := range replaced {
+			if err := deleter.DeleteEntityTag(uuid, key); err != nil {
+				return fmt.Errorf("unable to replace a provenance tag of an entity in turbonomic: %v", err)
+			}
+		}
+	}
+
+	tagEntityReq := turboclient.TagEntityRequest{
+		Uuid: uuid,
+	}
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		tagEntityReq.Tags = append(tagEntityReq.Tags, turboclient.Tag{
+			Key:    key,
+			Values: []string{tags[key]},
+		})
+	}
+
+	_, err = client.TagEntity(tagEntityReq)
+	if err != nil {
+		if strings.Contains(err.Error(), TagAlreadyExistsErrorMsg) {
+			return nil
+		}
+		return fmt.Errorf("unable to tag an entity in turbonomic: %v", err)
+	}
+
+	return nil
+}
+
+/*
+entityTagChanges compares the tags of an entity with the configured tags. Only the provenance tags
+are replaced: the action id and workspace tags when their value differs, and the applied at tag
+with the action id tag, so that it keeps the time the entity was first tagged with the action.
+
+Returns:
+  - map[string]string: The configured tags to write on the entity
+  - []string: The sorted keys of the tags of the entity to delete before writing their configured value
+*/
+func entityTagChanges(entityTags []turboclient.Tag, tags map[string]string) (map[string]string, []string) {
+	tags = maps.Clone(tags)
+
+	var replaced []string
+	appliedAt := false
+	for _, item := range entityTags {
+		value, ok := tags[item.Key]
+		switch {
+		case !ok:
+		case item.Key == AppliedAtTagName:
+			appliedAt = true
+		case (item.Key == ActionIdTagName || item.Key == WorkspaceTagName) && !slices.Contains(item.Values, value):
+			replaced = append(replaced, item.Key)
+		default:
+			delete(tags, item.Key)
+		}
+	}
+
+	if appliedAt && slices.Contains(replaced, ActionIdTagName) {
+		replaced = append(replaced, AppliedAtTagName)
+	} else if appliedAt {
+		delete(tags, AppliedAtTagName)
+	}
+
+	slices.Sort(replaced)
+	return tags, replaced
+}
@@ -0,0 +1,223 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+// Tests the tagging configuration read from the environment variables
+func TestTagConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		expected    TagConfig
+		expectError bool
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{},
+			expected: TagConfig{
+				Key:       OptimizedByTagName,
+				Value:     OptimizedByTagValue,
+				ExtraTags: map[string]string{},
+			},
+		},
+		{
+			name: "custom tag with extra tags",
+			env: map[string]string{
+				TagKeyEnvVar:    "managed_by",
+				TagValueEnvVar:  "platform-team",
+				ExtraTagsEnvVar: "cost_center=1234, owner = payments",
+			},
+			expected: TagConfig{
+				Key:   "managed_by",
+				Value: "platform-team",
+				ExtraTags: map[string]string{
+					"cost_center": "1234",
+					"owner":       "payments",
+				},
+			},
+		},
+		{
+			name: "tagging disabled",
+			env: map[string]string{
+				DisableEntityTaggingEnvVar: "true",
+			},
+			expected: TagConfig{
+				Key:       OptimizedByTagName,
+				Value:     OptimizedByTagValue,
+				ExtraTags: map[string]string{},
+				Disabled:  true,
+			},
+		},
+		{
+			name: "invalid extra tags",
+			env: map[string]string{
+				ExtraTagsEnvVar: "cost_center",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid disable value",
+			env: map[string]string{
+				DisableEntityTaggingEnvVar: "sometimes",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, envVar := range []string{TagKeyEnvVar, TagValueEnvVar, ExtraTagsEnvVar, DisableEntityTaggingEnvVar} {
+				t.Setenv(envVar, tt.env[envVar])
+			}
+
+			tagConfig, err := TagConfigFromEnv()
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, tagConfig)
+		})
+	}
+}
+
+// Tests the tags returned by the tagging configuration
+func TestTagConfigTags(t *testing.T) {
+	tagConfig := TagConfig{
+		Key:   OptimizedByTagName,
+		Value: OptimizedByTagValue,
+		ExtraTags: map[string]string{
+			"owner": "payments",
+		},
+	}
+
+	assert.Equal(t, map[string]string{
+		OptimizedByTagName: OptimizedByTagValue,
+		"owner":            "payments",
+	}, tagConfig.Tags())
+	assert.Len(t, tagConfig.ExtraTags, 1)
+}
+
+// Tests the data source override of the provider disable_entity_tagging attribute
+func TestTagConfigWithOverride(t *testing.T) {
+	tagConfig := TagConfig{Key: OptimizedByTagName, Value: OptimizedByTagValue}
+
+	assert.False(t, tagConfig.WithOverride(types.BoolNull()).Disabled)
+	assert.True(t, tagConfig.WithOverride(types.BoolValue(true)).Disabled)
+
+	tagConfig.Disabled = true
+	assert.True(t, tagConfig.WithOverride(types.BoolNull()).Disabled)
+	assert.False(t, tagConfig.WithOverride(types.BoolValue(false)).Disabled)
+}
+
+// Tests that no call is made to Turbonomic when tagging is disabled
+func TestTagEntityDisabled(t *testing.T) {
+	tagConfig := TagConfig{Key: OptimizedByTagName, Value: OptimizedByTagValue, Disabled: true}
+
+	assert.NoError(t, TagEntity(nil, "75878700658784", tagConfig))
+}
+
+// Tests that the provenance tags of another action are replaced through the session of the client of a read
+func TestTagEntityReplacesProvenanceTags(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{Method: http.MethodPost, Path: loginPath, ResponseCode: http.StatusOK, ResponseBody: `{"status":"ok"}`, Times: 1},
+		{Method: http.MethodDelete, Path: "/api/v3/entities/75878700658784/tags/turbonomic_action_id", ResponseCode: http.StatusOK, Times: 1},
+	})
+	httpClient, err := newHTTPClient(true, "")
+	require.NoError(t, err)
+	session := newAPISession(httpClient, strings.TrimPrefix(server.URL, "https://"),
+		connectivityCredentials{Username: "testuser", Password: "password"})
+
+	mockClient := new(MockT8cClient)
+	mockClient.On("GetEntityTags", turboclient.EntityRequest{Uuid: "75878700658784"}).Return([]turboclient.Tag{
+		{Key: OptimizedByTagName, Values: []string{OptimizedByTagValue}},
+		{Key: ActionIdTagName, Values: []string{"638861526930577"}},
+	}, nil)
+	mockClient.On("TagEntity", turboclient.TagEntityRequest{
+		Uuid: "75878700658784",
+		Tags: []turboclient.Tag{{Key: ActionIdTagName, Values: []string{"638861526930578"}}},
+	}).Return([]turboclient.Tag{}, nil)
+
+	tagConfig := TagConfig{Key: OptimizedByTagName, Value: OptimizedByTagValue, ActionId: "638861526930578"}
+	client := &requestClient{T8cClient: mockClient, ctx: context.Background(), requests: &clientRequests{session: session}}
+	assert.NoError(t, TagEntity(client, "75878700658784", tagConfig))
+	mockClient.AssertExpectations(t)
+
+	// the turbonomic client alone cannot delete the tag of the previous action
+	err = TagEntity(mockClient, "75878700658784", tagConfig)
+	assert.ErrorContains(t, err, "the client cannot delete tags")
+}
+
+// Tests the provenance tags of the action returned by a data source
+func TestTagConfigWithAction(t *testing.T) {
+	actions := turboclient.ActionResults{{ActionID: 638861526930578}}
+	tagConfig := TagConfig{Key: OptimizedByTagName, Value: OptimizedByTagValue, Workspace: "prod"}
+
+	tags := tagConfig.WithAction(actions).Tags()
+	assert.Equal(t, map[string]string{
+		OptimizedByTagName: OptimizedByTagValue,
+		WorkspaceTagName:   "prod",
+	}, tags)
+
+	tagConfig.Provenance = true
+	tags = tagConfig.WithAction(actions).Tags()
+	assert.Equal(t, "638861526930578", tags[ActionIdTagName])
+	_, err := time.Parse(time.RFC3339, tags[AppliedAtTagName])
+	assert.NoError(t, err)
+
+	assert.NotContains(t, tagConfig.WithAction(nil).Tags(), ActionIdTagName)
+}
+
+// Tests that only the missing tags are written and that only the provenance tags are replaced
+func TestEntityTagChanges(t *testing.T) {
+	entityTags := []turboclient.Tag{
+		{Key: OptimizedByTagName, Values: []string{OptimizedByTagValue}},
+		{Key: ActionIdTagName, Values: []string{"638861526930578"}},
+		{Key: AppliedAtTagName, Values: []string{"2026-10-19T08:00:00Z"}},
+		{Key: WorkspaceTagName, Values: []string{"prod"}},
+		{Key: "owner", Values: []string{"apps"}},
+	}
+
+	// the same action keeps the time it was first applied
+	tags, replaced := entityTagChanges(entityTags, map[string]string{
+		OptimizedByTagName: OptimizedByTagValue,
+		ActionIdTagName:    "638861526930578",
+		AppliedAtTagName:   "2026-10-20T08:00:00Z",
+		WorkspaceTagName:   "prod",
+	})
+	assert.Empty(t, tags)
+	assert.Empty(t, replaced)
+
+	// another action replaces the action id and applied at tags
+	tags, replaced = entityTagChanges(entityTags, map[string]string{
+		ActionIdTagName:  "638861526930999",
+		AppliedAtTagName: "2026-10-20T08:00:00Z",
+		WorkspaceTagName: "staging",
+		"team":           "payments",
+	})
+	assert.Equal(t, map[string]string{
+		ActionIdTagName:  "638861526930999",
+		AppliedAtTagName: "2026-10-20T08:00:00Z",
+		WorkspaceTagName: "staging",
+		"team":           "payments",
+	}, tags)
+	assert.Equal(t, []string{ActionIdTagName, AppliedAtTagName, WorkspaceTagName}, replaced)
+
+	// the tags that are not written by the provider are never replaced
+	tags, replaced = entityTagChanges(entityTags, map[string]string{OptimizedByTagName: "platform-terraform", "owner": "platform"})
+	assert.Empty(t, tags)
+	assert.Empty(t, replaced)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &GetTagsFunction{}
+
+// provenanceTagNames maps the provenance keys accepted by the options argument to the tag keys
+var provenanceTagNames = map[string]string{
+	"action_id":  ActionIdTagName,
+	"applied_at": AppliedAtTagName,
+	"workspace":  WorkspaceTagName,
+}
+
+// tagSettingNames are the keys of the options argument that override the TURBO_TAG_KEY, TURBO_TAG_VALUE
+// and TURBO_EXTRA_TAGS environment variables, to pass the tag_key, tag_value and extra_tags of the provider block
+var tagSettingNames = []string{"tag_key", "tag_value", "extra_tags"}
+
+type GetTagsFunction struct{}
+
+func NewGetTagsFunction() function.Function {
+	return &GetTagsFunction{}
+}
+
+func (f *GetTagsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "get_tags"
+}
+
+func (f *GetTagsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Get turbonomic tags",
+		Description: "Returns the tags written by the provider on the entities, by default {turbonomic_optimized_by = \"turbonomic-terraform-provider\"}. " +
+			"Provider functions cannot access the provider configuration, so the tag key and value and any extra tags are read from " +
+			"the tag_key, tag_value and extra_tags keys of the optional options map, falling back to the TURBO_TAG_KEY, TURBO_TAG_VALUE " +
+			"and TURBO_EXTRA_TAGS environment variables; pass the values of the provider block to return the tags written on the entities. " +
+			"The action_id, applied_at and workspace keys of the options map add the turbonomic_action_id, " +
+			"turbonomic_applied_at and turbonomic_workspace tags, the workspace defaults to TURBO_WORKSPACE_LABEL",
+		Parameters: []function.Parameter{},
+		VariadicParameter: function.MapParameter{
+			Name: "options",
+			Description: "optional map with the tag_key, tag_value and extra_tags of the provider, extra_tags as a comma separated " +
+				"list of key=value pairs, and the action_id, applied_at and workspace of the recommendation",
+			ElementType: types.StringType,
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Returns the optimized by turbonomic provider tag together with the extra and provenance tags
+func (f *GetTagsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var options []map[string]string
+	resp.Error = req.Arguments.Get(ctx, &options)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(options) > 1 {
+		resp.Error = function.NewArgumentFuncError(0, "at most one options map can be passed")
+		return
+	}
+
+	tagConfig, err := TagConfigFromEnv()
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	for _, values := range options {
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			if _, ok := provenanceTagNames[key]; !ok && !slices.Contains(tagSettingNames, key) {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown option %q, expected one of: "+
+					"tag_key, tag_value, extra_tags, action_id, applied_at, workspace", key))
+				return
+			}
+		}
+		if key := values["tag_key"]; len(key) != 0 {
+			tagConfig.Key = key
+		}
+		if value := values["tag_value"]; len(value) != 0 {
+			tagConfig.Value = value
+		}
+		if extraTags, ok := values["extra_tags"]; ok {
+			tagConfig.ExtraTags = map[string]string{}
+			if len(extraTags) != 0 {
+				tagConfig.ExtraTags, err = parseExtraTags("extra_tags", extraTags)
+				if err != nil {
+					resp.Error = function.NewArgumentFuncError(0, err.Error())
+					return
+				}
+			}
+		}
+		tagConfig.ActionId = values["action_id"]
+		tagConfig.AppliedAt = values["applied_at"]
+		if workspace, ok := values["workspace"]; ok {
+			tagConfig.Workspace = workspace
+		}
+	}
+
+	tagValueMap, diags := types.MapValueFrom(ctx, types.StringType, tagConfig.Tags())
+
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, tagValueMap))
+
+}
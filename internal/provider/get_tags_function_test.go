@@ -0,0 +1,113 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// runGetTags runs the get_tags function with the given options maps
+func runGetTags(t *testing.T, options ...map[string]string) (map[string]string, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+	mapType := types.MapType{ElemType: types.StringType}
+	var elemTypes []attr.Type
+	var elems []attr.Value
+	for _, values := range options {
+		mapValue, diags := types.MapValueFrom(ctx, types.StringType, values)
+		assert.False(t, diags.HasError())
+		elemTypes = append(elemTypes, mapType)
+		elems = append(elems, mapValue)
+	}
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.TupleValueMust(elemTypes, elems)}),
+	}
+	resp := function.RunResponse{
+		Result: function.NewResultData(types.MapUnknown(types.StringType)),
+	}
+	NewGetTagsFunction().Run(ctx, req, &resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var tags map[string]string
+	diags := resp.Result.Value().(types.Map).ElementsAs(ctx, &tags, false)
+	assert.False(t, diags.HasError())
+	return tags, nil
+}
+
+// test the provenance tags returned by the function
+func TestGetTagsFunction_Provenance(t *testing.T) {
+	for _, envVar := range []string{TagKeyEnvVar, TagValueEnvVar, ExtraTagsEnvVar, WorkspaceLabelEnvVar} {
+		t.Setenv(envVar, "")
+	}
+
+	tags, err := runGetTags(t)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{OptimizedByTagName: OptimizedByTagValue}, tags)
+
+	t.Setenv(WorkspaceLabelEnvVar, "prod")
+	tags, err = runGetTags(t, map[string]string{
+		"action_id":  "638861526930578",
+		"applied_at": "2026-10-19T08:00:00Z",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		OptimizedByTagName: OptimizedByTagValue,
+		ActionIdTagName:    "638861526930578",
+		AppliedAtTagName:   "2026-10-19T08:00:00Z",
+		WorkspaceTagName:   "prod",
+	}, tags)
+
+	tags, err = runGetTags(t, map[string]string{"workspace": "staging"})
+	assert.Nil(t, err)
+	assert.Equal(t, "staging", tags[WorkspaceTagName])
+
+	_, err = runGetTags(t, map[string]string{"action": "638861526930578"})
+	assert.NotNil(t, err)
+
+	_, err = runGetTags(t, map[string]string{}, map[string]string{})
+	assert.NotNil(t, err)
+}
+
+// test the tag settings of the provider block passed to the function
+func TestGetTagsFunction_TagSettings(t *testing.T) {
+	t.Setenv(TagKeyEnvVar, "managed_by")
+	t.Setenv(TagValueEnvVar, "env-terraform")
+	t.Setenv(ExtraTagsEnvVar, "team=platform")
+	t.Setenv(WorkspaceLabelEnvVar, "")
+
+	tags, err := runGetTags(t)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"managed_by": "env-terraform", "team": "platform"}, tags)
+
+	tags, err = runGetTags(t, map[string]string{
+		"tag_key":    "owner",
+		"tag_value":  "platform-terraform",
+		"extra_tags": "cost_center=1234, env = prod",
+		"action_id":  "638861526930578",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"owner":         "platform-terraform",
+		"cost_center":   "1234",
+		"env":           "prod",
+		ActionIdTagName: "638861526930578",
+	}, tags)
+
+	tags, err = runGetTags(t, map[string]string{"extra_tags": ""})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"managed_by": "env-terraform"}, tags)
+
+	_, err = runGetTags(t, map[string]string{"extra_tags": "cost_center"})
+	assert.ErrorContains(t, err, `invalid extra_tags entry "cost_center", expected key=value`)
+}
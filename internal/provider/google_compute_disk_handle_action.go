@@ -15,18 +15,6 @@ import (
 	turboclient "github.com/IBM/turbonomic-go-client"
 )
 
-var (
-	google_storage_tiers_map = map[string]string{
-		"Standard Persistent Disk": "pd-standard",
-		"Balanced Persistent Disk": "pd-balanced",
-		"SSD Persistent Disk":      "pd-ssd",
-		"Extreme Persistent Disk":  "pd-extreme",
-		"Hyperdisk Balanced":       "hyperdisk-balanced",
-		"Hyperdisk Throughput":     "hyperdisk-throughput",
-		"Hyperdisk Extreme":        "hyperdisk-extreme",
-	}
-)
-
 // GoogleComputeDiskStateAdapter adapts GoogleComputeDiskEntityModel to VolumeStateUpdater interface
 type GoogleComputeDiskStateAdapter struct {
 	State *GoogleComputeDiskEntityModel
@@ -55,11 +43,7 @@ func HandleGoogleComputeDiskAction(ctx context.Context, resp *datasource.ReadRes
 }
 
 func getStorageTier(disk string) (string, error) {
-	storageType, ok := google_storage_tiers_map[disk]
-	if !ok {
-		return "", fmt.Errorf("unknown storage type provided: %s", disk)
-	}
-	return storageType, nil
+	return tierToSku(CloudGCP, DiskResourceKind, disk)
 }
 
 // HandleGoogleComputeDiskCommodityAction processes commodity actions for Google Compute Disk entities and updates the state with appropriate values.
@@ -108,6 +108,10 @@ func TestGoogleComputeInstanceDataSourceWithValidEntity(t *testing.T) {
 							resource.TestCheckResourceAttr(googleVMDataSourceRef, "default_machine_type", tc.expectedDefaultType),
 							resource.TestCheckResourceAttr(googleVMDataSourceRef, "current_machine_type", tc.expectedCurrentType),
 							resource.TestCheckResourceAttr(googleVMDataSourceRef, "new_machine_type", tc.expectedNewType),
+							resource.TestCheckResourceAttr(googleVMDataSourceRef, "currency", "USD"),
+							resource.TestCheckResourceAttrSet(googleVMDataSourceRef, "current_cost"),
+							resource.TestCheckResourceAttrSet(googleVMDataSourceRef, "projected_cost"),
+							resource.TestCheckResourceAttrSet(googleVMDataSourceRef, "monthly_savings"),
 						),
 					},
 				},
@@ -27,17 +27,17 @@ const (
 	// Tag key and value for "optimized by" tag
 	OptimizedByTagName       = "turbonomic_optimized_by"
 	OptimizedByTagValue      = "turbonomic-terraform-provider"
-	TagAlreadyExistsErrorMsg = "INVALID_ARGUMENT: Trying to insert a tag with a key that already exists: turbonomic_optimized_by"
+	TagAlreadyExistsErrorMsg = "INVALID_ARGUMENT: Trying to insert a tag with a key that already exists"
 )
 
 // convertKbitToMiBps converts a value from Kibit/sec to MiB/sec and rounds the result
 func convertKibitToMiBps(value float64) float64 {
-	return math.Round(value / 8192)
+	return math.Round(value * units[KibitPerSecUnit].factor / units[MiBPerSecUnit].factor)
 }
 
 // convertMBtoGiB converts a value from MB to GiB and rounds to the nearest integer
 func convertMiBtoGiB(value float64) int64 {
-	return int64(math.Round(value / 1024))
+	return int64(math.Round(value * units[MBUnit].factor / units[GiBUnit].factor))
 }
 
 // convertSliceToUppercase converts a slice os strings to uppercase
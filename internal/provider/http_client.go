@@ -0,0 +1,60 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/IBM/terraform-provider-turbonomic/pkg/logger"
+)
+
+// httpClientTimeout is the timeout of the requests that the provider sends without the turbonomic client
+const httpClientTimeout = 30 * time.Second
+
+/*
+newHTTPClient creates the HTTP client of the requests that the provider sends without the
+turbonomic client, such as the access token requests.
+
+Parameters:
+  - skipverify: Whether to skip the verification of the TLS certificate
+  - caFile: The PEM file of the certificate authorities that sign the certificate of the
+    Turbonomic instance, the system certificate authorities are used when it is empty
+
+Returns:
+  - *http.Client: The HTTP client
+  - error: An error when the certificate authorities cannot be read
+*/
+func newHTTPClient(skipverify bool, caFile string) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: skipverify}
+
+	if caFile != "" && !skipverify {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the certificate authorities file: %v", err)
+		}
+
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificate found in the certificate authorities file %s", caFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Timeout: httpClientTimeout,
+		// the requests and responses are logged with their bodies at the trace level
+		Transport: &logger.TraceTransport{Base: transport},
+	}, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests the verification of the Turbonomic certificate with the certificate authorities file
+func TestNewHTTPClient(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{
+			Method:       http.MethodGet,
+			Path:         "/api/v3/admin/versioninfo",
+			ResponseCode: http.StatusOK,
+		},
+	})
+	caFile := writeSecretFile(t, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})))
+
+	httpClient, err := newHTTPClient(false, caFile)
+	assert.NoError(t, err)
+	resp, err := httpClient.Get(server.URL + "/api/v3/admin/versioninfo")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	httpClient, err = newHTTPClient(false, "")
+	assert.NoError(t, err)
+	_, err = httpClient.Get(server.URL + "/api/v3/admin/versioninfo")
+	assert.ErrorContains(t, err, "certificate")
+
+	_, err = newHTTPClient(false, writeSecretFile(t, "not a certificate"))
+	assert.ErrorContains(t, err, "no PEM certificate found")
+
+	_, err = newHTTPClient(false, filepath.Join(t.TempDir(), "missing.pem"))
+	assert.ErrorContains(t, err, "unable to read the certificate authorities file")
+}
@@ -0,0 +1,71 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &InstanceTypeFamilyFunction{}
+
+type InstanceTypeFamilyFunction struct{}
+
+func NewInstanceTypeFamilyFunction() function.Function {
+	return &InstanceTypeFamilyFunction{}
+}
+
+func (f *InstanceTypeFamilyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "instance_type_family"
+}
+
+func (f *InstanceTypeFamilyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Get the family of an instance type",
+		Description: "Returns the family of an instance type, E.G: m5 for the AWS m5.2xlarge, Ds_v3 for the Azure Standard_D4s_v3 " +
+			"and n2-standard for the GCP n2-standard-8. The older and shared-core sizes have the family of their newer form, " +
+			"E.G: Ds_v2 for Standard_DS2_v2 and e2-standard for e2-small",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cloud",
+				Description: "case insensitive cloud provider, one of aws, azure or gcp",
+			},
+			function.StringParameter{
+				Name:        "type",
+				Description: "instance type, E.G: m5.2xlarge",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Returns the family of the instance type
+func (f *InstanceTypeFamilyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cloud, name string
+
+	resp.Error = req.Arguments.Get(ctx, &cloud, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	family, err := instanceTypeFamily(cloud, name)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, family)
+}
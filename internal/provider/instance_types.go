@@ -0,0 +1,173 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// instanceType is an instance type split into its family and size. The size units order the
+// instance types of a cloud: the AWS normalization factor, or the number of vCPUs on Azure and GCP.
+type instanceType struct {
+	cloud  string
+	family string
+	size   string
+	units  float64
+}
+
+var (
+	// AWS instance types such as m5.2xlarge or db.r6g.large
+	awsInstanceTypeRegex = regexp.MustCompile(`^(?:db\.)?([a-z][a-z0-9-]*)\.([a-z0-9-]+)$`)
+	awsMultipleSizeRegex = regexp.MustCompile(`^(?:metal-)?(\d+)xl(?:arge)?$`)
+
+	// Azure VM sizes such as Standard_D4s_v3 or Standard_E8-4ds_v5
+	azureInstanceTypeRegex = regexp.MustCompile(`^(?:(?i:standard|basic)_)?([A-Z]+)(\d+)(?:-\d+)?([a-z]*)(?:_(v\d+))?$`)
+
+	// GCP machine types such as n2-standard-8 or c3-highcpu-4-lssd
+	gcpInstanceTypeRegex = regexp.MustCompile(`^([a-z]\d+[a-z]?)-([a-z]+)-(\d+)(-lssd)?$`)
+)
+
+// awsSizeUnits is the AWS normalization factor of the named instance sizes, the xlarge multiples
+// such as 2xlarge are 8 units per xlarge
+var awsSizeUnits = map[string]float64{
+	"nano":   0.25,
+	"micro":  0.5,
+	"small":  1,
+	"medium": 2,
+	"large":  4,
+	"xlarge": 8,
+	// bare metal is the largest size of its family
+	"metal": math.MaxFloat64,
+}
+
+// gcpSharedCoreTypes are the GCP shared-core machine types, sized as a fraction of a vCPU. They belong
+// to the standard family of their series, the f1 and g1 types are the shared-core types of N1.
+var gcpSharedCoreTypes = map[string]instanceType{
+	"e2-micro":  {CloudGCP, "e2-standard", "micro", 0.25},
+	"e2-small":  {CloudGCP, "e2-standard", "small", 0.5},
+	"e2-medium": {CloudGCP, "e2-standard", "medium", 1},
+	"f1-micro":  {CloudGCP, "n1-standard", "micro", 0.2},
+	"g1-small":  {CloudGCP, "n1-standard", "small", 0.5},
+}
+
+/*
+parseInstanceType splits an instance type into its family and size.
+
+Parameters:
+  - cloud: The cloud of the instance type, one of aws, azure or gcp; an empty cloud is detected from the name
+  - name: The instance type, E.G: m5.2xlarge, Standard_D4s_v3 or n2-standard-8
+
+Returns:
+  - instanceType: The family, size and size units of the instance type
+  - error: An error when the instance type cannot be parsed
+*/
+func parseInstanceType(cloud, name string) (instanceType, error) {
+	switch strings.ToLower(cloud) {
+	case CloudAWS:
+		return parseAwsInstanceType(name)
+	case CloudAzure:
+		return parseAzureInstanceType(name)
+	case CloudGCP:
+		return parseGcpInstanceType(name)
+	case "":
+		for _, parse := range []func(string) (instanceType, error){parseAwsInstanceType, parseAzureInstanceType, parseGcpInstanceType} {
+			if parsed, err := parse(name); err == nil {
+				return parsed, nil
+			}
+		}
+		return instanceType{}, fmt.Errorf("unknown instance type: %s", name)
+	default:
+		return instanceType{}, fmt.Errorf("unknown cloud %q, expected one of: %s", cloud, strings.Join(tierClouds, ", "))
+	}
+}
+
+func parseAwsInstanceType(name string) (instanceType, error) {
+	matches := awsInstanceTypeRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return instanceType{}, fmt.Errorf("unknown aws instance type: %s", name)
+	}
+
+	family, size := matches[1], matches[2]
+	units, ok := awsSizeUnits[size]
+	if !ok {
+		multiple := awsMultipleSizeRegex.FindStringSubmatch(size)
+		if multiple == nil {
+			return instanceType{}, fmt.Errorf("unknown aws instance size %s of instance type %s", size, name)
+		}
+		count, _ := strconv.Atoi(multiple[1])
+		units = float64(count) * awsSizeUnits["xlarge"]
+	}
+
+	return instanceType{cloud: CloudAWS, family: family, size: size, units: units}, nil
+}
+
+func parseAzureInstanceType(name string) (instanceType, error) {
+	matches := azureInstanceTypeRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return instanceType{}, fmt.Errorf("unknown azure instance type: %s", name)
+	}
+
+	// the S of the older sizes such as DS2_v2 is the premium storage feature s of D2s_v2
+	series, features := matches[1], matches[3]
+	if len(series) > 1 && strings.HasSuffix(series, "S") {
+		series = strings.TrimSuffix(series, "S")
+		if !strings.Contains(features, "s") {
+			features += "s"
+		}
+	}
+
+	family := series + features
+	if len(matches[4]) != 0 {
+		family += "_" + matches[4]
+	}
+	units, _ := strconv.ParseFloat(matches[2], 64)
+
+	return instanceType{cloud: CloudAzure, family: family, size: matches[2], units: units}, nil
+}
+
+func parseGcpInstanceType(name string) (instanceType, error) {
+	if sharedCore, ok := gcpSharedCoreTypes[name]; ok {
+		return sharedCore, nil
+	}
+
+	matches := gcpInstanceTypeRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return instanceType{}, fmt.Errorf("unknown gcp instance type: %s", name)
+	}
+	units, _ := strconv.ParseFloat(matches[3], 64)
+
+	return instanceType{cloud: CloudGCP, family: matches[1] + "-" + matches[2] + matches[4], size: matches[3], units: units}, nil
+}
+
+// compareInstanceTypes returns -1, 0 or 1 when the size of instance type a is smaller, equal
+// or bigger than the size of instance type b
+func compareInstanceTypes(cloud, a, b string) (int, error) {
+	typeA, err := parseInstanceType(cloud, a)
+	if err != nil {
+		return 0, err
+	}
+	typeB, err := parseInstanceType(cloud, b)
+	if err != nil {
+		return 0, err
+	}
+	if typeA.cloud != typeB.cloud {
+		return 0, fmt.Errorf("cannot compare %s instance type %s with %s instance type %s", typeA.cloud, a, typeB.cloud, b)
+	}
+
+	return cmp.Compare(typeA.units, typeB.units), nil
+}
+
+// instanceTypeFamily returns the family of an instance type, E.G: m5 for m5.2xlarge
+func instanceTypeFamily(cloud, name string) (string, error) {
+	parsed, err := parseInstanceType(cloud, name)
+	if err != nil {
+		return "", err
+	}
+	return parsed.family, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInstanceType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cloud    string
+		input    string
+		expected instanceType
+	}{
+		{name: "AWS instance", cloud: "aws", input: "m5.2xlarge", expected: instanceType{CloudAWS, "m5", "2xlarge", 16}},
+		{name: "AWS named size", cloud: "aws", input: "t3.micro", expected: instanceType{CloudAWS, "t3", "micro", 0.5}},
+		{name: "AWS RDS instance class", cloud: "aws", input: "db.r6g.large", expected: instanceType{CloudAWS, "r6g", "large", 4}},
+		{name: "AWS bare metal", cloud: "aws", input: "c5.metal", expected: instanceType{CloudAWS, "c5", "metal", math.MaxFloat64}},
+		{name: "AWS sized bare metal", cloud: "aws", input: "c7i.metal-24xl", expected: instanceType{CloudAWS, "c7i", "metal-24xl", 192}},
+		{name: "Azure VM", cloud: "azure", input: "Standard_D4s_v3", expected: instanceType{CloudAzure, "Ds_v3", "4", 4}},
+		{name: "Azure burstable VM", cloud: "azure", input: "Standard_B2ms", expected: instanceType{CloudAzure, "Bms", "2", 2}},
+		{name: "Azure constrained vCPU VM", cloud: "azure", input: "Standard_E8-4ds_v5", expected: instanceType{CloudAzure, "Eds_v5", "8", 8}},
+		{name: "GCP machine type", cloud: "gcp", input: "n2-standard-8", expected: instanceType{CloudGCP, "n2-standard", "8", 8}},
+		{name: "GCP local SSD machine type", cloud: "gcp", input: "c3-highcpu-4-lssd", expected: instanceType{CloudGCP, "c3-highcpu-lssd", "4", 4}},
+		{name: "GCP shared core machine type", cloud: "gcp", input: "e2-medium", expected: instanceType{CloudGCP, "e2-standard", "medium", 1}},
+		{name: "GCP N1 shared core machine type", cloud: "gcp", input: "g1-small", expected: instanceType{CloudGCP, "n1-standard", "small", 0.5}},
+		{name: "Azure premium storage VM", cloud: "azure", input: "Standard_DS2_v2", expected: instanceType{CloudAzure, "Ds_v2", "2", 2}},
+		{name: "Detected cloud", input: "Standard_F8s_v2", expected: instanceType{CloudAzure, "Fs_v2", "8", 8}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := parseInstanceType(tc.cloud, tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	for _, input := range []string{"m5.huge", "Standard_Dx_v3", "n2-standard"} {
+		_, err := parseInstanceType("", input)
+		assert.Error(t, err, input)
+	}
+
+	_, err := parseInstanceType("ibm", "bx2-2x8")
+	assert.ErrorContains(t, err, `unknown cloud "ibm"`)
+}
+
+func TestCompareInstanceTypes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cloud    string
+		a        string
+		b        string
+		expected int
+	}{
+		{name: "AWS bigger", cloud: "aws", a: "m5.2xlarge", b: "m5.xlarge", expected: 1},
+		{name: "AWS smaller", cloud: "aws", a: "t3.micro", b: "t3.small", expected: -1},
+		{name: "AWS same size across families", cloud: "AWS", a: "m5.large", b: "c5.large", expected: 0},
+		{name: "AWS bare metal", cloud: "aws", a: "m5.metal", b: "m5.24xlarge", expected: 1},
+		{name: "Azure bigger", cloud: "azure", a: "Standard_D8s_v3", b: "Standard_D4s_v3", expected: 1},
+		{name: "Azure constrained vCPU", cloud: "azure", a: "Standard_E8-4ds_v5", b: "Standard_E8ds_v5", expected: 0},
+		{name: "GCP smaller", cloud: "gcp", a: "n2-standard-4", b: "n2-standard-8", expected: -1},
+		{name: "GCP shared core", cloud: "gcp", a: "e2-medium", b: "e2-standard-2", expected: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := compareInstanceTypes(tc.cloud, tc.a, tc.b)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	_, err := compareInstanceTypes("aws", "m5.large", "Standard_D4s_v3")
+	assert.Error(t, err)
+
+	_, err = compareInstanceTypes("", "m5.large", "Standard_D4s_v3")
+	assert.ErrorContains(t, err, "cannot compare aws instance type m5.large with azure instance type Standard_D4s_v3")
+}
+
+func TestInstanceTypeFamily(t *testing.T) {
+	testCases := []struct {
+		cloud    string
+		input    string
+		expected string
+	}{
+		{cloud: "aws", input: "m5.2xlarge", expected: "m5"},
+		{cloud: "aws", input: "db.m6g.large", expected: "m6g"},
+		{cloud: "azure", input: "Standard_D4s_v3", expected: "Ds_v3"},
+		{cloud: "azure", input: "Standard_DS2_v2", expected: "Ds_v2"},
+		{cloud: "gcp", input: "n2-highmem-4", expected: "n2-highmem"},
+		{cloud: "gcp", input: "e2-small", expected: "e2-standard"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			family, err := instanceTypeFamily(tc.cloud, tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, family)
+		})
+	}
+
+	_, err := instanceTypeFamily("gcp", "m5.large")
+	assert.ErrorContains(t, err, "unknown gcp instance type: m5.large")
+}
+
+// Tests that the named sizes of the size tables are in ascending order
+func TestInstanceSizeTablesOrder(t *testing.T) {
+	awsSizes := []string{"nano", "micro", "small", "medium", "large", "xlarge", "2xlarge", "metal"}
+	for i := 1; i < len(awsSizes); i++ {
+		result, err := compareInstanceTypes(CloudAWS, "m5."+awsSizes[i], "m5."+awsSizes[i-1])
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result, awsSizes[i])
+	}
+
+	gcpSizes := []string{"e2-micro", "e2-small", "e2-medium", "e2-standard-2"}
+	for i := 1; i < len(gcpSizes); i++ {
+		result, err := compareInstanceTypes(CloudGCP, gcpSizes[i], gcpSizes[i-1])
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result, gcpSizes[i])
+	}
+}
+
+// Tests that the older and newer names of the sizes of a family have the same family
+func TestInstanceTypeFamilyForms(t *testing.T) {
+	testCases := []struct {
+		cloud string
+		a     string
+		b     string
+	}{
+		{cloud: "gcp", a: "e2-small", b: "e2-standard-2"},
+		{cloud: "gcp", a: "f1-micro", b: "n1-standard-1"},
+		{cloud: "azure", a: "Standard_DS2_v2", b: "Standard_D2s_v2"},
+		{cloud: "azure", a: "Standard_GS5", b: "Standard_G5s"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			familyA, err := instanceTypeFamily(tc.cloud, tc.a)
+			assert.NoError(t, err)
+			familyB, err := instanceTypeFamily(tc.cloud, tc.b)
+			assert.NoError(t, err)
+			assert.Equal(t, familyA, familyB)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Environment variables of the named profiles
+	ProfileEnvVar    = "TURBO_PROFILE"
+	ConfigFileEnvVar = "TURBO_CONFIG_FILE"
+
+	// Authentication methods of a profile
+	PasswordAuthMethod = "password"
+	OAuthAuthMethod    = "oauth"
+)
+
+// profileKeys are the keys that can be set in a profile of the config file
+var profileKeys = []string{"hostname", "auth_method", "username", "password", "password_file", "client_id",
+	"client_secret", "client_secret_file", "role", "credential_process", "skipverify", "ca_file"}
+
+// turboProfile is a named profile of the Turbonomic config file
+type turboProfile struct {
+	Name   string
+	File   string
+	values map[string]string
+}
+
+// defaultConfigFile returns the path of the Turbonomic config file, ~/.turbonomic/config
+// unless TURBO_CONFIG_FILE is set
+func defaultConfigFile() (string, error) {
+	if configFile := os.Getenv(ConfigFileEnvVar); configFile != "" {
+		return configFile, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the home directory of the turbonomic config file: %v", err)
+	}
+	return filepath.Join(home, ".turbonomic", "config"), nil
+}
+
+// loadProfileFromConfigFile reads and validates a named profile of the default Turbonomic config file
+func loadProfileFromConfigFile(name string) (turboProfile, error) {
+	configFile, err := defaultConfigFile()
+	if err != nil {
+		return turboProfile{Name: name}, err
+	}
+	return loadProfile(configFile, name)
+}
+
+/*
+loadProfile reads and validates a named profile of a Turbonomic config file.
+
+Parameters:
+  - configFile: The path of the config file
+  - name: The name of the profile
+
+Returns:
+  - turboProfile: The profile
+  - error: An error when the config file cannot be read or the profile is missing or invalid
+*/
+func loadProfile(configFile, name string) (turboProfile, error) {
+	profile := turboProfile{Name: name, File: configFile}
+
+	file, err := os.Open(configFile)
+	if err != nil {
+		return profile, fmt.Errorf("unable to read the turbonomic config file: %v", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	profiles, err := parseProfiles(file)
+	if err != nil {
+		return profile, fmt.Errorf("invalid turbonomic config file %s: %v", configFile, err)
+	}
+
+	values, ok := profiles[name]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for profileName := range profiles {
+			names = append(names, profileName)
+		}
+		slices.Sort(names)
+		return profile, fmt.Errorf("profile %q not found in %s, the available profiles are: %s", name, configFile, strings.Join(names, ", "))
+	}
+	profile.values = values
+
+	return profile, profile.validate()
+}
+
+/*
+parseProfiles parses the profiles of a config file in the INI format of the AWS CLI config file.
+A profile starts with a [name] or [profile name] section, followed by key = value lines, and
+the lines starting with # or ; are comments.
+
+Parameters:
+  - r: The content of the config file
+
+Returns:
+  - map[string]map[string]string: The keys and values of each profile
+  - error: An error with the line number of an invalid line
+*/
+func parseProfiles(r io.Reader) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid profile section %s", lineNumber, line)
+			}
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"))
+			if fields := strings.Fields(name); len(fields) == 2 && fields[0] == "profile" {
+				name = fields[1]
+			}
+			if name == "" || strings.ContainsAny(name, " \t") {
+				return nil, fmt.Errorf("line %d: invalid profile name in %s", lineNumber, line)
+			}
+			if _, ok := profiles[name]; ok {
+				return nil, fmt.Errorf("line %d: duplicate profile %q", lineNumber, name)
+			}
+			current = map[string]string{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: key %s is not in a profile section", lineNumber, strings.TrimSpace(key))
+		}
+		current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return profiles, scanner.Err()
+}
+
+// validate checks the keys of the profile and the consistency of its authentication method
+func (p turboProfile) validate() error {
+	for key := range p.values {
+		if !slices.Contains(profileKeys, key) {
+			return fmt.Errorf("unknown key %q in profile %q of %s, expected one of: %s", key, p.Name, p.File, strings.Join(profileKeys, ", "))
+		}
+	}
+
+	if _, err := p.Bool("skipverify"); err != nil {
+		return err
+	}
+
+	for _, pair := range [][2]string{{"password", "password_file"}, {"client_secret", "client_secret_file"}} {
+		if p.values[pair[0]] != "" && p.values[pair[1]] != "" {
+			return fmt.Errorf("profile %q of %s sets both %s and %s, only one of them can be set", p.Name, p.File, pair[0], pair[1])
+		}
+	}
+
+	if role := p.values["role"]; role != "" && !slices.Contains(validRoles, role) {
+		return fmt.Errorf("profile %q of %s sets an unknown role %s, expected one of: %s", p.Name, p.File, role, strings.Join(validRoles, ", "))
+	}
+
+	switch p.values["auth_method"] {
+	case "":
+	case PasswordAuthMethod:
+		if p.values["username"] == "" && p.values["credential_process"] == "" {
+			return fmt.Errorf("profile %q of %s uses the password auth_method without a username or credential_process", p.Name, p.File)
+		}
+	case OAuthAuthMethod:
+		if p.values["client_id"] == "" && p.values["credential_process"] == "" {
+			return fmt.Errorf("profile %q of %s uses the oauth auth_method without a client_id or credential_process", p.Name, p.File)
+		}
+	default:
+		return fmt.Errorf("profile %q of %s sets an unknown auth_method %s, expected one of: %s, %s",
+			p.Name, p.File, p.values["auth_method"], PasswordAuthMethod, OAuthAuthMethod)
+	}
+
+	return nil
+}
+
+// Bool returns the boolean value of a key of the profile, false when the key is not set
+func (p turboProfile) Bool(key string) (bool, error) {
+	value := p.values[key]
+	if value == "" {
+		return false, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("profile %q of %s sets an invalid boolean %s = %s", p.Name, p.File, key, value)
+	}
+	return parsed, nil
+}
+
+// Secret returns a secret of the profile set inline or read from a file
+func (p turboProfile) Secret(key, fileKey string) (string, error) {
+	if value := p.values[key]; value != "" {
+		return value, nil
+	}
+	if file := p.values[fileKey]; file != "" {
+		secret, err := readSecretFile(file)
+		if err != nil {
+			return "", fmt.Errorf("%s of profile %q: %v", fileKey, p.Name, err)
+		}
+		return secret, nil
+	}
+	return "", nil
+}
+
+// FillCredentials sets the credentials of the authentication method of the profile, the auth_method
+// selects the username or the client_id when the profile sets both
+func (p turboProfile) FillCredentials(username, clientId, credentialProcess *string) {
+	credentials := map[string]*string{"credential_process": credentialProcess}
+	switch p.values["auth_method"] {
+	case PasswordAuthMethod:
+		credentials["username"] = username
+	case OAuthAuthMethod:
+		credentials["client_id"] = clientId
+	default:
+		credentials["username"] = username
+		credentials["client_id"] = clientId
+	}
+	p.Fill(credentials)
+}
+
+// Fill sets the values that are still empty with the values of the profile
+func (p turboProfile) Fill(values map[string]*string) {
+	for key, value := range values {
+		if *value == "" {
+			*value = p.values[key]
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfigFile = `# Turbonomic instances
+[default]
+hostname = turbo-dev.example.com
+username = admin
+password = s3cr3t
+
+[profile prod-us]
+hostname    = turbo-prod-us.example.com
+auth_method = oauth
+client_id   = 12345
+client_secret_file = %s
+role        = OBSERVER
+ca_file     = /etc/ssl/turbonomic-ca.pem
+
+; shared core instance
+[profile prod-eu]
+hostname   = turbo-prod-eu.example.com
+skipverify = true
+`
+
+// writeConfigFile writes a Turbonomic config file in a temporary directory of the test
+func writeConfigFile(t *testing.T, content string) string {
+	name := filepath.Join(t.TempDir(), "config")
+	assert.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	return name
+}
+
+// Tests the parsing of the profiles of the config file
+func TestParseProfiles(t *testing.T) {
+	profiles, err := parseProfiles(strings.NewReader(strings.Replace(testConfigFile, "%s", "/vault/secrets/turbonomic", 1)))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"default": {
+			"hostname": "turbo-dev.example.com",
+			"username": "admin",
+			"password": "s3cr3t",
+		},
+		"prod-us": {
+			"hostname":           "turbo-prod-us.example.com",
+			"auth_method":        "oauth",
+			"client_id":          "12345",
+			"client_secret_file": "/vault/secrets/turbonomic",
+			"role":               "OBSERVER",
+			"ca_file":            "/etc/ssl/turbonomic-ca.pem",
+		},
+		"prod-eu": {
+			"hostname":   "turbo-prod-eu.example.com",
+			"skipverify": "true",
+		},
+	}, profiles)
+
+	invalidFiles := map[string]string{
+		"hostname = turbo.example.com":    "line 1: key hostname is not in a profile section",
+		"[prod\nhostname = a":             "line 1: invalid profile section [prod",
+		"[prod]\nhostname":                "line 2: expected key = value",
+		"[prod]\n[profile prod]":          `line 2: duplicate profile "prod"`,
+		"[profile prod eu]\nhostname = a": "line 1: invalid profile name in [profile prod eu]",
+	}
+	for content, expectedError := range invalidFiles {
+		_, err := parseProfiles(strings.NewReader(content))
+		assert.EqualError(t, err, expectedError)
+	}
+}
+
+// Tests the profiles loaded from the config file and the diagnostics that name the profile at fault
+func TestLoadProfile(t *testing.T) {
+	secretFile := writeSecretFile(t, "201918171615141312\n")
+	configFile := writeConfigFile(t, strings.Replace(testConfigFile, "%s", secretFile, 1))
+
+	profile, err := loadProfile(configFile, "prod-us")
+	assert.NoError(t, err)
+	hostname, role := "", ""
+	profile.Fill(map[string]*string{"hostname": &hostname, "role": &role})
+	assert.Equal(t, "turbo-prod-us.example.com", hostname)
+	assert.Equal(t, "OBSERVER", role)
+	clientSecret, err := profile.Secret("client_secret", "client_secret_file")
+	assert.NoError(t, err)
+	assert.Equal(t, "201918171615141312", clientSecret)
+
+	profile, err = loadProfile(configFile, "prod-eu")
+	assert.NoError(t, err)
+	skipverify, err := profile.Bool("skipverify")
+	assert.NoError(t, err)
+	assert.True(t, skipverify)
+
+	_, err = loadProfile(configFile, "staging")
+	assert.EqualError(t, err, `profile "staging" not found in `+configFile+`, the available profiles are: default, prod-eu, prod-us`)
+
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "unknown key",
+			content:       "[prod]\nhost = turbo.example.com",
+			expectedError: `unknown key "host" in profile "prod" of %s`,
+		},
+		{
+			name:          "invalid skipverify",
+			content:       "[prod]\nskipverify = sometimes",
+			expectedError: `profile "prod" of %s sets an invalid boolean skipverify = sometimes`,
+		},
+		{
+			name:          "password and password file",
+			content:       "[prod]\nusername = admin\npassword = a\npassword_file = /tmp/a",
+			expectedError: `profile "prod" of %s sets both password and password_file, only one of them can be set`,
+		},
+		{
+			name:          "unknown role",
+			content:       "[prod]\nclient_id = 12345\nrole = OWNER",
+			expectedError: `profile "prod" of %s sets an unknown role OWNER`,
+		},
+		{
+			name:          "oauth without client",
+			content:       "[prod]\nauth_method = oauth\nusername = admin",
+			expectedError: `profile "prod" of %s uses the oauth auth_method without a client_id or credential_process`,
+		},
+		{
+			name:          "unknown auth method",
+			content:       "[prod]\nauth_method = kerberos",
+			expectedError: `profile "prod" of %s sets an unknown auth_method kerberos, expected one of: password, oauth`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			configFile := writeConfigFile(t, tc.content)
+			_, err := loadProfile(configFile, "prod")
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), strings.Replace(tc.expectedError, "%s", configFile, 1))
+		})
+	}
+}
+
+// Tests that the auth_method of a profile selects its credentials
+func TestProfileFillCredentials(t *testing.T) {
+	tests := []struct {
+		name             string
+		authMethod       string
+		expectedUsername string
+		expectedClientId string
+	}{
+		{name: "password", authMethod: PasswordAuthMethod, expectedUsername: "admin"},
+		{name: "oauth", authMethod: OAuthAuthMethod, expectedClientId: "12345"},
+		{name: "no auth method", expectedUsername: "admin", expectedClientId: "12345"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := turboProfile{Name: "prod", values: map[string]string{
+				"auth_method": tc.authMethod,
+				"username":    "admin",
+				"client_id":   "12345",
+			}}
+
+			username, clientId, credentialProcess := "", "", ""
+			profile.FillCredentials(&username, &clientId, &credentialProcess)
+			assert.Equal(t, tc.expectedUsername, username)
+			assert.Equal(t, tc.expectedClientId, clientId)
+			assert.Empty(t, credentialProcess)
+		})
+	}
+}
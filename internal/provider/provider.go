@@ -19,20 +19,28 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	turboclient "github.com/IBM/turbonomic-go-client"
 	turboLogging "github.com/IBM/turbonomic-go-client/logging"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -40,10 +48,45 @@ import (
 )
 
 var (
-	_ provider.Provider              = &turbonomicProvider{}
-	_ provider.ProviderWithFunctions = &turbonomicProvider{}
+	_ provider.Provider                       = &turbonomicProvider{}
+	_ provider.ProviderWithFunctions          = &turbonomicProvider{}
+	_ provider.ProviderWithEphemeralResources = &turbonomicProvider{}
 )
 
+// validRoles are the OAuth 2.0 roles that can be used to access the Turbonomic instance
+var validRoles = []string{"ADMINISTRATOR", "SITE_ADMIN", "AUTOMATOR",
+	"DEPLOYER", "ADVISOR", "OBSERVER", "OPERATIONAL_OBSERVER", "SHARED_ADVISOR",
+	"SHARED_OBSERVER", "REPORT_EDITOR"}
+
+// TurbonomicProviderData is passed by the provider to the data sources and ephemeral resources
+type TurbonomicProviderData struct {
+	Client    *turboclient.Client
+	TagConfig TagConfig
+
+	// Hostname, Skipverify, CAFile and OAuth are used by the ephemeral resources that call
+	// Turbonomic without the client
+	Hostname   string
+	Skipverify bool
+	CAFile     string
+	OAuth      OAuthConfig
+
+	// ServerInfo is the version of the Turbonomic instance
+	ServerInfo ServerInfo
+
+	// TraceRecorder writes the Turbonomic API exchanges to the trace file when debug_trace_dir is set
+	TraceRecorder *traceRecorder
+
+	// Requests limits and records the requests the data sources send with Client
+	Requests *clientRequests
+}
+
+// OAuthConfig is the OAuth 2.0 client configured for the provider
+type OAuthConfig struct {
+	ClientId     string
+	ClientSecret string
+	Role         string
+}
+
 type turbonomicProvider struct {
 	version  string
 	typeName string
@@ -57,6 +100,24 @@ type turbonomicProviderModel struct {
 	ClientSecret types.String `tfsdk:"client_secret"`
 	Role         types.String `tfsdk:"role"`
 	Skipverify   types.Bool   `tfsdk:"skipverify"`
+
+	PasswordFile      types.String `tfsdk:"password_file"`
+	ClientSecretFile  types.String `tfsdk:"client_secret_file"`
+	CredentialProcess types.String `tfsdk:"credential_process"`
+	Profile           types.String `tfsdk:"profile"`
+	CAFile            types.String `tfsdk:"ca_file"`
+	AllowUnreachable  types.Bool   `tfsdk:"allow_unreachable"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	DebugTraceDir         types.String  `tfsdk:"debug_trace_dir"`
+
+	TagKey               types.String `tfsdk:"tag_key"`
+	TagValue             types.String `tfsdk:"tag_value"`
+	ExtraTags            types.Map    `tfsdk:"extra_tags"`
+	DisableEntityTagging types.Bool   `tfsdk:"disable_entity_tagging"`
+	ProvenanceTags       types.Bool   `tfsdk:"provenance_tags"`
+	WorkspaceLabel       types.String `tfsdk:"workspace_label"`
 }
 
 func (p *turbonomicProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -112,11 +173,134 @@ func (p *turbonomicProvider) Schema(ctx context.Context, req provider.SchemaRequ
 					"instance; use TURBO_ROLE to set with an environment variable",
 				Optional: true,
 			},
+			"password_file": schema.StringAttribute{
+				MarkdownDescription: "path of a file that contains the password for the username, such as a secret mounted by " +
+					"Vault Agent or Kubernetes; conflicts with password; use TURBO_PASSWORD_FILE to set with an environment variable",
+				Description: "path of a file that contains the password for the username, such as a secret mounted by " +
+					"Vault Agent or Kubernetes; conflicts with password; use TURBO_PASSWORD_FILE to set with an environment variable",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+				},
+			},
+			"client_secret_file": schema.StringAttribute{
+				MarkdownDescription: "path of a file that contains the OAuth 2.0 client secret, such as a secret mounted by " +
+					"Vault Agent or Kubernetes; conflicts with client_secret; use TURBO_CLIENT_SECRET_FILE to set with an environment variable",
+				Description: "path of a file that contains the OAuth 2.0 client secret, such as a secret mounted by " +
+					"Vault Agent or Kubernetes; conflicts with client_secret; use TURBO_CLIENT_SECRET_FILE to set with an environment variable",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_secret")),
+				},
+			},
+			"credential_process": schema.StringAttribute{
+				MarkdownDescription: "command that writes the credentials as JSON on its standard output, E.G: " +
+					"{\"Version\": 1, \"ClientId\": \"...\", \"ClientSecret\": \"...\", \"Role\": \"OBSERVER\"}; " +
+					"the command is only run when no username, password, client_id or client_secret is set otherwise; use TURBO_CREDENTIAL_PROCESS to set with an environment variable",
+				Description: "command that writes the credentials as JSON on its standard output, E.G: " +
+					"{\"Version\": 1, \"ClientId\": \"...\", \"ClientSecret\": \"...\", \"Role\": \"OBSERVER\"}; " +
+					"the command is only run when no username, password, client_id or client_secret is set otherwise; use TURBO_CREDENTIAL_PROCESS to set with an environment variable",
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "name of the profile of the Turbonomic config file, ~/.turbonomic/config or TURBO_CONFIG_FILE, " +
+					"whose values are used for the attributes that are not set in the configuration or the environment; " +
+					"use TURBO_PROFILE to set with an environment variable",
+				Description: "name of the profile of the Turbonomic config file, ~/.turbonomic/config or TURBO_CONFIG_FILE, " +
+					"whose values are used for the attributes that are not set in the configuration or the environment; " +
+					"use TURBO_PROFILE to set with an environment variable",
+				Optional: true,
+			},
+			"ca_file": schema.StringAttribute{
+				MarkdownDescription: "path of a PEM file of the certificate authorities that sign the certificate of the Turbonomic " +
+					"instance, only used by the requests of turbonomic_access_token; the Turbonomic API client and the connectivity check " +
+					"verify the certificate with the certificate authorities of the system; use TURBO_CA_FILE to set with an environment variable",
+				Description: "path of a PEM file of the certificate authorities that sign the certificate of the Turbonomic " +
+					"instance, only used by the requests of turbonomic_access_token; the Turbonomic API client and the connectivity check " +
+					"verify the certificate with the certificate authorities of the system; use TURBO_CA_FILE to set with an environment variable",
+				Optional: true,
+			},
 			"skipverify": schema.BoolAttribute{
 				MarkdownDescription: "boolean on whether to verify the SSL or TLS certificate for the hostname",
 				Description:         "boolean on whether to verify the SSL or TLS certificate for the hostname",
 				Optional:            true,
 			},
+			"allow_unreachable": schema.BoolAttribute{
+				MarkdownDescription: "boolean on whether to only warn when the Turbonomic instance is unreachable or rejects the credentials, " +
+					"the data sources then return their default values; use TURBO_ALLOW_UNREACHABLE to set with an environment variable",
+				Description: "boolean on whether to only warn when the Turbonomic instance is unreachable or rejects the credentials, " +
+					"the data sources then return their default values; use TURBO_ALLOW_UNREACHABLE to set with an environment variable",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "maximum number of requests that the data sources send to the Turbonomic instance at the same time; " +
+					"no limit by default; use TURBO_MAX_CONCURRENT_REQUESTS to set with an environment variable",
+				Description: "maximum number of requests that the data sources send to the Turbonomic instance at the same time; " +
+					"no limit by default; use TURBO_MAX_CONCURRENT_REQUESTS to set with an environment variable",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "maximum number of requests per second that the data sources send to the Turbonomic instance, " +
+					"E.G: 0.5 for a request every 2 seconds; no limit by default; use TURBO_REQUESTS_PER_SECOND to set with an environment variable",
+				Description: "maximum number of requests per second that the data sources send to the Turbonomic instance, " +
+					"E.G: 0.5 for a request every 2 seconds; no limit by default; use TURBO_REQUESTS_PER_SECOND to set with an environment variable",
+				Optional: true,
+			},
+			"debug_trace_dir": schema.StringAttribute{
+				MarkdownDescription: "directory where the provider writes every Turbonomic API exchange of a run as JSON lines, " +
+					"with the passwords, secrets and tokens masked, to troubleshoot the values returned by the data sources; " +
+					"use TURBO_DEBUG_TRACE_DIR to set with an environment variable",
+				Description: "directory where the provider writes every Turbonomic API exchange of a run as JSON lines, " +
+					"with the passwords, secrets and tokens masked, to troubleshoot the values returned by the data sources; " +
+					"use TURBO_DEBUG_TRACE_DIR to set with an environment variable",
+				Optional: true,
+			},
+			"tag_key": schema.StringAttribute{
+				MarkdownDescription: "key of the tag written on the Turbonomic entities read by the data sources, pass it to the get_tags " +
+					"function with its tag_key option; defaults to turbonomic_optimized_by; use TURBO_TAG_KEY to set with an environment variable",
+				Description: "key of the tag written on the Turbonomic entities read by the data sources, pass it to the get_tags " +
+					"function with its tag_key option; defaults to turbonomic_optimized_by; use TURBO_TAG_KEY to set with an environment variable",
+				Optional: true,
+			},
+			"tag_value": schema.StringAttribute{
+				MarkdownDescription: "value of the tag written on the Turbonomic entities read by the data sources, pass it to the get_tags " +
+					"function with its tag_value option; defaults to turbonomic-terraform-provider; use TURBO_TAG_VALUE to set with an environment variable",
+				Description: "value of the tag written on the Turbonomic entities read by the data sources, pass it to the get_tags " +
+					"function with its tag_value option; defaults to turbonomic-terraform-provider; use TURBO_TAG_VALUE to set with an environment variable",
+				Optional: true,
+			},
+			"extra_tags": schema.MapAttribute{
+				MarkdownDescription: "additional tags written on the Turbonomic entities read by the data sources; " +
+					"use TURBO_EXTRA_TAGS to set with an environment variable as a comma separated list of key=value pairs",
+				Description: "additional tags written on the Turbonomic entities read by the data sources; " +
+					"use TURBO_EXTRA_TAGS to set with an environment variable as a comma separated list of key=value pairs",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"disable_entity_tagging": schema.BoolAttribute{
+				MarkdownDescription: "boolean on whether to skip tagging the Turbonomic entities read by the data sources; " +
+					"use TURBO_DISABLE_ENTITY_TAGGING to set with an environment variable",
+				Description: "boolean on whether to skip tagging the Turbonomic entities read by the data sources; " +
+					"use TURBO_DISABLE_ENTITY_TAGGING to set with an environment variable",
+				Optional: true,
+			},
+			"provenance_tags": schema.BoolAttribute{
+				MarkdownDescription: "boolean on whether to tag the Turbonomic entities with the turbonomic_action_id and turbonomic_applied_at " +
+					"tags of the action whose values are returned by the data sources; use TURBO_PROVENANCE_TAGS to set with an environment variable",
+				Description: "boolean on whether to tag the Turbonomic entities with the turbonomic_action_id and turbonomic_applied_at " +
+					"tags of the action whose values are returned by the data sources; use TURBO_PROVENANCE_TAGS to set with an environment variable",
+				Optional: true,
+			},
+			"workspace_label": schema.StringAttribute{
+				MarkdownDescription: "workspace or run label written as the turbonomic_workspace tag on the Turbonomic entities read by " +
+					"the data sources; use TURBO_WORKSPACE_LABEL to set with an environment variable",
+				Description: "workspace or run label written as the turbonomic_workspace tag on the Turbonomic entities read by " +
+					"the data sources; use TURBO_WORKSPACE_LABEL to set with an environment variable",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -185,19 +369,38 @@ func (p *turbonomicProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	for attribute, value := range map[string]types.String{
+		"password_file":      config.PasswordFile,
+		"client_secret_file": config.ClientSecretFile,
+		"credential_process": config.CredentialProcess,
+		"profile":            config.Profile,
+		"ca_file":            config.CAFile,
+		"debug_trace_dir":    config.DebugTraceDir,
+	} {
+		if value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"unknown turbonomic api "+attribute,
+				"the provider cannot create the turbonomic api client; unknown configuration value for the turbonomic api "+attribute+". "+
+					"either target apply the source of the value first, or set the value statically in the configuration.",
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+	// with Terraform configuration value if set. The values
+	// that are still missing are read from the profile.
 
 	hostname := os.Getenv("TURBO_HOSTNAME")
 	username := os.Getenv("TURBO_USERNAME")
-	password := os.Getenv("TURBO_PASSWORD")
 	clientId := os.Getenv("TURBO_CLIENT_ID")
-	clientSecret := os.Getenv("TURBO_CLIENT_SECRET")
 	role := os.Getenv("TURBO_ROLE")
+	caFile := os.Getenv("TURBO_CA_FILE")
+	profileName := os.Getenv(ProfileEnvVar)
 	skipverify := false
 
 	if !config.Hostname.IsNull() {
@@ -208,18 +411,10 @@ func (p *turbonomicProvider) Configure(ctx context.Context, req provider.Configu
 		username = config.Username.ValueString()
 	}
 
-	if !config.Password.IsNull() {
-		password = config.Password.ValueString()
-	}
-
 	if !config.ClientId.IsNull() {
 		clientId = config.ClientId.ValueString()
 	}
 
-	if !config.ClientSecret.IsNull() {
-		clientSecret = config.ClientSecret.ValueString()
-	}
-
 	if !config.Role.IsNull() {
 		role = config.Role.ValueString()
 	}
@@ -228,20 +423,138 @@ func (p *turbonomicProvider) Configure(ctx context.Context, req provider.Configu
 		skipverify = config.Skipverify.ValueBool()
 	}
 
+	if !config.CAFile.IsNull() {
+		caFile = config.CAFile.ValueString()
+	}
+
+	allowUnreachable := false
+	if value := os.Getenv(AllowUnreachableEnvVar); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"invalid environment variable value -> "+AllowUnreachableEnvVar,
+				fmt.Sprintf("%s must be a boolean, got: %s", AllowUnreachableEnvVar, value),
+			)
+		}
+		allowUnreachable = parsed
+	}
+
+	if !config.AllowUnreachable.IsNull() && !config.AllowUnreachable.IsUnknown() {
+		allowUnreachable = config.AllowUnreachable.ValueBool()
+	}
+
+	if !config.Profile.IsNull() {
+		profileName = config.Profile.ValueString()
+	}
+
+	// The secrets can also be read from files, and the credential process sets
+	// the credentials when no other source does
+
+	password, errDiag := resolveSecret(config.Password, config.PasswordFile, "TURBO_PASSWORD", PasswordFileEnvVar, "password_file")
+	if errDiag != nil {
+		resp.Diagnostics.Append(errDiag)
+	}
+
+	clientSecret, errDiag := resolveSecret(config.ClientSecret, config.ClientSecretFile, "TURBO_CLIENT_SECRET", ClientSecretFileEnvVar, "client_secret_file")
+	if errDiag != nil {
+		resp.Diagnostics.Append(errDiag)
+	}
+
+	credentialProcess := os.Getenv(CredentialProcessEnvVar)
+	if !config.CredentialProcess.IsNull() {
+		credentialProcess = config.CredentialProcess.ValueString()
+	}
+
+	// profileNote names the profile in the validation diagnostics
+	profileNote := ""
+	if profileName != "" {
+		profile, err := loadProfileFromConfigFile(profileName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				fmt.Sprintf("invalid turbonomic profile %q", profileName),
+				err.Error(),
+			)
+			return
+		}
+		profileNote = fmt.Sprintf(" (the values missing from the configuration and the environment are read from profile %q of %s)",
+			profile.Name, profile.File)
+
+		profile.Fill(map[string]*string{
+			"hostname": &hostname,
+			"ca_file":  &caFile,
+		})
+
+		// The profile selects the authentication method only when the configuration and
+		// the environment do not, otherwise it only fills the credentials of that method
+		if username == "" && clientId == "" && credentialProcess == "" {
+			profile.FillCredentials(&username, &clientId, &credentialProcess)
+		}
+
+		if username != "" && password == "" {
+			password, err = profile.Secret("password", "password_file")
+		}
+		if err == nil && clientId != "" {
+			profile.Fill(map[string]*string{"role": &role})
+			if clientSecret == "" {
+				clientSecret, err = profile.Secret("client_secret", "client_secret_file")
+			}
+		}
+		if err == nil && config.Skipverify.IsNull() {
+			skipverify, err = profile.Bool("skipverify")
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				fmt.Sprintf("invalid turbonomic profile %q", profileName),
+				err.Error(),
+			)
+		}
+	}
+
+	// The credential process only sets the credentials when no other source sets them
+	if credentialProcess != "" {
+		if len(StringsWithValues(username, password, clientId, clientSecret)) != 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("credential_process"),
+				"turbonomic api credential_process not run",
+				"the credential process is ignored because the credentials are set by the configuration, the environment or the profile"+profileNote,
+			)
+		} else {
+			creds, err := runCredentialProcess(ctx, credentialProcess)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("credential_process"),
+					"unable to get turbonomic api credentials from credential_process",
+					err.Error(),
+				)
+			}
+
+			username, password, clientId, clientSecret = creds.Username, creds.Password, creds.ClientId, creds.ClientSecret
+			if creds.Role != "" {
+				role = creds.Role
+			}
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if hostname == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("hostname"),
 			"missing turbonomic api hostname",
 			"the provider cannot create the turbonomic api client; missing or empty value for the Turbonomic API hostname. "+
 				"set the hostname value in the configuration or use the TURBO_HOSTNAME environment variable. "+
-				"if either is already set, ensure the value is not empty.",
+				"if either is already set, ensure the value is not empty."+profileNote,
 		)
 	}
 
 	if len(StringsWithValues(username, clientId)) != 1 {
 		resp.Diagnostics.AddError(
 			"invalid attribute combination -> multiple authentication methods provided",
-			"exactly one of these attributes must be configured: [username, client_id]",
+			"exactly one of these attributes must be configured: [username, client_id]"+profileNote,
 		)
 	}
 
@@ -250,7 +563,7 @@ func (p *turbonomicProvider) Configure(ctx context.Context, req provider.Configu
 
 		resp.Diagnostics.AddError(
 			"invalid attribute combination -> username/password",
-			"these attributes must be configured together: [username, password]",
+			"these attributes must be configured together: [username, password]"+profileNote,
 		)
 	}
 
@@ -259,17 +572,14 @@ func (p *turbonomicProvider) Configure(ctx context.Context, req provider.Configu
 
 		resp.Diagnostics.AddError(
 			"invalid attribute combination -> oAuth",
-			"these attributes must be configured together: [client_id, client_secret, role]",
+			"these attributes must be configured together: [client_id, client_secret, role]"+profileNote,
 		)
 	}
 
 	if role != "" {
-		validRoles := []string{"ADMINISTRATOR", "SITE_ADMIN", "AUTOMATOR",
-			"DEPLOYER", "ADVISOR", "OBSERVER", "OPERATIONAL_OBSERVER", "SHARED_ADVISOR",
-			"SHARED_OBSERVER", "REPORT_EDITOR"}
 		validRolefmt, _ := json.Marshal(validRoles)
 		if !slices.Contains(validRoles, role) {
-			msg := fmt.Sprintf("attribute role value must be one of: %s, got: %s", validRolefmt, role)
+			msg := fmt.Sprintf("attribute role value must be one of: %s, got: %s", validRolefmt, role) + profileNote
 			resp.Diagnostics.AddError(
 				"invalid attribute value match -> unknown role",
 				msg,
@@ -277,6 +587,37 @@ func (p *turbonomicProvider) Configure(ctx context.Context, req provider.Configu
 		}
 	}
 
+	tagConfig, errDiag := getTagConfig(ctx, config)
+	if errDiag != nil {
+		resp.Diagnostics.Append(errDiag)
+	}
+
+	limiter, errDiag := getRequestLimiter(config)
+	if errDiag != nil {
+		resp.Diagnostics.Append(errDiag)
+	}
+
+	debugTraceDir := os.Getenv(DebugTraceDirEnvVar)
+	if !config.DebugTraceDir.IsNull() {
+		debugTraceDir = config.DebugTraceDir.ValueString()
+	}
+
+	var recorder *traceRecorder
+	if debugTraceDir != "" {
+		var err error
+		recorder, err = openTraceRecorder(debugTraceDir)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("debug_trace_dir"), "invalid turbonomic debug_trace_dir", err.Error())
+		} else {
+			tflog.Info(ctx, "writing the turbonomic api exchanges to "+recorder.Name())
+		}
+	}
+
+	// ca_file is only read by turbonomic_access_token, check it before the clients log in
+	if _, err := newHTTPClient(skipverify, caFile); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ca_file"), "invalid turbonomic api ca_file", err.Error())
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -311,24 +652,123 @@ func (p *turbonomicProvider) Configure(ctx context.Context, req provider.Configu
 	}
 
 	goClientCtx := tflog.NewSubsystem(ctx, "turbo-go-client", tflog.WithLevel(hclog.Debug))
-	logAdapter := logger.TfLogAdapter{}
+	// The messages of the client go through the log/slog handler, which masks the secrets
+	logAdapter := logger.SlogAdapter{Logger: slog.New(logger.NewTfLogHandler(goClientCtx))}
 
+	// The client logs in when it is created
+	start := time.Now()
 	client, err := turboclient.NewClient(
 		&newClientOpts,
 		turboLogging.WithContext(goClientCtx),
 		turboLogging.WithLogger(&logAdapter))
+	recorder.recordOperation("NewClient", newClientOpts, nil, err, start)
 
+	var serverInfo ServerInfo
+	var session *apiSession
 	if err != nil {
-		resp.Diagnostics.AddWarning(
-			"unable to create turbonomic api client",
-			"an unexpected error occurred when creating the turbonomic api client. "+
-				"if the error is not clear, please contact the provider developers.\n\n"+
-				"turbonomic client error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(connectivityDiagnostic(
+			fmt.Errorf("unable to create the turbonomic api client: %w", err), allowUnreachable))
+	} else {
+		// Check the connectivity and the credentials so that the data sources
+		// do not silently return their default values. The session of the check keeps
+		// its login for the requests that the turbonomic client does not support, and
+		// verifies the certificate like the turbonomic client, with the certificate
+		// authorities of the system, as ca_file only applies to turbonomic_access_token
+
+		httpClient, err := newHTTPClient(skipverify, "")
+		if err != nil {
+			resp.Diagnostics.AddError("unable to create the turbonomic api connectivity check client", err.Error())
+			return
+		}
+		recorder.wrapClient(httpClient)
+
+		creds := connectivityCredentials{
+			Username: username,
+			Password: password,
+			OAuth:    OAuthConfig{ClientId: clientId, ClientSecret: clientSecret, Role: role},
+		}
+		session = newAPISession(httpClient, hostname, creds)
+
+		version, err := session.checkConnectivity(ctx)
+		if err != nil {
+			resp.Diagnostics.Append(connectivityDiagnostic(err, allowUnreachable))
+		} else if serverInfo, err = newServerInfo(version); err != nil {
+			resp.Diagnostics.AddWarning("unable to detect the turbonomic version",
+				err.Error()+"; the data sources assume that every feature is supported")
+		} else {
+			tflog.Info(ctx, fmt.Sprintf("turbonomic version %s detected", serverInfo.Version))
+		}
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData := &TurbonomicProviderData{
+		Client:        client,
+		TagConfig:     tagConfig,
+		Hostname:      hostname,
+		Skipverify:    skipverify,
+		CAFile:        caFile,
+		ServerInfo:    serverInfo,
+		TraceRecorder: recorder,
+		// The data sources of the configuration share the limits, the trace file and the session of the requests of its client
+		Requests: &clientRequests{limiter: limiter, recorder: recorder, session: session},
+		OAuth: OAuthConfig{
+			ClientId:     clientId,
+			ClientSecret: clientSecret,
+			Role:         role,
+		},
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+}
+
+// getTagConfig returns the entity tagging configuration, the provider configuration
+// values override the environment variables
+func getTagConfig(ctx context.Context, config turbonomicProviderModel) (TagConfig, diag.Diagnostic) {
+	tagConfig, err := TagConfigFromEnv()
+	if err != nil {
+		return tagConfig, diag.NewErrorDiagnostic("invalid entity tagging environment variable", err.Error())
+	}
+
+	if !config.TagKey.IsNull() && !config.TagKey.IsUnknown() {
+		tagConfig.Key = config.TagKey.ValueString()
+	}
+
+	if !config.TagValue.IsNull() && !config.TagValue.IsUnknown() {
+		tagConfig.Value = config.TagValue.ValueString()
+	}
+
+	if !config.ExtraTags.IsNull() && !config.ExtraTags.IsUnknown() {
+		var extraTags map[string]string
+		if diags := config.ExtraTags.ElementsAs(ctx, &extraTags, false); diags.HasError() {
+			return tagConfig, diag.NewAttributeErrorDiagnostic(path.Root("extra_tags"),
+				"invalid attribute value -> extra_tags", "extra_tags must be a map of strings")
+		}
+		tagConfig.ExtraTags = extraTags
+	}
+
+	if !config.DisableEntityTagging.IsNull() && !config.DisableEntityTagging.IsUnknown() {
+		tagConfig.Disabled = config.DisableEntityTagging.ValueBool()
+	}
+
+	if !config.ProvenanceTags.IsNull() && !config.ProvenanceTags.IsUnknown() {
+		tagConfig.Provenance = config.ProvenanceTags.ValueBool()
+	}
+
+	if !config.WorkspaceLabel.IsNull() && !config.WorkspaceLabel.IsUnknown() {
+		tagConfig.Workspace = config.WorkspaceLabel.ValueString()
+	}
+
+	if tagConfig.Key == "" {
+		return tagConfig, diag.NewAttributeErrorDiagnostic(path.Root("tag_key"),
+			"invalid attribute value -> empty tag_key",
+			"the tag key must not be empty. set the tag_key value in the configuration or use the TURBO_TAG_KEY environment variable.")
+	}
+
+	return tagConfig, nil
 }
 
 func StringsWithValues(ss ...string) []string {
@@ -346,6 +786,12 @@ func (p *turbonomicProvider) Resources(ctx context.Context) []func() resource.Re
 	return nil
 }
 
+func (p *turbonomicProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
+	}
+}
+
 func (p *turbonomicProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewCloudEntityRecommendationDataSource,
@@ -359,6 +805,11 @@ func (p *turbonomicProvider) DataSources(ctx context.Context) []func() datasourc
 		NewAzurermWindowsVirtualMachineDataSource,
 		NewGoogleComputeInstanceDataSource,
 		NewAzurermMssqlDatabaseDataSource,
+		NewEntitiesDataSource,
+		NewEntityDataSource,
+		NewEntityStatsDataSource,
+		NewServerInfoDataSource,
+		NewComplianceReportDataSource,
 	}
 }
 
@@ -374,5 +825,12 @@ func New(version, typeName string) func() provider.Provider {
 func (p *turbonomicProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
 		NewGetTagFunction,
+		NewGetTagsFunction,
+		NewConvertUnitsFunction,
+		NewTierToSkuFunction,
+		NewSkuToTierFunction,
+		NewChooseFunction,
+		NewCompareInstanceTypesFunction,
+		NewInstanceTypeFamilyFunction,
 	}
 }
@@ -96,7 +96,7 @@ func TestProviderOAuth(t *testing.T) {
 			Method:       http.MethodPost,
 			Path:         "/oauth2/token",
 			ResponseCode: http.StatusOK,
-			ResponseBody: `{"status":"ok"}`,
+			ResponseBody: accessTokenRespTestData,
 		},
 	}, LoginAndTagRoutes(t)...))
 	defer mockServer.Close()
@@ -259,6 +259,7 @@ func TestProviderTurboApiNotWorking(t *testing.T) {
 		username = "testuser"
 		password = "password"
 		skipverify = true
+		allow_unreachable = true
 	}
 	`
 	providerConfig := fmt.Sprintf(testConfig, "invalid-hostname")
@@ -283,3 +284,230 @@ func TestProviderTurboApiNotWorking(t *testing.T) {
 		})
 	})
 }
+
+func TestProviderCredentialSources(t *testing.T) {
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			ResponseBody: loadTestFile(t, cloudTestDataBaseDir, searchRespTestData),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/entities/{id}/actions",
+			ResponseBody: loadTestFile(t, cloudTestDataBaseDir, validVmActionRespTestData),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodPost,
+			Path:         "/oauth2/token",
+			ResponseCode: http.StatusOK,
+			ResponseBody: accessTokenRespTestData,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	hostname := strings.TrimPrefix(mockServer.URL, "https://")
+	passwordFile := writeSecretFile(t, "password\n")
+
+	t.Run("password file", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`provider "turbonomic" {
+						hostname = "%s"
+						username = "testuser"
+						password_file = "%s"
+						skipverify = true
+					}
+					`, hostname, passwordFile) + resourceConfig,
+				},
+			},
+		})
+	})
+
+	t.Run("credential process", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`provider "turbonomic" {
+						hostname = "%s"
+						credential_process = "echo '{\"Version\": 1, \"ClientId\": \"12345\", \"ClientSecret\": \"201918171615141312\", \"Role\": \"OBSERVER\"}'"
+						skipverify = true
+					}
+					`, hostname) + resourceConfig,
+				},
+			},
+		})
+	})
+
+	t.Run("credential process with other credentials", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`provider "turbonomic" {
+						hostname = "%s"
+						username = "testuser"
+						password_file = "%s"
+						credential_process = "exit 1"
+						skipverify = true
+					}
+					`, hostname, passwordFile) + resourceConfig,
+				},
+			},
+		})
+	})
+
+	t.Run("password and password file", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`provider "turbonomic" {
+						hostname = "%s"
+						username = "testuser"
+						password = "password"
+						password_file = "%s"
+						skipverify = true
+					}
+					`, hostname, passwordFile) + resourceConfig,
+					ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+				},
+			},
+		})
+	})
+}
+
+func TestProviderProfile(t *testing.T) {
+	mockServer := mockTurboServer(t, append([]MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/search",
+			ResponseBody: loadTestFile(t, cloudTestDataBaseDir, searchRespTestData),
+			ResponseCode: http.StatusOK,
+		},
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/entities/{id}/actions",
+			ResponseBody: loadTestFile(t, cloudTestDataBaseDir, validVmActionRespTestData),
+			ResponseCode: http.StatusOK,
+		},
+	}, LoginAndTagRoutes(t)...))
+	defer mockServer.Close()
+
+	t.Setenv(ConfigFileEnvVar, writeConfigFile(t, fmt.Sprintf(`[profile test]
+		hostname = %s
+		username = testuser
+		password = password
+		skipverify = true
+
+		[profile invalid]
+		hostname = %s
+		auth_method = oauth
+		`, strings.TrimPrefix(mockServer.URL, "https://"), strings.TrimPrefix(mockServer.URL, "https://"))))
+
+	t.Run("profile", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: `provider "turbonomic" {
+						profile = "test"
+					}
+					` + resourceConfig,
+				},
+			},
+		})
+	})
+
+	t.Run("invalid profile", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: `provider "turbonomic" {
+						profile = "invalid"
+					}
+					` + resourceConfig,
+					ExpectError: regexp.MustCompile(`invalid turbonomic profile "invalid"`),
+				},
+			},
+		})
+	})
+
+	t.Run("missing profile", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: `provider "turbonomic" {
+						profile = "staging"
+					}
+					` + resourceConfig,
+					ExpectError: regexp.MustCompile(`profile "staging" not found`),
+				},
+			},
+		})
+	})
+}
+
+func TestProviderFailFast(t *testing.T) {
+	mockServer := mockTurboServer(t, []MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/login",
+			ResponseCode: http.StatusUnauthorized,
+			ResponseBody: `{"type":"Unauthorized","message":"Bad credentials"}`,
+		},
+	})
+	defer mockServer.Close()
+	hostname := strings.TrimPrefix(mockServer.URL, "https://")
+
+	testConfig := `provider "turbonomic" {
+		hostname = "%s"
+		username = "testuser"
+		password = "password"
+		skipverify = %t
+	}
+	`
+
+	tests := []struct {
+		name        string
+		config      string
+		expectError string
+	}{
+		{
+			name:        "bad credentials",
+			config:      fmt.Sprintf(testConfig, hostname, true),
+			expectError: BadCredentialsSummary,
+		},
+		{
+			name:        "unreachable host",
+			config:      fmt.Sprintf(testConfig, "invalid-hostname", true),
+			expectError: UnreachableSummary,
+		},
+		{
+			name:        "tls failure",
+			config:      fmt.Sprintf(testConfig, hostname, false),
+			expectError: TLSFailureSummary,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      tc.config + resourceConfig,
+						ExpectError: regexp.MustCompile(tc.expectError),
+					},
+				},
+			})
+		})
+	}
+}
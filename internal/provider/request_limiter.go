@@ -0,0 +1,145 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	// Environment variables of the request limits
+	MaxConcurrentRequestsEnvVar = "TURBO_MAX_CONCURRENT_REQUESTS"
+	RequestsPerSecondEnvVar     = "TURBO_REQUESTS_PER_SECOND"
+)
+
+// requestLimiter limits the number of requests sent to Turbonomic at the same time and
+// the rate at which they are sent, a zero limit is no limit
+type requestLimiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+/*
+newRequestLimiter creates a limiter of the requests sent to Turbonomic.
+
+Parameters:
+  - maxConcurrent: The maximum number of requests in flight, 0 for no limit
+  - perSecond: The maximum number of requests started per second, 0 for no limit
+
+Returns:
+  - *requestLimiter: The limiter, nil when neither limit is set
+*/
+func newRequestLimiter(maxConcurrent int64, perSecond float64) *requestLimiter {
+	if maxConcurrent <= 0 && perSecond <= 0 {
+		return nil
+	}
+
+	limiter := &requestLimiter{}
+	if maxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return limiter
+}
+
+// getRequestLimiter returns the limiter of the requests sent by the data sources, the provider
+// configuration values override the environment variables
+func getRequestLimiter(config turbonomicProviderModel) (*requestLimiter, diag.Diagnostic) {
+	var maxConcurrent int64
+	if value := os.Getenv(MaxConcurrentRequestsEnvVar); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			return nil, diag.NewErrorDiagnostic(
+				"invalid environment variable value -> "+MaxConcurrentRequestsEnvVar,
+				fmt.Sprintf("%s must be an integer greater than 0, got: %s", MaxConcurrentRequestsEnvVar, value),
+			)
+		}
+		maxConcurrent = parsed
+	}
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		maxConcurrent = config.MaxConcurrentRequests.ValueInt64()
+	}
+
+	var perSecond float64
+	if value := os.Getenv(RequestsPerSecondEnvVar); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 {
+			return nil, diag.NewErrorDiagnostic(
+				"invalid environment variable value -> "+RequestsPerSecondEnvVar,
+				fmt.Sprintf("%s must be a number greater than 0, got: %s", RequestsPerSecondEnvVar, value),
+			)
+		}
+		perSecond = parsed
+	}
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		perSecond = config.RequestsPerSecond.ValueFloat64()
+		if perSecond <= 0 {
+			return nil, diag.NewAttributeErrorDiagnostic(
+				path.Root("requests_per_second"),
+				"invalid attribute value -> requests_per_second",
+				fmt.Sprintf("requests_per_second must be greater than 0, got: %v", perSecond),
+			)
+		}
+	}
+
+	return newRequestLimiter(maxConcurrent, perSecond), nil
+}
+
+// wait blocks until the request can be sent and returns the function that releases its slot, or the
+// error of the context when it is done first
+func (l *requestLimiter) wait(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.interval > 0 {
+		// the requests are spaced by the interval, each one reserves the next start time
+		l.mu.Lock()
+		now := time.Now()
+		start := l.next
+		if start.Before(now) {
+			start = now
+		}
+		l.next = start.Add(l.interval)
+		l.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(start))
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+// Tests that the helpers do not send more requests at the same time than the concurrency limit
+func TestRequestLimiterMaxConcurrent(t *testing.T) {
+	mockClient := new(MockT8cClient)
+	client := &requestClient{T8cClient: mockClient, ctx: context.Background(), requests: &clientRequests{limiter: newRequestLimiter(2, 0)}}
+
+	var inFlight, maxInFlight atomic.Int32
+	mockClient.On("GetActionsByUUID", mock.Anything).Run(func(args mock.Arguments) {
+		current := inFlight.Add(1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+	}).Return(turboclient.ActionResults{}, nil)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errDiag := GetActions(client, WithEntityUuid("123"), WithActionTypes([]string{"SCALE"}))
+			assert.Nil(t, errDiag)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), maxInFlight.Load())
+	mockClient.AssertNumberOfCalls(t, "GetActionsByUUID", 8)
+}
+
+// Tests that the helpers space the requests by the rate limit
+func TestRequestLimiterRequestsPerSecond(t *testing.T) {
+	mockClient := new(MockT8cClient)
+	client := &requestClient{T8cClient: mockClient, ctx: context.Background(), requests: &clientRequests{limiter: newRequestLimiter(0, 50)}}
+
+	mockClient.On("GetEntity", mock.Anything).Return(&turboclient.EntityResults{UUID: "123"}, nil)
+	mockClient.On("GetEntityTags", mock.Anything).Return([]turboclient.Tag{}, nil)
+
+	start := time.Now()
+	for range 3 {
+		_, _, errDiag := GetEntityDetails(client, "123")
+		assert.Nil(t, errDiag)
+	}
+
+	// 6 requests at 50 per second start over at least 5 intervals of 20ms
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+// Tests that the requests of a client without a limiter are not limited
+func TestRequestLimiterNotConfigured(t *testing.T) {
+	assert.Nil(t, newRequestLimiter(0, 0))
+
+	var limiter *requestLimiter
+	release, err := limiter.wait(context.Background())
+	assert.NoError(t, err)
+	release()
+
+	// the data sources of a provider configuration that failed to create the client get no client
+	var requests *clientRequests
+	assert.Nil(t, requests.client(context.Background(), nil))
+
+	mockClient := new(MockT8cClient)
+	mockClient.On("GetEntity", mock.Anything).Return(&turboclient.EntityResults{UUID: "123"}, nil)
+	mockClient.On("GetEntityTags", mock.Anything).Return([]turboclient.Tag{}, nil)
+	client := &requestClient{T8cClient: mockClient, ctx: context.Background(), requests: &clientRequests{}}
+	_, _, errDiag := GetEntityDetails(client, "123")
+	assert.Nil(t, errDiag)
+}
+
+// Tests that a request stops waiting for the request limits once the context of the read is done
+func TestRequestLimiterContextDone(t *testing.T) {
+	limiter := newRequestLimiter(1, 0.5)
+	release, err := limiter.wait(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = limiter.wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the slot is free again but the next start time is 2 seconds away
+	release()
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = limiter.wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, limiter.slots, 0)
+
+	mockClient := new(MockT8cClient)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := &requestClient{T8cClient: mockClient, ctx: canceled, requests: &clientRequests{limiter: limiter}}
+	_, errDiag := GetActions(client, WithEntityUuid("123"), WithActionTypes([]string{"SCALE"}))
+	assert.NotNil(t, errDiag)
+	assert.Contains(t, errDiag.Detail(), "GetActionsByUUID canceled while waiting for the request limits")
+	mockClient.AssertNotCalled(t, "GetActionsByUUID", mock.Anything)
+}
+
+// Tests the limits read from the provider configuration and the environment variables
+func TestGetRequestLimiter(t *testing.T) {
+	tests := []struct {
+		name                  string
+		maxConcurrentRequests types.Int64
+		requestsPerSecond     types.Float64
+		env                   map[string]string
+		expected              *requestLimiter
+		expectedError         string
+	}{
+		{
+			name:                  "no limits",
+			maxConcurrentRequests: types.Int64Null(),
+			requestsPerSecond:     types.Float64Null(),
+		},
+		{
+			name:                  "configuration",
+			maxConcurrentRequests: types.Int64Value(4),
+			requestsPerSecond:     types.Float64Value(0.5),
+			expected:              &requestLimiter{slots: make(chan struct{}, 4), interval: 2 * time.Second},
+		},
+		{
+			name:                  "configuration over environment",
+			maxConcurrentRequests: types.Int64Value(4),
+			requestsPerSecond:     types.Float64Null(),
+			env:                   map[string]string{MaxConcurrentRequestsEnvVar: "8", RequestsPerSecondEnvVar: "10"},
+			expected:              &requestLimiter{slots: make(chan struct{}, 4), interval: 100 * time.Millisecond},
+		},
+		{
+			name:                  "invalid max concurrent requests environment variable",
+			maxConcurrentRequests: types.Int64Null(),
+			requestsPerSecond:     types.Float64Null(),
+			env:                   map[string]string{MaxConcurrentRequestsEnvVar: "0"},
+			expectedError:         "TURBO_MAX_CONCURRENT_REQUESTS must be an integer greater than 0, got: 0",
+		},
+		{
+			name:                  "invalid requests per second environment variable",
+			maxConcurrentRequests: types.Int64Null(),
+			requestsPerSecond:     types.Float64Null(),
+			env:                   map[string]string{RequestsPerSecondEnvVar: "fast"},
+			expectedError:         "TURBO_REQUESTS_PER_SECOND must be a number greater than 0, got: fast",
+		},
+		{
+			name:                  "invalid requests per second",
+			maxConcurrentRequests: types.Int64Null(),
+			requestsPerSecond:     types.Float64Value(0),
+			expectedError:         "requests_per_second must be greater than 0, got: 0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(MaxConcurrentRequestsEnvVar, "")
+			t.Setenv(RequestsPerSecondEnvVar, "")
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			limiter, errDiag := getRequestLimiter(turbonomicProviderModel{
+				MaxConcurrentRequests: tc.maxConcurrentRequests,
+				RequestsPerSecond:     tc.requestsPerSecond,
+			})
+			if tc.expectedError != "" {
+				assert.NotNil(t, errDiag)
+				assert.Equal(t, tc.expectedError, errDiag.Detail())
+				return
+			}
+			assert.Nil(t, errDiag)
+			if tc.expected == nil {
+				assert.Nil(t, limiter)
+				return
+			}
+			assert.Equal(t, cap(tc.expected.slots), cap(limiter.slots))
+			assert.Equal(t, tc.expected.interval, limiter.interval)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// serverCapability is a feature of the Turbonomic API with the first release that supports it
+type serverCapability struct {
+	name        string
+	description string
+	major       int
+	minor       int
+}
+
+// serverCapabilities are the features of the Turbonomic API that the data sources rely on, each one
+// with a comment citing the release notes of the first release that supports it. The execution
+// schedules and the actionStateDescription of the actions are not listed until their first release
+// is sourced, the actions of the releases without them leave these fields empty, which
+// canExecuteAction treats as executable.
+var serverCapabilities = []serverCapability{}
+
+// actionExecutionCapabilities are the capabilities used to check whether an action can be executed
+var actionExecutionCapabilities = []string{}
+
+var serverVersionRegex = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ServerInfo is the version of the Turbonomic instance detected when the provider is configured
+type ServerInfo struct {
+	Version     string
+	VersionInfo string
+	Major       int
+	Minor       int
+	Patch       int
+	// Known is false when the version could not be read, E.G: when allow_unreachable is set
+	Known bool
+}
+
+// newServerInfo parses the response of the Turbonomic version endpoint, such as 8.14.3
+func newServerInfo(version serverVersion) (ServerInfo, error) {
+	info := ServerInfo{Version: version.Version, VersionInfo: version.VersionInfo}
+
+	matches := serverVersionRegex.FindStringSubmatch(version.Version)
+	if matches == nil {
+		return info, fmt.Errorf("unable to parse the turbonomic version %q", version.Version)
+	}
+	info.Major, _ = strconv.Atoi(matches[1])
+	info.Minor, _ = strconv.Atoi(matches[2])
+	if matches[3] != "" {
+		info.Patch, _ = strconv.Atoi(matches[3])
+	}
+	info.Known = true
+
+	return info, nil
+}
+
+// Supports returns whether the Turbonomic instance supports a capability, an unknown version
+// is assumed to support every capability
+func (s ServerInfo) Supports(capability string) bool {
+	if !s.Known {
+		return true
+	}
+
+	index := slices.IndexFunc(serverCapabilities, func(c serverCapability) bool { return c.name == capability })
+	if index == -1 {
+		return false
+	}
+	c := serverCapabilities[index]
+	return s.Major > c.major || (s.Major == c.major && s.Minor >= c.minor)
+}
+
+// Capabilities returns whether the Turbonomic instance supports each capability
+func (s ServerInfo) Capabilities() map[string]bool {
+	capabilities := make(map[string]bool, len(serverCapabilities))
+	for _, c := range serverCapabilities {
+		capabilities[c.name] = s.Supports(c.name)
+	}
+	return capabilities
+}
+
+/*
+capabilityDiagnostics returns a warning for each capability that a data source relies on and
+that the Turbonomic instance does not support.
+
+Parameters:
+  - serverInfo: The version of the Turbonomic instance
+  - capabilities: The capabilities that the data source relies on
+
+Returns:
+  - diag.Diagnostics: A warning diagnostic for each unsupported capability
+*/
+func capabilityDiagnostics(serverInfo ServerInfo, capabilities ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, c := range serverCapabilities {
+		if !slices.Contains(capabilities, c.name) || serverInfo.Supports(c.name) {
+			continue
+		}
+		diags.AddWarning(
+			"unsupported turbonomic feature -> "+c.name,
+			fmt.Sprintf("turbonomic %s does not support %s, which require turbonomic %d.%d or later; "+
+				"the recommendations are returned without checking them", serverInfo.Version, c.description, c.major, c.minor),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,126 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &serverInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &serverInfoDataSource{}
+)
+
+func NewServerInfoDataSource() datasource.DataSource {
+	return &serverInfoDataSource{}
+}
+
+// serverInfoDataSource defines the data source implementation.
+type serverInfoDataSource struct {
+	hostname   string
+	serverInfo ServerInfo
+}
+
+// ServerInfoModel describes the data source data model.
+type ServerInfoModel struct {
+	Hostname     types.String `tfsdk:"hostname"`
+	Version      types.String `tfsdk:"version"`
+	VersionInfo  types.String `tfsdk:"version_info"`
+	Major        types.Int64  `tfsdk:"major"`
+	Minor        types.Int64  `tfsdk:"minor"`
+	Patch        types.Int64  `tfsdk:"patch"`
+	Capabilities types.Map    `tfsdk:"capabilities"`
+}
+
+func (d *serverInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_info"
+}
+
+func (d *serverInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The following example demonstrates the syntax for the `turbonomic_server_info` data source. " +
+			"This can be used to read the version of the Turbonomic instance detected by the provider and the features it supports",
+		Attributes: map[string]schema.Attribute{
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "hostname of the Turbonomic instance",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "version of the Turbonomic instance, E.G: 8.14.3; null when the version could not be detected",
+				Computed:            true,
+			},
+			"version_info": schema.StringAttribute{
+				MarkdownDescription: "detailed version of the Turbonomic instance with its build",
+				Computed:            true,
+			},
+			"major": schema.Int64Attribute{
+				MarkdownDescription: "major version of the Turbonomic instance",
+				Computed:            true,
+			},
+			"minor": schema.Int64Attribute{
+				MarkdownDescription: "minor version of the Turbonomic instance",
+				Computed:            true,
+			},
+			"patch": schema.Int64Attribute{
+				MarkdownDescription: "patch version of the Turbonomic instance",
+				Computed:            true,
+			},
+			"capabilities": schema.MapAttribute{
+				MarkdownDescription: "whether the Turbonomic instance supports each feature that the data sources rely on, " +
+					"and that are gated by version; every feature is assumed to be supported when the version could not be detected",
+				ElementType: types.BoolType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *serverInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TurbonomicProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data-source configure type",
+			fmt.Sprintf("expected: *TurbonomicProviderData, got: %T. please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.hostname = providerData.Hostname
+	d.serverInfo = providerData.ServerInfo
+}
+
+func (d *serverInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	state := ServerInfoModel{
+		Hostname:    types.StringValue(d.hostname),
+		Version:     types.StringNull(),
+		VersionInfo: types.StringNull(),
+		Major:       types.Int64Null(),
+		Minor:       types.Int64Null(),
+		Patch:       types.Int64Null(),
+	}
+
+	if d.serverInfo.Known {
+		state.Version = types.StringValue(d.serverInfo.Version)
+		state.VersionInfo = types.StringValue(d.serverInfo.VersionInfo)
+		state.Major = types.Int64Value(int64(d.serverInfo.Major))
+		state.Minor = types.Int64Value(int64(d.serverInfo.Minor))
+		state.Patch = types.Int64Value(int64(d.serverInfo.Patch))
+	}
+
+	capabilities, diags := types.MapValueFrom(ctx, types.BoolType, d.serverInfo.Capabilities())
+	resp.Diagnostics.Append(diags...)
+	state.Capabilities = capabilities
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestServerInfoDataSource(t *testing.T) {
+	// the provider logs in with the turbonomic client and checks the connectivity in each
+	// terraform command of the step
+	server := fixtureTurboServer(t, "server_info_data_source").Repeat()
+	hostname := server.Hostname()
+	dsName := "data.turbonomic_server_info.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`provider "turbonomic" {
+					hostname = "%s"
+					username = "%s"
+					password = "password"
+					skipverify = true
+				}
+
+				data "turbonomic_server_info" "test" {}
+				`, hostname, fixtureUsername),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dsName, "hostname", hostname),
+					resource.TestCheckResourceAttr(dsName, "version", "7.22.4"),
+					resource.TestCheckResourceAttr(dsName, "major", "7"),
+					resource.TestCheckResourceAttr(dsName, "minor", "22"),
+					resource.TestCheckResourceAttr(dsName, "patch", "4"),
+					resource.TestCheckResourceAttr(dsName, "capabilities.%", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests the parsing of the Turbonomic version
+func TestNewServerInfo(t *testing.T) {
+	tests := []struct {
+		version       string
+		expected      ServerInfo
+		expectedError string
+	}{
+		{
+			version:  "8.14.3",
+			expected: ServerInfo{Version: "8.14.3", Major: 8, Minor: 14, Patch: 3, Known: true},
+		},
+		{
+			version:  "8.2",
+			expected: ServerInfo{Version: "8.2", Major: 8, Minor: 2, Known: true},
+		},
+		{
+			version:  "7.22.10-SNAPSHOT",
+			expected: ServerInfo{Version: "7.22.10-SNAPSHOT", Major: 7, Minor: 22, Patch: 10, Known: true},
+		},
+		{
+			version:       "",
+			expected:      ServerInfo{},
+			expectedError: `unable to parse the turbonomic version ""`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.version, func(t *testing.T) {
+			info, err := newServerInfo(serverVersion{Version: tc.version})
+			if len(tc.expectedError) > 0 {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.False(t, info.Known)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, info)
+		})
+	}
+}
+
+// setTestCapabilities replaces the capabilities of the Turbonomic releases for the duration of a test
+func setTestCapabilities(t *testing.T, capabilities ...serverCapability) {
+	saved := serverCapabilities
+	serverCapabilities = capabilities
+	t.Cleanup(func() { serverCapabilities = saved })
+}
+
+// Tests the capabilities supported by each Turbonomic version
+func TestServerInfoCapabilities(t *testing.T) {
+	assert.Empty(t, ServerInfo{Version: "8.14.3", Major: 8, Minor: 14, Patch: 3, Known: true}.Capabilities())
+
+	setTestCapabilities(t,
+		serverCapability{"old_feature", "an old feature", 7, 22},
+		serverCapability{"new_feature", "a new feature", 8, 2},
+	)
+
+	tests := []struct {
+		name     string
+		info     ServerInfo
+		expected map[string]bool
+	}{
+		{
+			name:     "unknown version",
+			info:     ServerInfo{},
+			expected: map[string]bool{"old_feature": true, "new_feature": true},
+		},
+		{
+			name:     "7.21",
+			info:     ServerInfo{Version: "7.21.0", Major: 7, Minor: 21, Known: true},
+			expected: map[string]bool{"old_feature": false, "new_feature": false},
+		},
+		{
+			name:     "7.22",
+			info:     ServerInfo{Version: "7.22.0", Major: 7, Minor: 22, Known: true},
+			expected: map[string]bool{"old_feature": true, "new_feature": false},
+		},
+		{
+			name:     "8.14",
+			info:     ServerInfo{Version: "8.14.3", Major: 8, Minor: 14, Patch: 3, Known: true},
+			expected: map[string]bool{"old_feature": true, "new_feature": true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.info.Capabilities())
+		})
+	}
+
+	assert.False(t, ServerInfo{Known: true, Major: 9}.Supports("unknown_capability"))
+}
+
+// Tests the warnings of the capabilities that the Turbonomic instance does not support
+func TestCapabilityDiagnostics(t *testing.T) {
+	info := ServerInfo{Version: "7.22.0", Major: 7, Minor: 22, Known: true}
+	assert.Empty(t, capabilityDiagnostics(info, actionExecutionCapabilities...))
+
+	setTestCapabilities(t,
+		serverCapability{"old_feature", "an old feature", 7, 22},
+		serverCapability{"new_feature", "a new feature", 8, 2},
+	)
+
+	diags := capabilityDiagnostics(info, "old_feature", "new_feature")
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "unsupported turbonomic feature -> new_feature", diags[0].Summary())
+	assert.Equal(t, "turbonomic 7.22.0 does not support a new feature, which require turbonomic 8.2 or later; "+
+		"the recommendations are returned without checking them", diags[0].Detail())
+	assert.False(t, diags.HasError())
+
+	assert.Empty(t, capabilityDiagnostics(info, "old_feature"))
+	assert.Empty(t, capabilityDiagnostics(ServerInfo{}, "old_feature", "new_feature"))
+}
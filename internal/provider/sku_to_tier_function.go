@@ -0,0 +1,73 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &SkuToTierFunction{}
+
+type SkuToTierFunction struct{}
+
+func NewSkuToTierFunction() function.Function {
+	return &SkuToTierFunction{}
+}
+
+func (f *SkuToTierFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sku_to_tier"
+}
+
+func (f *SkuToTierFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Map a cloud SKU to a Turbonomic tier",
+		Description: "Returns the Turbonomic tier display name of a case insensitive cloud provider SKU, the inverse of tier_to_sku, E.G: pd-balanced is Balanced Persistent Disk",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cloud",
+				Description: "case insensitive cloud provider, one of aws, azure or gcp",
+			},
+			function.StringParameter{
+				Name:        "resource_kind",
+				Description: "case insensitive kind of the resource, only disk is supported as the compute and database tiers are not mapped",
+			},
+			function.StringParameter{
+				Name:        "sku",
+				Description: "SKU of the cloud provider, E.G: pd-balanced",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Returns the Turbonomic display name of the SKU
+func (f *SkuToTierFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cloud, resourceKind, sku string
+
+	resp.Error = req.Arguments.Get(ctx, &cloud, &resourceKind, &sku)
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := skuToTier(cloud, resourceKind, sku)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
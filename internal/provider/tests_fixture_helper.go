@@ -0,0 +1,424 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/terraform-provider-turbonomic/pkg/logger"
+)
+
+const (
+	// RecordFixturesEnvVar records the fixtures from the Turbonomic instance of TURBO_HOSTNAME,
+	// TURBO_USERNAME and TURBO_PASSWORD instead of replaying them
+	RecordFixturesEnvVar = "TURBO_RECORD_FIXTURES"
+
+	fixturesTestDataBaseDir = "fixtures"
+
+	// fixtureHostname and fixtureUsername replace the hostname and the username of the
+	// recording instance in the fixtures, the tests log in with fixtureUsername
+	fixtureHostname = "turbonomic.example.com"
+	fixtureUsername = "administrator"
+)
+
+// fixtureRequest is a request of a fixture, the credentials of its query and body are masked
+type fixtureRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// fixtureResponse is the response replayed for a request of a fixture
+type fixtureResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// fixtureInteraction is a request to Turbonomic and its response
+type fixtureInteraction struct {
+	Request  fixtureRequest  `json:"request"`
+	Response fixtureResponse `json:"response"`
+}
+
+// fixture is the content of a fixture file of testdata/fixtures
+type fixture struct {
+	Interactions []fixtureInteraction `json:"interactions"`
+}
+
+// fixtureServer is a Turbonomic test server that replays the interactions of a fixture file, or
+// records them from a Turbonomic instance
+type fixtureServer struct {
+	*httptest.Server
+
+	file     string
+	upstream *url.URL
+	client   *http.Client
+	scrub    *strings.Replacer
+	username string
+	password string
+
+	mu           sync.Mutex
+	interactions []fixtureInteraction
+	used         []bool
+	unmatched    []string
+	repeat       bool
+	replays      int
+}
+
+/*
+fixtureTurboServer starts a Turbonomic test server for the fixture testdata/fixtures/<name>.json.
+
+The interactions of the fixture are replayed with strict request matching, and the test fails
+on cleanup when a request has no matching interaction or an interaction is not requested. When
+TURBO_RECORD_FIXTURES is set, the requests are forwarded to the Turbonomic instance of
+TURBO_HOSTNAME and the fixture is written with the credentials scrubbed instead. The tests log
+in with fixtureUsername, which is replaced with TURBO_USERNAME and TURBO_PASSWORD when the
+requests are forwarded.
+
+Parameters:
+  - t: The test
+  - name: The name of the fixture file without its extension
+
+Returns:
+  - *fixtureServer: The started server
+*/
+func fixtureTurboServer(t *testing.T, name string) *fixtureServer {
+	t.Helper()
+
+	file := filepath.Join(testDataBaseDir, fixturesTestDataBaseDir, name+".json")
+	var server *fixtureServer
+	if os.Getenv(RecordFixturesEnvVar) != "" {
+		server = newRecordingServer(t, file, os.Getenv("TURBO_HOSTNAME"), os.Getenv("TURBO_USERNAME"), os.Getenv("TURBO_PASSWORD"))
+		t.Cleanup(func() {
+			if err := server.Save(); err != nil {
+				t.Errorf("unable to save fixture %s: %v", file, err)
+			}
+		})
+		return server
+	}
+
+	server = newReplayServer(t, file)
+	t.Cleanup(func() {
+		for _, request := range server.Unmatched() {
+			t.Errorf("fixture %s has no interaction for request %s", file, request)
+		}
+		for _, request := range server.Unused() {
+			t.Errorf("fixture %s interaction %s was not requested", file, request)
+		}
+	})
+	return server
+}
+
+// newReplayServer starts a server that replays the interactions of a fixture file
+func newReplayServer(t *testing.T, file string) *fixtureServer {
+	t.Helper()
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read fixture %q: %v", file, err)
+	}
+	var f fixture
+	if err := json.Unmarshal(content, &f); err != nil {
+		t.Fatalf("failed to parse fixture %q: %v", file, err)
+	}
+
+	server := &fixtureServer{
+		file:         file,
+		interactions: f.Interactions,
+		used:         make([]bool, len(f.Interactions)),
+	}
+	server.Server = startFixtureServer(t, http.HandlerFunc(server.replay))
+	return server
+}
+
+/*
+newRecordingServer starts a server that forwards the requests to a Turbonomic instance and
+records the interactions, with the hostname, the username and the password of the instance
+replaced by fixtureHostname and fixtureUsername. The login requests of the tests are sent
+with the username and the password of the instance when they carry fixtureUsername.
+
+Parameters:
+  - t: The test
+  - file: The fixture file written by Save
+  - hostname: The hostname of the Turbonomic instance, with an optional port
+  - username: The username of the Turbonomic instance
+  - password: The password of the Turbonomic instance
+
+Returns:
+  - *fixtureServer: The started server
+*/
+func newRecordingServer(t *testing.T, file, hostname, username, password string) *fixtureServer {
+	t.Helper()
+
+	if hostname == "" {
+		t.Fatalf("%s requires TURBO_HOSTNAME to record fixture %s", RecordFixturesEnvVar, file)
+	}
+
+	// the recording instance usually has a self-signed certificate
+	httpClient, err := newHTTPClient(true, "")
+	if err != nil {
+		t.Fatalf("failed to create the recording client: %v", err)
+	}
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	pairs := []string{hostname, fixtureHostname}
+	if username != "" {
+		pairs = append(pairs, username, fixtureUsername)
+	}
+
+	server := &fixtureServer{
+		file:     file,
+		upstream: &url.URL{Scheme: "https", Host: hostname},
+		client:   httpClient,
+		scrub:    strings.NewReplacer(pairs...),
+		username: username,
+		password: password,
+	}
+	server.Server = startFixtureServer(t, http.HandlerFunc(server.record))
+	return server
+}
+
+// startFixtureServer starts a TLS test server, closed on cleanup
+func startFixtureServer(t *testing.T, handler http.Handler) *httptest.Server {
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.ReadHeaderTimeout = 1 * time.Second
+	server.TLS = &tls.Config{
+		InsecureSkipVerify: true,
+	}
+	server.StartTLS()
+
+	t.Cleanup(func() {
+		server.CloseClientConnections()
+		server.Close()
+	})
+	return server
+}
+
+// newFixtureRequest reads a request of a test with its query and body scrubbed
+func newFixtureRequest(r *http.Request) (fixtureRequest, []byte, error) {
+	body, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	if err != nil {
+		return fixtureRequest{}, nil, err
+	}
+
+	return fixtureRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  redactQuery(r.URL.RawQuery),
+		Body:   logger.RedactBody(r.Header.Get("Content-Type"), body),
+	}, body, nil
+}
+
+// String returns the method, the path and the query of the request
+func (r fixtureRequest) String() string {
+	request := r.Method + " " + r.Path
+	if r.Query != "" {
+		request += "?" + r.Query
+	}
+	return request
+}
+
+// replay writes the response of the first interaction not yet used that matches the request, the
+// interactions are all available again when the server repeats and they have all been used
+func (s *fixtureServer) replay(w http.ResponseWriter, r *http.Request) {
+	request, _, err := newFixtureRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	index := s.match(request)
+	if index == -1 && s.repeat && !slices.Contains(s.used, false) {
+		s.used = make([]bool, len(s.interactions))
+		s.replays++
+		index = s.match(request)
+	}
+	if index == -1 {
+		description := request.String()
+		if request.Body != "" {
+			description += " with body " + request.Body
+		}
+		s.unmatched = append(s.unmatched, description)
+	}
+	s.mu.Unlock()
+
+	if index == -1 {
+		http.Error(w, fmt.Sprintf("no fixture interaction for request %s", request), http.StatusNotImplemented)
+		return
+	}
+
+	response := s.interactions[index].Response
+	if response.ContentType != "" {
+		w.Header().Set("Content-Type", response.ContentType)
+	}
+	w.WriteHeader(response.Status)
+	_, _ = fmt.Fprint(w, response.Body)
+}
+
+// match marks the first interaction not yet used that matches the request as used and returns
+// its index, or -1 when there is none
+func (s *fixtureServer) match(request fixtureRequest) int {
+	for i, interaction := range s.interactions {
+		if !s.used[i] && interaction.Request == request {
+			s.used[i] = true
+			return i
+		}
+	}
+	return -1
+}
+
+// record forwards a request to the Turbonomic instance and records the scrubbed interaction
+func (s *fixtureServer) record(w http.ResponseWriter, r *http.Request) {
+	request, body, err := newFixtureRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the login requests of the tests carry the fixture credentials
+	if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+		if form, err := url.ParseQuery(string(body)); err == nil && form.Get("username") == fixtureUsername {
+			form.Set("username", s.username)
+			form.Set("password", s.password)
+			body = []byte(form.Encode())
+		}
+	}
+
+	upstreamUrl := *s.upstream
+	upstreamUrl.Path = r.URL.Path
+	upstreamUrl.RawQuery = r.URL.RawQuery
+	upstreamReq, err := http.NewRequestWithContext(r.Context(), r.Method, upstreamUrl.String(), bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	for _, header := range []string{"Accept", "Authorization", "Content-Type", "Cookie"} {
+		if value := r.Header.Get(header); value != "" {
+			upstreamReq.Header.Set(header, value)
+		}
+	}
+
+	resp, err := s.client.Do(upstreamReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	request.Query = s.scrub.Replace(request.Query)
+	request.Body = s.scrub.Replace(request.Body)
+	interaction := fixtureInteraction{
+		Request: request,
+		Response: fixtureResponse{
+			Status:      resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        s.scrub.Replace(logger.RedactBody(resp.Header.Get("Content-Type"), respBody)),
+		},
+	}
+	s.mu.Lock()
+	s.interactions = append(s.interactions, interaction)
+	s.mu.Unlock()
+
+	// the session cookie of the instance is passed to the test, but never recorded
+	for _, cookie := range resp.Header.Values("Set-Cookie") {
+		w.Header().Add("Set-Cookie", cookie)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(respBody)
+}
+
+// Save writes the recorded interactions to the fixture file
+func (s *fixtureServer) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, err := json.MarshalIndent(fixture{Interactions: s.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.file, append(content, '\n'), 0o644)
+}
+
+// Unmatched returns the requests that have no interaction in the fixture
+func (s *fixtureServer) Unmatched() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.unmatched)
+}
+
+// Unused returns the interactions of the fixture that were not requested
+func (s *fixtureServer) Unused() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var unused []string
+	if s.replays > 0 {
+		return unused
+	}
+	for i, interaction := range s.interactions {
+		if s.used != nil && !s.used[i] {
+			unused = append(unused, interaction.Request.String())
+		}
+	}
+	return unused
+}
+
+// Repeat replays the interactions of the fixture again once they have all been requested, for the
+// tests that configure the provider in each terraform command of their steps
+func (s *fixtureServer) Repeat() *fixtureServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.repeat = true
+	return s
+}
+
+// Hostname returns the host and port of the server, to be set as the hostname of the provider
+func (s *fixtureServer) Hostname() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
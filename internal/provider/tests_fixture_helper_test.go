@@ -0,0 +1,116 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests that the recorder scrubs the credentials of the fixture and that the replay server
+// serves the recorded interactions
+func TestFixtureRecordAndReplay(t *testing.T) {
+	upstream := mockTurboServer(t, []MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         loginPath,
+			ExpectedBody: "password=s3cr3t&username=turbo-admin",
+			ResponseBody: `{"username":"turbo-admin","uuid":"_4T_7kwY-Ed-WUKbEYSVIDw"}`,
+			ResponseCode: http.StatusOK,
+		},
+	})
+	upstreamHostname := upstream.Listener.Addr().String()
+	file := filepath.Join(t.TempDir(), "fixtures", "connectivity_check.json")
+
+	recorder := newRecordingServer(t, file, upstreamHostname, "turbo-admin", "s3cr3t")
+	httpClient, err := newHTTPClient(true, "")
+	assert.NoError(t, err)
+
+	creds := connectivityCredentials{Username: fixtureUsername, Password: "not-the-password"}
+	version, err := checkConnectivity(context.Background(), httpClient, recorder.Hostname(), creds)
+	assert.NoError(t, err)
+	assert.Equal(t, "8.14.3", version.Version)
+	assert.NoError(t, recorder.Save())
+
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	for _, secret := range []string{"s3cr3t", "turbo-admin", "not-the-password", upstreamHostname} {
+		assert.NotContains(t, string(content), secret)
+	}
+
+	replay := newReplayServer(t, file)
+	version, err = checkConnectivity(context.Background(), httpClient, replay.Hostname(), creds)
+	assert.NoError(t, err)
+	assert.Equal(t, "8.14.3", version.Version)
+	assert.Empty(t, replay.Unmatched())
+	assert.Empty(t, replay.Unused())
+
+	// each interaction is replayed once, so a second check is unmatched
+	_, err = checkConnectivity(context.Background(), httpClient, replay.Hostname(), creds)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"POST /api/v3/login with body password=%2A%2A%2A&username=administrator"}, replay.Unmatched())
+}
+
+// Tests that a request that differs from the fixture is reported and that the interactions
+// not requested are reported
+func TestFixtureReplayStrictMatching(t *testing.T) {
+	replay := newReplayServer(t, filepath.Join(testDataBaseDir, fixturesTestDataBaseDir, "connectivity_check.json"))
+	httpClient, err := newHTTPClient(true, "")
+	assert.NoError(t, err)
+
+	resp, err := httpClient.Get(replay.URL + versionInfoPath + "?verbose=true")
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+
+	assert.Equal(t, []string{"GET /api/v3/admin/versioninfo?verbose=true"}, replay.Unmatched())
+	assert.Equal(t, []string{"POST /api/v3/login", "GET /api/v3/admin/versioninfo"}, replay.Unused())
+}
+
+// Tests the connectivity check against a recorded fixture
+func TestFixtureConnectivityCheck(t *testing.T) {
+	server := fixtureTurboServer(t, "connectivity_check")
+	httpClient, err := newHTTPClient(true, "")
+	assert.NoError(t, err)
+
+	version, err := checkConnectivity(context.Background(), httpClient, server.Hostname(),
+		connectivityCredentials{Username: fixtureUsername, Password: "12345"})
+	assert.NoError(t, err)
+
+	serverInfo, err := newServerInfo(version)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, serverInfo.Major)
+	assert.Equal(t, 14, serverInfo.Minor)
+}
+
+// Tests that a repeated fixture is replayed again once its interactions have all been requested
+func TestFixtureReplayRepeat(t *testing.T) {
+	replay := newReplayServer(t, filepath.Join(testDataBaseDir, fixturesTestDataBaseDir, "connectivity_check.json")).Repeat()
+	httpClient, err := newHTTPClient(true, "")
+	assert.NoError(t, err)
+
+	creds := connectivityCredentials{Username: fixtureUsername, Password: "12345"}
+	for range 2 {
+		version, err := checkConnectivity(context.Background(), httpClient, replay.Hostname(), creds)
+		assert.NoError(t, err)
+		assert.Equal(t, "8.14.3", version.Version)
+	}
+	assert.Empty(t, replay.Unmatched())
+	assert.Empty(t, replay.Unused())
+
+	// the interactions are not replayed again before they have all been requested
+	login := url.Values{"username": {fixtureUsername}, "password": {"12345"}}
+	for _, status := range []int{http.StatusOK, http.StatusNotImplemented} {
+		resp, err := httpClient.PostForm(replay.URL+loginPath, login)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, status, resp.StatusCode)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -52,11 +53,18 @@ const (
 	googleComputeDiskDataBaseDir    = "google_compute_disk_data_source"
 	entityActionDir                 = "entity_action_data_source"
 	azureMSSQLTestDataBaseDir       = "azurerm_mssql_database_data_source"
+	entitiesTestDataBaseDir         = "entities_data_source"
+	entityTestDataBaseDir           = "entity_data_source"
+	entityStatsTestDataBaseDir      = "entity_stats_data_source"
+	complianceReportTestDataBaseDir = "compliance_report_data_source"
 
 	vmEntityType = "VirtualMachine"
 
 	entityTagsRespTestData = "entity_tags_success_response.json"
 	entityTagRespTestData  = "entity_tag_success_response.json"
+
+	versionInfoRespTestData = `{"version":"8.14.3","versionInfo":"Turbonomic Operations Manager 8.14.3"}`
+	accessTokenRespTestData = `{"access_token":"eyJhbGciOi","scope":"role:OBSERVER","token_type":"Bearer","expires_in":600}`
 )
 
 type Response struct {
@@ -64,34 +72,109 @@ type Response struct {
 	HttpStatus int
 }
 
+// NotCalled is the Times of a route that must not be requested
+const NotCalled = -1
+
 type MockRoute struct {
 	Method       string
 	Path         string
 	ExpectedBody string
 	ResponseBody string
 	ResponseCode int
+
+	// Query and Headers are the query parameters and headers a request must have to match the route
+	Query   map[string]string
+	Headers map[string]string
+
+	// Times is the exact number of requests of the route, or NotCalled; MinTimes and MaxTimes bound
+	// it instead. A route that reached its maximum no longer matches, so the next matching route
+	// answers the following requests. The counts are checked when the test ends.
+	Times    int
+	MinTimes int
+	MaxTimes int
+}
+
+// MockServer is a Turbonomic API mock that counts the requests of its routes
+type MockServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	routes []MockRoute
+	counts []int
+	excess []int
+	calls  []string
+}
+
+// maxCalls returns the maximum number of requests of a route, 0 when it is not bounded
+func (r MockRoute) maxCalls() int {
+	switch {
+	case r.Times == NotCalled:
+		return 0
+	case r.Times > 0:
+		return r.Times
+	default:
+		return r.MaxTimes
+	}
+}
+
+// bounded tells if the number of requests of a route is limited
+func (r MockRoute) bounded() bool {
+	return r.Times != 0 || r.MaxTimes > 0
+}
+
+// String is the method and the path of a route, as listed by Calls
+func (r MockRoute) String() string {
+	return r.Method + " " + r.Path
 }
 
-func mockTurboServer(t *testing.T, routes []MockRoute) *httptest.Server {
+// matches tells if a request has the method, path, query parameters and headers of a route
+func (r MockRoute) matches(req *http.Request) bool {
+	if req.Method != r.Method || !matchPath(r.Path, req.URL.Path) {
+		return false
+	}
+	for key, value := range r.Query {
+		if !req.URL.Query().Has(key) || req.URL.Query().Get(key) != value {
+			return false
+		}
+	}
+	for key, value := range r.Headers {
+		if req.Header.Get(key) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func mockTurboServer(t *testing.T, routes []MockRoute) *MockServer {
+	mock := &MockServer{
+		routes: routes,
+		counts: make([]int, len(routes)),
+		excess: make([]int, len(routes)),
+	}
+
 	// Set shorter timeouts to prevent hanging connections
 	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		for _, route := range routes {
-			if r.Method == route.Method && matchPath(route.Path, r.URL.Path) {
-				if len(route.ExpectedBody) > 0 {
-					body, _ := io.ReadAll(r.Body)
-					defer func() {
-						_ = r.Body.Close()
-					}()
-					if !bytes.Equal(bytes.TrimSpace(body), []byte(route.ExpectedBody)) {
-						http.Error(w, fmt.Sprintf("unexpected body: expected: %q, got %q", route.ExpectedBody, string(body)), http.StatusBadRequest)
-						return
-					}
+		route, ok := mock.match(r)
+		if ok {
+			if len(route.ExpectedBody) > 0 {
+				body, _ := io.ReadAll(r.Body)
+				defer func() {
+					_ = r.Body.Close()
+				}()
+				if !bytes.Equal(bytes.TrimSpace(body), []byte(route.ExpectedBody)) {
+					http.Error(w, fmt.Sprintf("unexpected body: expected: %q, got %q", route.ExpectedBody, string(body)), http.StatusBadRequest)
+					return
 				}
-
-				w.WriteHeader(route.ResponseCode)
-				_, _ = fmt.Fprint(w, route.ResponseBody)
-				return
 			}
+
+			w.WriteHeader(route.ResponseCode)
+			_, _ = fmt.Fprint(w, route.ResponseBody)
+			return
+		}
+		// every mock answers the connectivity check of the provider unless a route overrides it
+		if r.Method == http.MethodGet && r.URL.Path == versionInfoPath {
+			_, _ = fmt.Fprint(w, versionInfoRespTestData)
+			return
 		}
 		http.NotFound(w, r)
 	}))
@@ -104,13 +187,90 @@ func mockTurboServer(t *testing.T, routes []MockRoute) *httptest.Server {
 		InsecureSkipVerify: true,
 	}
 	server.StartTLS()
+	mock.Server = server
 
 	t.Cleanup(func() {
 		server.CloseClientConnections()
 		server.Close()
+		mock.assertExpectations(t)
 	})
 
-	return server
+	return mock
+}
+
+// match returns the first route of a request that has not reached its maximum number of requests
+func (m *MockServer) match(r *http.Request) (MockRoute, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exhausted := -1
+	for i, route := range m.routes {
+		if !route.matches(r) {
+			continue
+		}
+		if route.bounded() && m.counts[i] >= route.maxCalls() {
+			exhausted = i
+			continue
+		}
+		m.counts[i]++
+		m.calls = append(m.calls, route.String())
+		return route, true
+	}
+	// the request is one too many for the last route that matched it
+	if exhausted >= 0 {
+		m.excess[exhausted]++
+	}
+	return MockRoute{}, false
+}
+
+// Calls are the routes of the requests received by the server, in order
+func (m *MockServer) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.calls...)
+}
+
+// errorReporter is the part of testing.T that reports the failed expectations
+type errorReporter interface {
+	Errorf(format string, args ...any)
+}
+
+// assertExpectations reports the routes requested fewer or more times than expected
+func (m *MockServer) assertExpectations(t errorReporter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, route := range m.routes {
+		count := m.counts[i] + m.excess[i]
+		switch {
+		case route.Times == NotCalled && count > 0:
+			t.Errorf("route %s: expected no requests, got %d", route, count)
+		case route.Times > 0 && count != route.Times:
+			t.Errorf("route %s: expected %d requests, got %d", route, route.Times, count)
+		case route.MinTimes > 0 && count < route.MinTimes:
+			t.Errorf("route %s: expected at least %d requests, got %d", route, route.MinTimes, count)
+		case route.MaxTimes > 0 && count > route.MaxTimes:
+			t.Errorf("route %s: expected at most %d requests, got %d", route, route.MaxTimes, count)
+		}
+	}
+}
+
+// assertCallOrder checks that the routes were requested in the order of expected, other requests
+// may come in between
+func assertCallOrder(t *testing.T, server *MockServer, expected ...string) {
+	t.Helper()
+
+	calls := server.Calls()
+	next := 0
+	for _, call := range calls {
+		if next < len(expected) && call == expected[next] {
+			next++
+		}
+	}
+	if next < len(expected) {
+		t.Errorf("expected the requests %v in order, got %v", expected, calls)
+	}
 }
 
 func matchPath(pattern, path string) bool {
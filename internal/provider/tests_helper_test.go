@@ -0,0 +1,112 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// errorRecorder records the failed expectations of a mock server or of a test helper
+type errorRecorder struct {
+	errors []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// get sends a request to a mock server and returns the status and the body of the response
+func get(t *testing.T, server *MockServer, path string, headers map[string]string) (int, string) {
+	req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	assert.NoError(t, err)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := server.Client().Do(req)
+	assert.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+// Tests that a route answers its number of requests and then lets the next route answer
+func TestMockServerCallCounts(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{Method: http.MethodGet, Path: "/api/v3/entities/{id}", ResponseCode: http.StatusOK, ResponseBody: "first", Times: 1},
+		{Method: http.MethodGet, Path: "/api/v3/entities/{id}", ResponseCode: http.StatusOK, ResponseBody: "second", MaxTimes: 2},
+		{Method: http.MethodGet, Path: "/api/v3/entities/{id}/tags", ResponseCode: http.StatusOK, MinTimes: 1},
+		{Method: http.MethodPost, Path: "/api/v3/entities/{id}/tags", Times: NotCalled},
+	})
+
+	for _, expected := range []string{"first", "second", "second"} {
+		_, body := get(t, server, "/api/v3/entities/123", nil)
+		assert.Equal(t, expected, body)
+	}
+	get(t, server, "/api/v3/entities/123/tags", nil)
+}
+
+// Tests the failed expectations reported when a test ends
+func TestMockServerExpectations(t *testing.T) {
+	server := &MockServer{
+		routes: []MockRoute{
+			{Method: http.MethodGet, Path: "/api/v3/entities/{id}", Times: 1},
+			{Method: http.MethodGet, Path: "/api/v3/entities/{id}", MaxTimes: 2},
+			{Method: http.MethodGet, Path: "/api/v3/entities/{id}/tags", MinTimes: 2},
+			{Method: http.MethodPost, Path: "/api/v3/entities/{id}/tags", Times: NotCalled},
+			{Method: http.MethodPost, Path: "/api/v3/stats/{id}"},
+		},
+		counts: []int{1, 2, 1, 1, 5},
+		excess: []int{0, 1, 0, 0, 0},
+	}
+
+	errors := &errorRecorder{}
+	server.assertExpectations(errors)
+	assert.Equal(t, []string{
+		"route GET /api/v3/entities/{id}: expected at most 2 requests, got 3",
+		"route GET /api/v3/entities/{id}/tags: expected at least 2 requests, got 1",
+		"route POST /api/v3/entities/{id}/tags: expected no requests, got 1",
+	}, errors.errors)
+}
+
+// Tests that the routes match the query parameters and the headers of the requests
+func TestMockServerQueryAndHeaders(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{
+			Method: http.MethodGet, Path: "/api/v3/search", ResponseCode: http.StatusOK, ResponseBody: "filtered",
+			Query: map[string]string{"limit": "10"}, Headers: map[string]string{"Authorization": "Bearer abc"}, Times: 1,
+		},
+		{Method: http.MethodGet, Path: "/api/v3/search", ResponseCode: http.StatusOK, ResponseBody: "all"},
+	})
+
+	_, body := get(t, server, "/api/v3/search?limit=10", map[string]string{"Authorization": "Bearer abc"})
+	assert.Equal(t, "filtered", body)
+	_, body = get(t, server, "/api/v3/search?limit=20", map[string]string{"Authorization": "Bearer abc"})
+	assert.Equal(t, "all", body)
+	_, body = get(t, server, "/api/v3/search?limit=10", nil)
+	assert.Equal(t, "all", body)
+}
+
+// Tests the order of the requests received by a mock server
+func TestMockServerCallOrder(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{Method: http.MethodGet, Path: "/api/v3/entities/{id}", ResponseCode: http.StatusOK},
+		{Method: http.MethodGet, Path: "/api/v3/entities/{id}/tags", ResponseCode: http.StatusOK},
+	})
+
+	get(t, server, "/api/v3/entities/123", nil)
+	get(t, server, "/api/v3/markets", nil)
+	get(t, server, "/api/v3/entities/123/tags", nil)
+
+	assert.Equal(t, []string{"GET /api/v3/entities/{id}", "GET /api/v3/entities/{id}/tags"}, server.Calls())
+	assertCallOrder(t, server, "GET /api/v3/entities/{id}", "GET /api/v3/entities/{id}/tags")
+}
@@ -0,0 +1,115 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// Clouds of the tier mapping table
+	CloudAWS   = "aws"
+	CloudAzure = "azure"
+	CloudGCP   = "gcp"
+
+	// Resource kinds of the tier mapping table
+	DiskResourceKind = "disk"
+
+	// azureManagedDiskPrefix is the prefix of the Turbonomic display names of the Azure managed disk tiers
+	azureManagedDiskPrefix = "Managed "
+)
+
+// tierMapping maps the Turbonomic display name of a tier to the SKU of the cloud provider
+type tierMapping struct {
+	cloud        string
+	resourceKind string
+	displayName  string
+	sku          string
+}
+
+// tierMappings is the shared table of the tiers whose Turbonomic display name differs from
+// the SKU used by the cloud provider
+var tierMappings = []tierMapping{
+	{CloudAWS, DiskResourceKind, "STANDARD", "standard"},
+	{CloudAWS, DiskResourceKind, "GP2", "gp2"},
+	{CloudAWS, DiskResourceKind, "GP3", "gp3"},
+	{CloudAWS, DiskResourceKind, "IO1", "io1"},
+	{CloudAWS, DiskResourceKind, "IO2", "io2"},
+	{CloudAWS, DiskResourceKind, "ST1", "st1"},
+	{CloudAWS, DiskResourceKind, "SC1", "sc1"},
+
+	{CloudAzure, DiskResourceKind, string(StandardHDD), "Standard_LRS"},
+	{CloudAzure, DiskResourceKind, string(StandardSSD), "StandardSSD_LRS"},
+	{CloudAzure, DiskResourceKind, string(PremiumSSD), "Premium_LRS"},
+	{CloudAzure, DiskResourceKind, string(UltraDisk), "UltraSSD_LRS"},
+	{CloudAzure, DiskResourceKind, string(PremiumSSDv2), "PremiumV2_LRS"},
+	{CloudAzure, DiskResourceKind, string(ZoneRedundantSSD), "StandardSSD_ZRS"},
+
+	{CloudGCP, DiskResourceKind, "Standard Persistent Disk", "pd-standard"},
+	{CloudGCP, DiskResourceKind, "Balanced Persistent Disk", "pd-balanced"},
+	{CloudGCP, DiskResourceKind, "SSD Persistent Disk", "pd-ssd"},
+	{CloudGCP, DiskResourceKind, "Extreme Persistent Disk", "pd-extreme"},
+	{CloudGCP, DiskResourceKind, "Hyperdisk Balanced", "hyperdisk-balanced"},
+	{CloudGCP, DiskResourceKind, "Hyperdisk Throughput", "hyperdisk-throughput"},
+	{CloudGCP, DiskResourceKind, "Hyperdisk Extreme", "hyperdisk-extreme"},
+}
+
+var (
+	tierClouds        = []string{CloudAWS, CloudAzure, CloudGCP}
+	tierResourceKinds = []string{DiskResourceKind}
+)
+
+// tierToSku returns the cloud provider SKU of a Turbonomic tier display name, the Managed prefix
+// of the Azure managed disk tiers is optional
+func tierToSku(cloud, resourceKind, displayName string) (string, error) {
+	cloud, resourceKind, err := normalizeTierKeys(cloud, resourceKind)
+	if err != nil {
+		return "", err
+	}
+
+	if cloud == CloudAzure {
+		displayName = strings.TrimPrefix(displayName, azureManagedDiskPrefix)
+	}
+
+	for _, mapping := range tierMappings {
+		if mapping.cloud == cloud && mapping.resourceKind == resourceKind && mapping.displayName == displayName {
+			return mapping.sku, nil
+		}
+	}
+	return "", fmt.Errorf("unknown %s %s tier provided: %s", cloud, resourceKind, displayName)
+}
+
+// skuToTier returns the Turbonomic tier display name of a case insensitive cloud provider SKU
+func skuToTier(cloud, resourceKind, sku string) (string, error) {
+	cloud, resourceKind, err := normalizeTierKeys(cloud, resourceKind)
+	if err != nil {
+		return "", err
+	}
+
+	for _, mapping := range tierMappings {
+		if mapping.cloud == cloud && mapping.resourceKind == resourceKind && strings.EqualFold(mapping.sku, sku) {
+			if cloud == CloudAzure {
+				return azureManagedDiskPrefix + mapping.displayName, nil
+			}
+			return mapping.displayName, nil
+		}
+	}
+	return "", fmt.Errorf("unknown %s %s sku provided: %s", cloud, resourceKind, sku)
+}
+
+// normalizeTierKeys lower cases and validates the cloud and resource kind of a tier lookup
+func normalizeTierKeys(cloud, resourceKind string) (string, string, error) {
+	cloud = strings.ToLower(cloud)
+	resourceKind = strings.ToLower(resourceKind)
+
+	if !slices.Contains(tierClouds, cloud) {
+		return "", "", fmt.Errorf("unknown cloud %q, expected one of: %s", cloud, strings.Join(tierClouds, ", "))
+	}
+	if !slices.Contains(tierResourceKinds, resourceKind) {
+		return "", "", fmt.Errorf("unknown resource kind %q, expected one of: %s", resourceKind, strings.Join(tierResourceKinds, ", "))
+	}
+	return cloud, resourceKind, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/stretchr/testify/assert"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+func TestTierToSku(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cloud        string
+		resourceKind string
+		displayName  string
+		expected     string
+	}{
+		{name: "AWS volume", cloud: "aws", resourceKind: "disk", displayName: "GP3", expected: "gp3"},
+		{name: "Azure managed disk", cloud: "azure", resourceKind: "disk", displayName: "Managed Premium SSD", expected: "Premium_LRS"},
+		{name: "Azure disk without prefix", cloud: "Azure", resourceKind: "Disk", displayName: "Premium SSD v2", expected: "PremiumV2_LRS"},
+		{name: "GCP persistent disk", cloud: "gcp", resourceKind: "disk", displayName: "Balanced Persistent Disk", expected: "pd-balanced"},
+		{name: "GCP hyperdisk", cloud: "GCP", resourceKind: "disk", displayName: "Hyperdisk Throughput", expected: "hyperdisk-throughput"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sku, err := tierToSku(tc.cloud, tc.resourceKind, tc.displayName)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, sku)
+		})
+	}
+
+	_, err := tierToSku("azure", "disk", "Managed Standard Fastest Ever")
+	assert.ErrorContains(t, err, "unknown azure disk tier provided")
+
+	_, err = tierToSku("ibm", "disk", "GP3")
+	assert.ErrorContains(t, err, `unknown cloud "ibm"`)
+
+	_, err = tierToSku("aws", "compute", "t3.micro")
+	assert.ErrorContains(t, err, `unknown resource kind "compute"`)
+
+	_, err = tierToSku("aws", "bucket", "GP3")
+	assert.ErrorContains(t, err, `unknown resource kind "bucket"`)
+}
+
+func TestSkuToTier(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cloud    string
+		sku      string
+		expected string
+	}{
+		{name: "AWS volume", cloud: "aws", sku: "io2", expected: "IO2"},
+		{name: "Azure managed disk", cloud: "azure", sku: "StandardSSD_ZRS", expected: "Managed Zone-redundant SSD"},
+		{name: "Case insensitive sku", cloud: "azure", sku: "premium_lrs", expected: "Managed Premium SSD"},
+		{name: "GCP persistent disk", cloud: "gcp", sku: "pd-ssd", expected: "SSD Persistent Disk"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tier, err := skuToTier(tc.cloud, DiskResourceKind, tc.sku)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, tier)
+		})
+	}
+
+	_, err := skuToTier("gcp", "disk", "pd-unknown")
+	assert.ErrorContains(t, err, "unknown gcp disk sku provided")
+}
+
+// Tests that every mapping of the table converts both ways
+func TestTierMappingsRoundTrip(t *testing.T) {
+	for _, mapping := range tierMappings {
+		sku, err := tierToSku(mapping.cloud, mapping.resourceKind, mapping.displayName)
+		assert.NoError(t, err)
+		assert.Equal(t, mapping.sku, sku)
+
+		tier, err := skuToTier(mapping.cloud, mapping.resourceKind, sku)
+		assert.NoError(t, err)
+		resku, err := tierToSku(mapping.cloud, mapping.resourceKind, tier)
+		assert.NoError(t, err)
+		assert.Equal(t, sku, resku)
+	}
+}
+
+func TestHandleAwsEbsVolumeCurrentState(t *testing.T) {
+	entities := turboclient.SearchResults{{}}
+
+	entities[0].Template.DisplayName = "IO2"
+	state, err := HandleAwsEbsVolumeCurrentState(context.Background(), AwsEbsVolumeEntityModel{}, entities)
+	assert.NoError(t, err)
+	assert.Equal(t, "io2", state.CurrentType.ValueString())
+
+	entities[0].Template.DisplayName = "Magnetic"
+	state, err = HandleAwsEbsVolumeCurrentState(context.Background(), AwsEbsVolumeEntityModel{}, entities)
+	assert.NoError(t, err)
+	assert.Equal(t, "magnetic", state.CurrentType.ValueString())
+}
+
+func TestHandleAwsEbsVolumeAction(t *testing.T) {
+	var actions turboclient.ActionResults
+	err := json.Unmarshal([]byte(`[{"compoundActions": [{
+		"currentEntity": {"displayName": "Magnetic"},
+		"newEntity": {"displayName": "GP3"}
+	}]}]`), &actions)
+	assert.NoError(t, err)
+
+	state, err := HandleAwsEbsVolumeAction(context.Background(), &datasource.ReadResponse{}, AwsEbsVolumeEntityModel{}, actions)
+	assert.NoError(t, err)
+	assert.Equal(t, "magnetic", state.CurrentType.ValueString())
+	assert.Equal(t, "gp3", state.NewType.ValueString())
+}
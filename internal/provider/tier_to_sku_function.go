@@ -0,0 +1,73 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &TierToSkuFunction{}
+
+type TierToSkuFunction struct{}
+
+func NewTierToSkuFunction() function.Function {
+	return &TierToSkuFunction{}
+}
+
+func (f *TierToSkuFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tier_to_sku"
+}
+
+func (f *TierToSkuFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Map a Turbonomic tier to a cloud SKU",
+		Description: "Returns the cloud provider SKU of a Turbonomic tier display name, such as the new_entity.display_name of turbonomic_entity_actions, E.G: Managed Premium SSD is Premium_LRS. The same mapping table is used by the typed data sources",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cloud",
+				Description: "case insensitive cloud provider, one of aws, azure or gcp",
+			},
+			function.StringParameter{
+				Name:        "resource_kind",
+				Description: "case insensitive kind of the resource, only disk is supported as the compute and database tiers are not mapped",
+			},
+			function.StringParameter{
+				Name:        "display_name",
+				Description: "Turbonomic display name of the tier, E.G: Balanced Persistent Disk",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Returns the cloud provider SKU of the tier
+func (f *TierToSkuFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cloud, resourceKind, displayName string
+
+	resp.Error = req.Arguments.Get(ctx, &cloud, &resourceKind, &displayName)
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := tierToSku(cloud, resourceKind, displayName)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
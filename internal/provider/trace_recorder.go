@@ -0,0 +1,300 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	turboclient "github.com/IBM/turbonomic-go-client"
+
+	"github.com/IBM/terraform-provider-turbonomic/pkg/logger"
+)
+
+// DebugTraceDirEnvVar is the environment variable of the directory of the trace files
+const DebugTraceDirEnvVar = "TURBO_DEBUG_TRACE_DIR"
+
+// traceEntry is a Turbonomic API exchange written as a JSON line of the trace file. The requests of the
+// turbonomic client are recorded by operation and marked synthetic: the client does not expose its HTTP
+// exchanges, so their method and path are the route of the API that the operation requests, and they
+// have no status and headers. The requests that the provider sends without the client are recorded as
+// they are sent.
+type traceEntry struct {
+	Time            time.Time           `json:"time"`
+	Operation       string              `json:"operation,omitempty"`
+	Synthetic       bool                `json:"synthetic,omitempty"`
+	Method          string              `json:"method,omitempty"`
+	Path            string              `json:"path,omitempty"`
+	Query           string              `json:"query,omitempty"`
+	Status          int                 `json:"status,omitempty"`
+	RequestHeaders  map[string][]string `json:"request_headers,omitempty"`
+	RequestBody     json.RawMessage     `json:"request_body,omitempty"`
+	ResponseHeaders map[string][]string `json:"response_headers,omitempty"`
+	ResponseBody    json.RawMessage     `json:"response_body,omitempty"`
+	LatencyMs       float64             `json:"latency_ms"`
+	Error           string              `json:"error,omitempty"`
+}
+
+// traceRecorder writes the Turbonomic API exchanges of a run to a JSON lines file, which is opened
+// for each exchange so that no file is left open when the provider stops
+type traceRecorder struct {
+	mu   sync.Mutex
+	name string
+}
+
+// clientOperationRoutes are the method and path of the Turbonomic API requested by the operations of
+// the turbonomic client, {uuid} is the entity of the request
+var clientOperationRoutes = map[string][2]string{
+	"NewClient":              {http.MethodPost, "/api/v3/login"},
+	"SearchEntityByName":     {http.MethodPost, "/api/v3/search"},
+	"SearchEntities":         {http.MethodPost, "/api/v3/search"},
+	"SearchEntityByVendorId": {http.MethodPost, "/api/v3/search"},
+	"GetEntity":              {http.MethodGet, "/api/v3/entities/{uuid}"},
+	"GetEntityTags":          {http.MethodGet, "/api/v3/entities/{uuid}/tags"},
+	"TagEntity":              {http.MethodPost, "/api/v3/entities/{uuid}/tags"},
+	"GetActionsByUUID":       {http.MethodPost, "/api/v3/entities/{uuid}/actions"},
+	"GetStats":               {http.MethodPost, "/api/v3/stats/{uuid}"},
+}
+
+var (
+	// traceRecorders share a trace file per directory between the provider configurations of a run
+	traceRecorders   = map[string]*traceRecorder{}
+	traceRecordersMu sync.Mutex
+)
+
+/*
+openTraceRecorder creates the trace file of the run in a directory, such as
+turbonomic-trace-20240102T150405Z-1234.jsonl, or returns the recorder of the directory when
+the file is already created.
+
+Parameters:
+  - dir: The directory of the trace files, created when it does not exist
+
+Returns:
+  - *traceRecorder: The recorder of the trace file
+  - error: An error when the directory or the file cannot be created
+*/
+func openTraceRecorder(dir string) (*traceRecorder, error) {
+	traceRecordersMu.Lock()
+	defer traceRecordersMu.Unlock()
+
+	if recorder, ok := traceRecorders[dir]; ok {
+		return recorder, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create the debug trace directory: %v", err)
+	}
+
+	name := fmt.Sprintf("turbonomic-trace-%s-%d.jsonl", time.Now().UTC().Format("20060102T150405Z"), os.Getpid())
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the debug trace file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("unable to create the debug trace file: %v", err)
+	}
+
+	recorder := &traceRecorder{name: file.Name()}
+	traceRecorders[dir] = recorder
+	return recorder, nil
+}
+
+// Name returns the path of the trace file
+func (r *traceRecorder) Name() string {
+	return r.name
+}
+
+// record writes an exchange as a line of the trace file
+func (r *traceRecorder) record(entry traceEntry) {
+	if r == nil {
+		return
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, err := os.OpenFile(r.name, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return
+	}
+	_, _ = file.Write(append(line, '\n'))
+	_ = file.Close()
+}
+
+// recordOperation writes a request of the turbonomic client with its redacted request and response
+func (r *traceRecorder) recordOperation(operation string, request any, response any, err error, start time.Time) {
+	if r == nil {
+		return
+	}
+
+	method, path := clientOperationRoute(operation, request)
+	entry := traceEntry{
+		Time:         start.UTC(),
+		Operation:    operation,
+		Synthetic:    true,
+		Method:       method,
+		Path:         path,
+		RequestBody:  traceValue(request),
+		ResponseBody: traceValue(response),
+		LatencyMs:    latencyMs(time.Since(start)),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	r.record(entry)
+}
+
+// clientOperationRoute returns the method and path of the Turbonomic API requested by an operation of
+// the turbonomic client, the client logs in with the OAuth 2.0 token endpoint when it has a client ID
+func clientOperationRoute(operation string, request any) (string, string) {
+	route, ok := clientOperationRoutes[operation]
+	if !ok {
+		return "", ""
+	}
+
+	var uuid string
+	switch r := request.(type) {
+	case turboclient.ClientParameters:
+		if r.OAuthCreds.ClientId != "" {
+			return route[0], "/oauth2/token"
+		}
+	case turboclient.EntityRequest:
+		uuid = r.Uuid
+	case turboclient.TagEntityRequest:
+		uuid = r.Uuid
+	case turboclient.ActionsRequest:
+		uuid = r.Uuid
+	case turboclient.StatsRequest:
+		uuid = r.EntityUUID
+	}
+	return route[0], strings.ReplaceAll(route[1], "{uuid}", url.PathEscape(uuid))
+}
+
+// wrapClient records the requests of an HTTP client that the provider sends without the turbonomic client
+func (r *traceRecorder) wrapClient(httpClient *http.Client) {
+	if r == nil {
+		return
+	}
+	httpClient.Transport = &traceTransport{base: httpClient.Transport, recorder: r}
+}
+
+// traceTransport is an http.RoundTripper that records the exchanges in the trace file
+type traceTransport struct {
+	base     http.RoundTripper
+	recorder *traceRecorder
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	entry := traceEntry{
+		Time:           time.Now().UTC(),
+		Method:         req.Method,
+		Path:           req.URL.Path,
+		Query:          redactQuery(req.URL.RawQuery),
+		RequestHeaders: logger.RedactHeaders(req.Header),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		entry.RequestBody = traceBody(req.Header.Get("Content-Type"), body)
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		entry.LatencyMs = latencyMs(time.Since(start))
+		entry.Error = err.Error()
+		t.recorder.record(entry)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	entry.LatencyMs = latencyMs(time.Since(start))
+	entry.Status = resp.StatusCode
+	entry.ResponseHeaders = logger.RedactHeaders(resp.Header)
+	if err != nil {
+		entry.Error = err.Error()
+		t.recorder.record(entry)
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	entry.ResponseBody = traceBody(resp.Header.Get("Content-Type"), body)
+	t.recorder.record(entry)
+
+	return resp, nil
+}
+
+// traceBody returns a redacted body as JSON, a body that is not JSON is written as a string
+func traceBody(contentType string, body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+
+	redacted := logger.RedactBody(contentType, body)
+	if json.Valid([]byte(redacted)) {
+		return json.RawMessage(redacted)
+	}
+	quoted, _ := json.Marshal(redacted)
+	return quoted
+}
+
+// traceValue returns a redacted value of the turbonomic client as JSON
+func traceValue(value any) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(value)
+	if err != nil || string(body) == "null" {
+		return nil
+	}
+	return traceBody("application/json", body)
+}
+
+// redactQuery masks the values of the sensitive parameters of a query string
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return logger.Redacted
+	}
+	for key := range values {
+		if logger.IsSensitiveKey(key) {
+			values[key] = []string{logger.Redacted}
+		}
+	}
+	return values.Encode()
+}
+
+// latencyMs returns a latency in milliseconds
+func latencyMs(latency time.Duration) float64 {
+	return float64(latency.Microseconds()) / 1000
+}
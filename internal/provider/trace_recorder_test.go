@@ -0,0 +1,190 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+// readTraceEntries reads the JSON lines of a trace file
+func readTraceEntries(t *testing.T, name string) []map[string]any {
+	file, err := os.Open(name)
+	assert.NoError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var entries []map[string]any
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Tests that a run writes a single trace file per directory
+func TestOpenTraceRecorder(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "traces")
+
+	recorder, err := openTraceRecorder(dir)
+	assert.NoError(t, err)
+	again, err := openTraceRecorder(dir)
+	assert.NoError(t, err)
+	assert.Same(t, recorder, again)
+	assert.Regexp(t, `turbonomic-trace-\d{8}T\d{6}Z-\d+\.jsonl$`, recorder.Name())
+
+	// the file is only open while an exchange is written
+	recorder.record(traceEntry{Operation: "GetEntity"})
+	assert.Len(t, readTraceEntries(t, recorder.Name()), 1)
+	if fds, err := os.ReadDir("/proc/self/fd"); err == nil {
+		for _, fd := range fds {
+			target, _ := os.Readlink(filepath.Join("/proc/self/fd", fd.Name()))
+			assert.NotEqual(t, recorder.Name(), target)
+		}
+	}
+
+	_, err = openTraceRecorder(writeSecretFile(t, "not a directory"))
+	assert.ErrorContains(t, err, "unable to create the debug trace directory")
+}
+
+// Tests that the requests of the turbonomic client are recorded by operation
+func TestTraceRecorderClientRequests(t *testing.T) {
+	recorder, err := openTraceRecorder(t.TempDir())
+	assert.NoError(t, err)
+
+	mockClient := new(MockT8cClient)
+	client := &requestClient{T8cClient: mockClient, ctx: context.Background(), requests: &clientRequests{recorder: recorder}}
+
+	mockClient.On("GetActionsByUUID", mock.Anything).Return(turboclient.ActionResults{
+		{ActionType: "SCALE", ActionState: "READY"},
+	}, nil)
+
+	_, errDiag := GetActions(client, WithEntityUuid("123"), WithActionTypes([]string{"SCALE"}))
+	assert.Nil(t, errDiag)
+
+	entries := readTraceEntries(t, recorder.Name())
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "GetActionsByUUID", entries[0]["operation"])
+	assert.Equal(t, true, entries[0]["synthetic"])
+	assert.Equal(t, http.MethodPost, entries[0]["method"])
+	assert.Equal(t, "/api/v3/entities/123/actions", entries[0]["path"])
+	assert.Equal(t, "123", entries[0]["request_body"].(map[string]any)["Uuid"])
+	assert.Equal(t, "SCALE", entries[0]["response_body"].([]any)[0].(map[string]any)["actionType"])
+	assert.Contains(t, entries[0], "latency_ms")
+}
+
+// Tests that the requests sent without the turbonomic client are recorded with their method, path,
+// headers and bodies, and that the credentials are masked
+func TestTraceRecorderHTTPRequests(t *testing.T) {
+	server := mockTurboServer(t, []MockRoute{
+		{
+			Method:       http.MethodPost,
+			Path:         "/api/v3/login",
+			ResponseBody: `{"username":"admin"}`,
+			ResponseCode: http.StatusOK,
+		},
+	})
+
+	recorder, err := openTraceRecorder(t.TempDir())
+	assert.NoError(t, err)
+
+	httpClient, err := newHTTPClient(true, "")
+	assert.NoError(t, err)
+	recorder.wrapClient(httpClient)
+
+	form := url.Values{"username": {"admin"}, "password": {"s3cr3t"}}
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v3/login?token=abc", strings.NewReader(form.Encode()))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Basic YWRtaW46czNjcjN0")
+
+	resp, err := httpClient.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+
+	entries := readTraceEntries(t, recorder.Name())
+	assert.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, http.MethodPost, entry["method"])
+	assert.Equal(t, "/api/v3/login", entry["path"])
+	assert.NotContains(t, entry, "synthetic")
+	assert.Equal(t, "token=%2A%2A%2A", entry["query"])
+	assert.Equal(t, float64(http.StatusOK), entry["status"])
+	assert.Equal(t, []any{"***"}, entry["request_headers"].(map[string]any)["Authorization"])
+	assert.Equal(t, "password=%2A%2A%2A&username=admin", entry["request_body"])
+	assert.Equal(t, map[string]any{"username": "admin"}, entry["response_body"])
+
+	content, err := os.ReadFile(recorder.Name())
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "s3cr3t")
+}
+
+// Tests that the login of the turbonomic client is recorded with its endpoint and masked credentials
+func TestTraceRecorderClientLogin(t *testing.T) {
+	recorder, err := openTraceRecorder(t.TempDir())
+	assert.NoError(t, err)
+
+	recorder.recordOperation("NewClient", turboclient.ClientParameters{Hostname: "turbo", Username: "admin", Password: "s3cr3t"}, nil, nil, time.Now())
+	recorder.recordOperation("NewClient", turboclient.ClientParameters{
+		Hostname:   "turbo",
+		OAuthCreds: turboclient.OAuthCreds{ClientId: "12345", ClientSecret: "s3cr3t"},
+	}, nil, errors.New("invalid_client"), time.Now())
+
+	entries := readTraceEntries(t, recorder.Name())
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "/api/v3/login", entries[0]["path"])
+	assert.Equal(t, "/oauth2/token", entries[1]["path"])
+	assert.Equal(t, "invalid_client", entries[1]["error"])
+
+	content, err := os.ReadFile(recorder.Name())
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "s3cr3t")
+}
+
+// failingBodyTransport returns a response whose body cannot be read
+type failingBodyTransport struct{}
+
+func (failingBodyTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(iotest.ErrReader(errors.New("connection reset by peer"))),
+	}, nil
+}
+
+// Tests that a response whose body cannot be read is recorded and returned as an error only
+func TestTraceTransportBodyReadError(t *testing.T) {
+	recorder, err := openTraceRecorder(t.TempDir())
+	assert.NoError(t, err)
+
+	transport := &traceTransport{base: failingBodyTransport{}, recorder: recorder}
+	req, err := http.NewRequest(http.MethodGet, "https://turbo/api/v3/admin/versioninfo", nil)
+	assert.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	assert.Nil(t, resp)
+	assert.ErrorContains(t, err, "connection reset by peer")
+
+	entries := readTraceEntries(t, recorder.Name())
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "connection reset by peer", entries[0]["error"])
+}
@@ -0,0 +1,685 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The payloads of the Turbonomic API, limited to the fields read by the provider
+
+type apiError struct {
+	Type      string `json:"type"`
+	Exception string `json:"exception"`
+	Message   string `json:"message"`
+}
+
+type apiDiscoveredBy struct {
+	DisplayName string `json:"displayName"`
+	Category    string `json:"category"`
+	Type        string `json:"type"`
+}
+
+type apiTemplate struct {
+	UUID        string `json:"uuid,omitempty"`
+	DisplayName string `json:"displayName"`
+	ClassName   string `json:"className,omitempty"`
+}
+
+type apiEntity struct {
+	UUID            string              `json:"uuid"`
+	DisplayName     string              `json:"displayName"`
+	ClassName       string              `json:"className"`
+	EnvironmentType string              `json:"environmentType"`
+	DiscoveredBy    *apiDiscoveredBy    `json:"discoveredBy,omitempty"`
+	VendorIds       map[string]string   `json:"vendorIds,omitempty"`
+	State           string              `json:"state"`
+	Template        *apiTemplate        `json:"template,omitempty"`
+	Tags            map[string][]string `json:"tags,omitempty"`
+}
+
+type apiTag struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+type apiFilter struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type apiStatValues struct {
+	Max   float64 `json:"max"`
+	Min   float64 `json:"min"`
+	Avg   float64 `json:"avg"`
+	Total float64 `json:"total"`
+}
+
+type apiStat struct {
+	Name     string         `json:"name"`
+	Units    string         `json:"units,omitempty"`
+	Value    float64        `json:"value"`
+	Values   *apiStatValues `json:"values,omitempty"`
+	Capacity *apiStatValues `json:"capacity,omitempty"`
+	Filters  []apiFilter    `json:"filters,omitempty"`
+}
+
+type apiStatSnapshot struct {
+	DisplayName string    `json:"displayName"`
+	Date        string    `json:"date"`
+	Statistics  []apiStat `json:"statistics"`
+}
+
+type apiRisk struct {
+	ReasonCommodities []string `json:"reasonCommodities,omitempty"`
+}
+
+type apiAction struct {
+	UUID                   string       `json:"uuid,omitempty"`
+	ActionID               int64        `json:"actionID,omitempty"`
+	ActionType             string       `json:"actionType"`
+	ActionState            string       `json:"actionState"`
+	ActionMode             string       `json:"actionMode"`
+	ActionStateDescription string       `json:"actionStateDescription,omitempty"`
+	Details                string       `json:"details,omitempty"`
+	Target                 apiEntity    `json:"target"`
+	CurrentEntity          *apiTemplate `json:"currentEntity,omitempty"`
+	NewEntity              *apiTemplate `json:"newEntity,omitempty"`
+	Template               *apiTemplate `json:"template,omitempty"`
+	CurrentValue           string       `json:"currentValue,omitempty"`
+	NewValue               string       `json:"newValue,omitempty"`
+	ValueUnits             string       `json:"valueUnits,omitempty"`
+	ResizeAttribute        string       `json:"resizeAttribute,omitempty"`
+	Risk                   apiRisk      `json:"risk"`
+	Stats                  []apiStat    `json:"stats,omitempty"`
+	CompoundActions        []apiAction  `json:"compoundActions,omitempty"`
+}
+
+type apiCriteria struct {
+	ExpType       string `json:"expType"`
+	ExpVal        string `json:"expVal"`
+	FilterType    string `json:"filterType"`
+	CaseSensitive bool   `json:"caseSensitive"`
+}
+
+type apiSearch struct {
+	ClassName       string        `json:"className"`
+	CriteriaList    []apiCriteria `json:"criteriaList"`
+	LogicalOperator string        `json:"logicalOperator"`
+	EnvironmentType string        `json:"environmentType"`
+	CloudType       string        `json:"cloudType"`
+	Scope           []string      `json:"scope"`
+}
+
+type apiActionsQuery struct {
+	ActionStateList []string `json:"actionStateList"`
+	ActionTypeList  []string `json:"actionTypeList"`
+}
+
+type apiStatsQuery struct {
+	StartDate  string `json:"startDate"`
+	EndDate    string `json:"endDate"`
+	Statistics []struct {
+		Name string `json:"name"`
+	} `json:"statistics"`
+}
+
+// statsSnapshotInterval is the interval of the historical snapshots of the stats
+const statsSnapshotInterval = time.Hour
+
+var relativeDateRegex = regexp.MustCompile(`^([+-]\d+)([mhdw])$`)
+
+// routes are the endpoints of the simulated Turbonomic API
+func (s *Simulator) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v3/login", s.login)
+	mux.HandleFunc("POST /oauth2/token", s.token)
+	mux.HandleFunc("GET /api/v3/admin/versioninfo", s.authenticated(s.versionInfo))
+	mux.HandleFunc("POST /api/v3/search", s.authenticated(s.search))
+	mux.HandleFunc("GET /api/v3/entities/{uuid}", s.authenticated(s.getEntity))
+	mux.HandleFunc("POST /api/v3/entities/{uuid}/actions", s.authenticated(s.entityActions))
+	mux.HandleFunc("GET /api/v3/entities/{uuid}/tags", s.authenticated(s.getTags))
+	mux.HandleFunc("POST /api/v3/entities/{uuid}/tags", s.authenticated(s.addTags))
+	mux.HandleFunc("DELETE /api/v3/entities/{uuid}/tags/{key}", s.authenticated(s.deleteTag))
+	mux.HandleFunc("POST /api/v3/stats/{uuid}", s.authenticated(s.stats))
+	mux.HandleFunc("GET /api/v3/actions/{uuid}", s.authenticated(s.getAction))
+	mux.HandleFunc("POST /api/v3/actions/{uuid}", s.authenticated(s.acceptAction))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not simulated", r.Method, r.URL.Path))
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body)})
+		s.mu.Unlock()
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authenticated rejects the requests without a session or an access token
+func (s *Simulator) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Simulator) login(w http.ResponseWriter, r *http.Request) {
+	username, password := r.FormValue("username"), r.FormValue("password")
+	if !slices.Contains(s.scenario.Users, User{Username: username, Password: password}) {
+		writeError(w, http.StatusUnauthorized, "The username or password is incorrect")
+		return
+	}
+
+	session := newSecret()
+	s.mu.Lock()
+	s.sessions[session] = true
+	s.mu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{Name: SessionCookie, Value: session, Path: "/", HttpOnly: true, Secure: true})
+	writeJSON(w, http.StatusOK, map[string]string{"username": username, "uuid": session})
+}
+
+func (s *Simulator) token(w http.ResponseWriter, r *http.Request) {
+	clientID, secret, role := r.FormValue("client_id"), r.FormValue("client_secret"), r.FormValue("role")
+	valid := r.FormValue("grant_type") == "client_credentials" &&
+		slices.ContainsFunc(s.scenario.Clients, func(c Client) bool {
+			return c.ClientID == clientID && c.ClientSecret == secret && (c.Role == "" || strings.EqualFold(c.Role, role))
+		})
+	if !valid {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	token := newSecret()
+	s.mu.Lock()
+	s.tokens[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   600,
+		"scope":        "role:" + role,
+	})
+}
+
+func (s *Simulator) versionInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"version":     s.scenario.Version,
+		"versionInfo": "Turbonomic Operations Manager " + s.scenario.Version,
+	})
+}
+
+func (s *Simulator) search(w http.ResponseWriter, r *http.Request) {
+	var query apiSearch
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid search request: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := []apiEntity{}
+	for i := range s.scenario.Entities {
+		entity := &s.scenario.Entities[i]
+		matched, err := matchSearch(entity, query)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if matched {
+			results = append(results, toAPIEntity(entity))
+		}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Simulator) getEntity(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity := s.entity(r.PathValue("uuid"))
+	if entity == nil {
+		writeError(w, http.StatusNotFound, "entity not found: "+r.PathValue("uuid"))
+		return
+	}
+	writeJSON(w, http.StatusOK, toAPIEntity(entity))
+}
+
+func (s *Simulator) entityActions(w http.ResponseWriter, r *http.Request) {
+	var query apiActionsQuery
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "invalid action request: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity := s.entity(r.PathValue("uuid"))
+	if entity == nil {
+		writeError(w, http.StatusNotFound, "entity not found: "+r.PathValue("uuid"))
+		return
+	}
+
+	results := []apiAction{}
+	for i := range entity.Actions {
+		action := &entity.Actions[i]
+		if len(query.ActionStateList) == 0 && isTerminalState(action.State) ||
+			len(query.ActionStateList) > 0 && !containsFold(query.ActionStateList, action.State) ||
+			len(query.ActionTypeList) > 0 && !containsFold(query.ActionTypeList, action.Type) {
+			continue
+		}
+		results = append(results, toAPIAction(entity, action))
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Simulator) getTags(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity := s.entity(r.PathValue("uuid"))
+	if entity == nil {
+		writeError(w, http.StatusNotFound, "entity not found: "+r.PathValue("uuid"))
+		return
+	}
+	writeJSON(w, http.StatusOK, toAPITags(entity.Tags))
+}
+
+func (s *Simulator) addTags(w http.ResponseWriter, r *http.Request) {
+	var tags []apiTag
+	if err := json.NewDecoder(r.Body).Decode(&tags); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid tag request: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity := s.entity(r.PathValue("uuid"))
+	if entity == nil {
+		writeError(w, http.StatusNotFound, "entity not found: "+r.PathValue("uuid"))
+		return
+	}
+	for _, tag := range tags {
+		if _, ok := entity.Tags[tag.Key]; ok {
+			writeError(w, http.StatusBadRequest, TagAlreadyExistsMessage)
+			return
+		}
+	}
+
+	if entity.Tags == nil {
+		entity.Tags = map[string][]string{}
+	}
+	for _, tag := range tags {
+		entity.Tags[tag.Key] = tag.Values
+	}
+	writeJSON(w, http.StatusOK, toAPITags(entity.Tags))
+}
+
+func (s *Simulator) deleteTag(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity := s.entity(r.PathValue("uuid"))
+	if entity == nil {
+		writeError(w, http.StatusNotFound, "entity not found: "+r.PathValue("uuid"))
+		return
+	}
+	if _, ok := entity.Tags[r.PathValue("key")]; !ok {
+		writeError(w, http.StatusNotFound, "tag not found: "+r.PathValue("key"))
+		return
+	}
+	delete(entity.Tags, r.PathValue("key"))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Simulator) stats(w http.ResponseWriter, r *http.Request) {
+	var query apiStatsQuery
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "invalid stats request: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity := s.entity(r.PathValue("uuid"))
+	if entity == nil {
+		writeError(w, http.StatusNotFound, "entity not found: "+r.PathValue("uuid"))
+		return
+	}
+
+	now := time.Now().UTC()
+	dates, err := statsSnapshotDates(query.StartDate, query.EndDate, now)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid stats period: "+err.Error())
+		return
+	}
+
+	// the statistics are returned by name, whatever their filters, as Turbonomic returns the sold
+	// and the projected values of a commodity
+	names := []string{}
+	for _, stat := range query.Statistics {
+		names = append(names, stat.Name)
+	}
+	snapshots := []apiStatSnapshot{}
+	for i, date := range dates {
+		statistics := []apiStat{}
+		for _, stat := range entity.Stats {
+			if len(names) == 0 || containsFold(names, stat.Name) {
+				if len(stat.Samples) > 0 {
+					stat.Value = stat.Samples[i%len(stat.Samples)]
+				}
+				statistics = append(statistics, toAPIStat(stat))
+			}
+		}
+		snapshots = append(snapshots, apiStatSnapshot{
+			DisplayName: entity.Name,
+			Date:        date.Format(time.RFC3339),
+			Statistics:  statistics,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, snapshots)
+}
+
+/*
+statsSnapshotDates returns the dates of the snapshots of a stats request, oldest first. A request
+without a start date only returns the current snapshot, otherwise a snapshot is returned for every
+interval of the period that is not in the future, ending at the end date or now.
+
+Parameters:
+  - startDate: The start date of the request, relative to now, E.G: -7d, or absolute
+  - endDate: The end date of the request, relative to now, E.G: +10m, or absolute
+  - now: The time of the request
+
+Returns:
+  - []time.Time: The dates of the snapshots
+  - error: An error if a date can not be parsed
+*/
+func statsSnapshotDates(startDate string, endDate string, now time.Time) ([]time.Time, error) {
+	if startDate == "" {
+		return []time.Time{now}, nil
+	}
+
+	start, err := parseStatsDate(startDate, now)
+	if err != nil {
+		return nil, err
+	}
+	end := now
+	if endDate != "" {
+		if end, err = parseStatsDate(endDate, now); err != nil {
+			return nil, err
+		}
+	}
+	if end.After(now) {
+		end = now
+	}
+	if start.After(end) {
+		return nil, fmt.Errorf("the start date %s is after the end date", startDate)
+	}
+
+	dates := []time.Time{}
+	for date := end; !date.Before(start); date = date.Add(-statsSnapshotInterval) {
+		dates = append(dates, date)
+	}
+	slices.Reverse(dates)
+	return dates, nil
+}
+
+// parseStatsDate parses a date of a stats request, relative to now in minutes, hours, days or weeks,
+// E.G: -7d, or absolute in epoch milliseconds, RFC 3339 or YYYY-MM-DD
+func parseStatsDate(date string, now time.Time) (time.Time, error) {
+	if match := relativeDateRegex.FindStringSubmatch(date); match != nil {
+		count, _ := strconv.Atoi(match[1])
+		unit := map[string]time.Duration{"m": time.Minute, "h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}[match[2]]
+		return now.Add(time.Duration(count) * unit), nil
+	}
+	if millis, err := strconv.ParseInt(date, 10, 64); err == nil {
+		return time.UnixMilli(millis).UTC(), nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, date); err == nil {
+		return parsed.UTC(), nil
+	}
+	if parsed, err := time.Parse(time.DateOnly, date); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("unable to parse the date %s", date)
+}
+
+func (s *Simulator) getAction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity, action := s.action(r.PathValue("uuid"))
+	if action == nil {
+		writeError(w, http.StatusNotFound, "action not found: "+r.PathValue("uuid"))
+		return
+	}
+	writeJSON(w, http.StatusOK, toAPIAction(entity, action))
+}
+
+func (s *Simulator) acceptAction(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("accept") != "true" {
+		writeError(w, http.StatusBadRequest, "only the accept=true action requests are simulated")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity, err := s.executeAction(r.PathValue("uuid"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	_, action := s.action(r.PathValue("uuid"))
+	writeJSON(w, http.StatusOK, toAPIAction(entity, action))
+}
+
+/*
+matchSearch checks an entity against the class, environment, cloud type, scope and criteria of
+a search request.
+
+Parameters:
+  - entity: The entity to check
+  - query: The search request
+
+Returns:
+  - bool: Whether the entity matches the search request
+  - error: An error if a criteria is not simulated
+*/
+func matchSearch(entity *Entity, query apiSearch) (bool, error) {
+	if query.ClassName != "" && !strings.EqualFold(query.ClassName, entity.ClassName) {
+		return false, nil
+	}
+	if query.EnvironmentType != "" && !strings.EqualFold(query.EnvironmentType, "HYBRID") &&
+		!strings.EqualFold(query.EnvironmentType, entity.EnvironmentType) {
+		return false, nil
+	}
+	if query.CloudType != "" && !strings.EqualFold(query.CloudType, entity.CloudType) {
+		return false, nil
+	}
+	if len(query.Scope) > 0 && !slices.Contains(query.Scope, entity.UUID) &&
+		!slices.ContainsFunc(entity.Scopes, func(scope string) bool { return slices.Contains(query.Scope, scope) }) {
+		return false, nil
+	}
+
+	anyOf := strings.EqualFold(query.LogicalOperator, "OR")
+	for _, criteria := range query.CriteriaList {
+		matched, err := matchCriteria(entity, criteria)
+		if err != nil {
+			return false, err
+		}
+		if anyOf && matched {
+			return true, nil
+		}
+		if !anyOf && !matched {
+			return false, nil
+		}
+	}
+	return !anyOf || len(query.CriteriaList) == 0, nil
+}
+
+// matchCriteria checks an entity against a criteria, the field is chosen by the suffix of the
+// filter type, E.G: vmsByName or volumeByName
+func matchCriteria(entity *Entity, criteria apiCriteria) (bool, error) {
+	var values []string
+	filterType := strings.ToLower(criteria.FilterType)
+	switch {
+	case strings.HasSuffix(filterType, "byname"):
+		values = []string{entity.Name}
+	case strings.HasSuffix(filterType, "byvendorid"):
+		for _, vendorID := range entity.VendorIDs {
+			values = append(values, vendorID)
+		}
+	case strings.HasSuffix(filterType, "bycloudprovider"):
+		values = []string{entity.CloudType}
+	case strings.HasSuffix(filterType, "byguestname"):
+		values = []string{entity.OSName}
+	default:
+		return false, fmt.Errorf("the filter type %s is not simulated", criteria.FilterType)
+	}
+
+	var match func(string) bool
+	switch strings.ToUpper(criteria.ExpType) {
+	case "EQ", "NEQ":
+		match = func(value string) bool {
+			return value == criteria.ExpVal || !criteria.CaseSensitive && strings.EqualFold(value, criteria.ExpVal)
+		}
+	case "RXEQ", "RXNEQ":
+		pattern := "^(?:" + criteria.ExpVal + ")$"
+		if !criteria.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid expression %s: %v", criteria.ExpVal, err)
+		}
+		match = re.MatchString
+	default:
+		return false, fmt.Errorf("the expression type %s is not simulated", criteria.ExpType)
+	}
+
+	matched := slices.ContainsFunc(values, match)
+	if strings.HasPrefix(strings.ToUpper(criteria.ExpType), "RXN") || strings.EqualFold(criteria.ExpType, "NEQ") {
+		return !matched, nil
+	}
+	return matched, nil
+}
+
+func toAPIEntity(entity *Entity) apiEntity {
+	result := apiEntity{
+		UUID:            entity.UUID,
+		DisplayName:     entity.Name,
+		ClassName:       entity.ClassName,
+		EnvironmentType: entity.EnvironmentType,
+		VendorIds:       entity.VendorIDs,
+		State:           "ACTIVE",
+		Tags:            entity.Tags,
+	}
+	if entity.CloudType != "" {
+		result.DiscoveredBy = &apiDiscoveredBy{DisplayName: entity.CloudType, Category: "Public Cloud", Type: entity.CloudType}
+	}
+	if entity.Template != "" {
+		result.Template = &apiTemplate{DisplayName: entity.Template}
+	}
+	return result
+}
+
+func toAPIAction(entity *Entity, action *Action) apiAction {
+	actionID, _ := strconv.ParseInt(action.UUID, 10, 64)
+	result := apiAction{
+		UUID:                   action.UUID,
+		ActionID:               actionID,
+		ActionType:             action.Type,
+		ActionState:            action.State,
+		ActionMode:             action.Mode,
+		ActionStateDescription: action.StateDescription,
+		Details:                action.Details,
+		Target:                 toAPIEntity(entity),
+		CurrentEntity:          toAPITier(action.CurrentEntity),
+		NewEntity:              toAPITier(action.NewEntity),
+		Template:               toAPITier(action.NewEntity),
+		CurrentValue:           action.CurrentValue,
+		NewValue:               action.NewValue,
+		ValueUnits:             action.ValueUnits,
+		ResizeAttribute:        action.ResizeAttribute,
+		Risk:                   apiRisk{ReasonCommodities: action.ReasonCommodities},
+	}
+	for _, stat := range action.Stats {
+		result.Stats = append(result.Stats, toAPIStat(stat))
+	}
+	for i := range action.CompoundActions {
+		result.CompoundActions = append(result.CompoundActions, toAPIAction(entity, &action.CompoundActions[i]))
+	}
+	return result
+}
+
+func toAPITier(tier *Tier) *apiTemplate {
+	if tier == nil {
+		return nil
+	}
+	return &apiTemplate{UUID: tier.UUID, DisplayName: tier.Name, ClassName: tier.ClassName}
+}
+
+func toAPIStat(stat Stat) apiStat {
+	result := apiStat{
+		Name:   stat.Name,
+		Units:  stat.Units,
+		Value:  stat.Value,
+		Values: &apiStatValues{Max: stat.Value, Min: stat.Value, Avg: stat.Value, Total: stat.Value},
+	}
+	if stat.Capacity != 0 {
+		result.Capacity = &apiStatValues{Max: stat.Capacity, Min: stat.Capacity, Avg: stat.Capacity, Total: stat.Capacity}
+	}
+	for filterType, value := range stat.Filters {
+		result.Filters = append(result.Filters, apiFilter{Type: filterType, Value: value})
+	}
+	sort.Slice(result.Filters, func(i, j int) bool { return result.Filters[i].Type < result.Filters[j].Type })
+	return result
+}
+
+func toAPITags(tags map[string][]string) []apiTag {
+	result := []apiTag{}
+	for key, values := range tags {
+		result = append(result, apiTag{Key: key, Values: values})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiError{Type: "Error", Exception: http.StatusText(status), Message: message})
+}
@@ -0,0 +1,179 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultVersion is the version of the simulated Turbonomic instance when a scenario does not set one
+	DefaultVersion = "8.14.3"
+)
+
+// Scenario is the initial state of a simulated Turbonomic instance
+type Scenario struct {
+	// Version is the version reported by the admin/versioninfo endpoint
+	Version string `yaml:"version"`
+	// Users are the local users that can log in with a username and password
+	Users []User `yaml:"users"`
+	// Clients are the OAuth 2.0 clients that can request an access token
+	Clients []Client `yaml:"clients"`
+	// Entities are the entities of the instance, with their actions and stats
+	Entities []Entity `yaml:"entities"`
+}
+
+// User is a local user of the simulated instance
+type User struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// Client is an OAuth 2.0 client of the simulated instance
+type Client struct {
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	Role         string `yaml:"role"`
+}
+
+// Entity is an entity of the simulated instance
+type Entity struct {
+	UUID            string `yaml:"uuid"`
+	Name            string `yaml:"name"`
+	ClassName       string `yaml:"class_name"`
+	EnvironmentType string `yaml:"environment_type"`
+	// CloudType is the type of the probe that discovered the entity, E.G: AWS, AZURE, GCP
+	CloudType string `yaml:"cloud_type"`
+	// OSName is the guest OS of a virtual machine, matched by the guest name search filters
+	OSName string `yaml:"os_name"`
+	// Template is the current size of the entity, E.G: t2.micro or db.t4g.medium-gp3
+	Template  string            `yaml:"template"`
+	VendorIDs map[string]string `yaml:"vendor_ids"`
+	// Scopes are the uuids of the accounts, regions and groups that contain the entity
+	Scopes  []string            `yaml:"scopes"`
+	Tags    map[string][]string `yaml:"tags"`
+	Stats   []Stat              `yaml:"stats"`
+	Actions []Action            `yaml:"actions"`
+}
+
+// Stat is a statistic of an entity or an action
+type Stat struct {
+	Name     string  `yaml:"name"`
+	Units    string  `yaml:"units"`
+	Value    float64 `yaml:"value"`
+	Capacity float64 `yaml:"capacity"`
+	// Samples are the values of the historical snapshots, repeated over the period of a stats request,
+	// the value is used for every snapshot when they are not set
+	Samples []float64         `yaml:"samples"`
+	Filters map[string]string `yaml:"filters"`
+}
+
+// Action is an action of an entity, or a compound action of an action
+type Action struct {
+	UUID  string `yaml:"uuid"`
+	Type  string `yaml:"type"`
+	State string `yaml:"state"`
+	Mode  string `yaml:"mode"`
+	// StateDescription is the reason an action can not be executed, E.G: READY_ACCEPT_AND_EXECUTE
+	StateDescription  string   `yaml:"state_description"`
+	Details           string   `yaml:"details"`
+	CurrentEntity     *Tier    `yaml:"current_entity"`
+	NewEntity         *Tier    `yaml:"new_entity"`
+	CurrentValue      string   `yaml:"current_value"`
+	NewValue          string   `yaml:"new_value"`
+	ValueUnits        string   `yaml:"value_units"`
+	ResizeAttribute   string   `yaml:"resize_attribute"`
+	ReasonCommodities []string `yaml:"reason_commodities"`
+	CompoundActions   []Action `yaml:"compound_actions"`
+	Stats             []Stat   `yaml:"stats"`
+}
+
+// Tier is the current or new tier of an action
+type Tier struct {
+	UUID      string `yaml:"uuid"`
+	Name      string `yaml:"name"`
+	ClassName string `yaml:"class_name"`
+}
+
+/*
+LoadScenario reads a YAML scenario file.
+
+Parameters:
+  - name: The path of the scenario file
+
+Returns:
+  - *Scenario: The scenario, with its defaults applied
+  - error: An error if the file can not be read or is not a valid scenario
+*/
+func LoadScenario(name string) (*Scenario, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the scenario file: %w", err)
+	}
+
+	scenario, err := ParseScenario(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", filepath.Base(name), err)
+	}
+	return scenario, nil
+}
+
+// ParseScenario parses a YAML scenario, applies its defaults and validates it
+func ParseScenario(content []byte) (*Scenario, error) {
+	var scenario Scenario
+	if err := yaml.Unmarshal(content, &scenario); err != nil {
+		return nil, err
+	}
+
+	if scenario.Version == "" {
+		scenario.Version = DefaultVersion
+	}
+
+	entities, actions := map[string]bool{}, map[string]bool{}
+	for i := range scenario.Entities {
+		entity := &scenario.Entities[i]
+		if entity.UUID == "" || entity.Name == "" || entity.ClassName == "" {
+			return nil, fmt.Errorf("entity %d requires a uuid, a name and a class_name", i)
+		}
+		if entities[entity.UUID] {
+			return nil, fmt.Errorf("duplicate entity uuid: %s", entity.UUID)
+		}
+		entities[entity.UUID] = true
+		if entity.EnvironmentType == "" {
+			entity.EnvironmentType = "CLOUD"
+		}
+
+		for j := range entity.Actions {
+			action := &entity.Actions[j]
+			if action.UUID == "" || action.Type == "" {
+				return nil, fmt.Errorf("action %d of entity %s requires a uuid and a type", j, entity.UUID)
+			}
+			if actions[action.UUID] {
+				return nil, fmt.Errorf("duplicate action uuid: %s", action.UUID)
+			}
+			actions[action.UUID] = true
+			applyActionDefaults(action)
+		}
+	}
+	return &scenario, nil
+}
+
+// applyActionDefaults sets the state and mode of an action and its compound actions when unset
+func applyActionDefaults(action *Action) {
+	action.Type = strings.ToUpper(action.Type)
+	if action.State == "" {
+		action.State = "READY"
+	}
+	if action.Mode == "" {
+		action.Mode = "MANUAL"
+	}
+	for i := range action.CompoundActions {
+		applyActionDefaults(&action.CompoundActions[i])
+	}
+}
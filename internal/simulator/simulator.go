@@ -0,0 +1,206 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package simulator is an in-process Turbonomic API seeded from YAML scenarios, used to run the
+acceptance tests of the provider without a Turbonomic instance.
+
+The simulator keeps the state of the instance: the tags added by the provider are returned by
+the following requests, and an executed action resizes its entity and leaves the pending actions.
+*/
+package simulator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const (
+	// SessionCookie is the cookie of the sessions opened by a login
+	SessionCookie = "JSESSIONID"
+	// TagAlreadyExistsMessage is the error of a tag request with a key the entity already has
+	TagAlreadyExistsMessage = "INVALID_ARGUMENT: Trying to insert a tag with a key that already exists"
+)
+
+// terminalActionStates are the states of the actions that are no longer pending
+var terminalActionStates = []string{"SUCCEEDED", "FAILED", "CLEARED", "REJECTED"}
+
+// Request is a request received by the simulator
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// Simulator is a Turbonomic instance served over TLS by an httptest server
+type Simulator struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	scenario *Scenario
+	sessions map[string]bool
+	tokens   map[string]bool
+	requests []Request
+}
+
+/*
+New starts a simulator with the state of a scenario. The simulator updates the entities of the
+scenario as they are tagged and as their actions are executed.
+
+Parameters:
+  - scenario: The initial state of the simulated instance
+
+Returns:
+  - *Simulator: The started simulator, to close once the tests are done
+*/
+func New(scenario *Scenario) *Simulator {
+	s := &Simulator{
+		scenario: scenario,
+		sessions: map[string]bool{},
+		tokens:   map[string]bool{},
+	}
+	s.Server = httptest.NewTLSServer(s.routes())
+	return s
+}
+
+// Hostname is the host and port of the simulator, as set in the hostname of the provider
+func (s *Simulator) Hostname() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// Requests are the requests received by the simulator, in order
+func (s *Simulator) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Entity returns a copy of the current state of an entity
+func (s *Simulator) Entity(uuid string) (Entity, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity := s.entity(uuid)
+	if entity == nil {
+		return Entity{}, false
+	}
+	return *entity, true
+}
+
+/*
+ExecuteAction executes a pending action as if it was accepted in Turbonomic: the action succeeds,
+and its entity moves from the current to the new tier of the action and of its compound actions.
+
+Parameters:
+  - uuid: The uuid of the action
+
+Returns:
+  - error: An error if the action does not exist or can not be executed
+*/
+func (s *Simulator) ExecuteAction(uuid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.executeAction(uuid)
+	return err
+}
+
+func (s *Simulator) executeAction(uuid string) (*Entity, error) {
+	entity, action := s.action(uuid)
+	if action == nil {
+		return nil, fmt.Errorf("action %s not found", uuid)
+	}
+	if isTerminalState(action.State) {
+		return nil, fmt.Errorf("action %s is %s", uuid, action.State)
+	}
+	if action.Mode != "MANUAL" && action.Mode != "AUTOMATIC" && action.Mode != "EXTERNAL_APPROVAL" {
+		return nil, fmt.Errorf("action %s in mode %s can not be executed", uuid, action.Mode)
+	}
+
+	action.State = "SUCCEEDED"
+	resize(entity, action)
+	for i := range action.CompoundActions {
+		action.CompoundActions[i].State = "SUCCEEDED"
+		resize(entity, &action.CompoundActions[i])
+	}
+	return entity, nil
+}
+
+// resize moves an entity to the new tier of an action, the tiers of a compound template like
+// db.t4g.medium-gp3 are replaced one at a time
+func resize(entity *Entity, action *Action) {
+	if action.CurrentEntity == nil || action.NewEntity == nil {
+		return
+	}
+
+	if entity.Template == "" || strings.EqualFold(entity.Template, action.CurrentEntity.Name) {
+		entity.Template = action.NewEntity.Name
+		return
+	}
+
+	parts := strings.Split(entity.Template, "-")
+	for i, part := range parts {
+		if strings.EqualFold(part, action.CurrentEntity.Name) {
+			parts[i] = action.NewEntity.Name
+		}
+	}
+	entity.Template = strings.Join(parts, "-")
+}
+
+// entity returns the entity of a uuid, the caller holds the lock
+func (s *Simulator) entity(uuid string) *Entity {
+	for i := range s.scenario.Entities {
+		if s.scenario.Entities[i].UUID == uuid {
+			return &s.scenario.Entities[i]
+		}
+	}
+	return nil
+}
+
+// action returns the action of a uuid and its entity, the caller holds the lock
+func (s *Simulator) action(uuid string) (*Entity, *Action) {
+	for i := range s.scenario.Entities {
+		entity := &s.scenario.Entities[i]
+		for j := range entity.Actions {
+			if entity.Actions[j].UUID == uuid {
+				return entity, &entity.Actions[j]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// newSecret returns a random session id or access token
+func newSecret() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func isTerminalState(state string) bool {
+	for _, terminal := range terminalActionStates {
+		if strings.EqualFold(state, terminal) {
+			return true
+		}
+	}
+	return false
+}
+
+// authorized checks the session cookie or the bearer token of a request
+func (s *Simulator) authorized(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cookie, err := r.Cookie(SessionCookie); err == nil && s.sessions[cookie.Value] {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && s.tokens[token]
+}
@@ -0,0 +1,399 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testScenario = `
+users:
+  - username: administrator
+    password: s3cr3t
+clients:
+  - client_id: terraform
+    client_secret: cl13nt
+    role: OBSERVER
+entities:
+  - uuid: "75919430323056"
+    name: testRDS
+    class_name: DatabaseServer
+    cloud_type: AWS
+    template: db.t4g.medium-gp3
+    vendor_ids:
+      engineering.aws.amazon.com: arn:aws:rds:us-east-2:111111111111:db:testRDS
+    stats:
+      - name: StorageAccess
+        units: IOPS
+        value: 0.283
+        capacity: 3000
+        filters:
+          relation: sold
+      - name: StorageAmount
+        units: MB
+        value: 2048
+        samples: [1024, 2048, 4096]
+    actions:
+      - uuid: "638877190762822"
+        type: scale
+        compound_actions:
+          - type: SCALE
+            current_entity: {name: db.t4g.medium, class_name: ComputeTier}
+            new_entity: {name: db.t4g.small, class_name: ComputeTier}
+          - type: SCALE
+            current_entity: {name: gp3, class_name: StorageTier}
+            new_entity: {name: standard, class_name: StorageTier}
+  - uuid: "75919430323057"
+    name: linux-vm
+    class_name: VirtualMachine
+    cloud_type: AZURE
+    os_name: LINUX
+    template: Standard_B1s
+    tags:
+      owner: [infra]
+`
+
+// newTestSimulator starts a simulator of the test scenario and returns it with a client that keeps
+// the session cookie
+func newTestSimulator(t *testing.T) (*Simulator, *http.Client) {
+	scenario, err := ParseScenario([]byte(testScenario))
+	require.NoError(t, err)
+
+	sim := New(scenario)
+	t.Cleanup(sim.Close)
+
+	client := sim.Client()
+	client.Jar, _ = cookiejar.New(nil)
+	return sim, client
+}
+
+// login opens a session of the test user
+func login(t *testing.T, sim *Simulator, client *http.Client) {
+	resp, err := client.PostForm(sim.URL+"/api/v3/login", url.Values{"username": {"administrator"}, "password": {"s3cr3t"}})
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// call sends a request to the simulator and decodes its JSON response
+func call(t *testing.T, client *http.Client, method string, url string, body string, result any) int {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	if result != nil {
+		require.NoError(t, json.Unmarshal(content, result), string(content))
+	}
+	return resp.StatusCode
+}
+
+// Tests the defaults and the validation of the scenarios
+func TestParseScenario(t *testing.T) {
+	scenario, err := ParseScenario([]byte(testScenario))
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultVersion, scenario.Version)
+	assert.Equal(t, "CLOUD", scenario.Entities[0].EnvironmentType)
+	assert.Equal(t, "SCALE", scenario.Entities[0].Actions[0].Type)
+	assert.Equal(t, "READY", scenario.Entities[0].Actions[0].State)
+	assert.Equal(t, "MANUAL", scenario.Entities[0].Actions[0].CompoundActions[1].Mode)
+
+	for _, tc := range []struct {
+		name          string
+		scenario      string
+		expectedError string
+	}{
+		{
+			name:          "entity without a class",
+			scenario:      "entities: [{uuid: '1', name: vm}]",
+			expectedError: "entity 0 requires a uuid, a name and a class_name",
+		},
+		{
+			name:          "duplicate entity",
+			scenario:      "entities: [{uuid: '1', name: vm, class_name: VirtualMachine}, {uuid: '1', name: vm2, class_name: VirtualMachine}]",
+			expectedError: "duplicate entity uuid: 1",
+		},
+		{
+			name:          "duplicate action",
+			scenario:      "entities: [{uuid: '1', name: vm, class_name: VirtualMachine, actions: [{uuid: '2', type: SCALE}, {uuid: '2', type: SCALE}]}]",
+			expectedError: "duplicate action uuid: 2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseScenario([]byte(tc.scenario))
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+
+	_, err = LoadScenario("testdata/missing.yaml")
+	assert.ErrorContains(t, err, "unable to read the scenario file")
+}
+
+// Tests that the API requires a session or an access token
+func TestSimulatorAuthentication(t *testing.T) {
+	sim, client := newTestSimulator(t)
+
+	assert.Equal(t, http.StatusUnauthorized, call(t, client, http.MethodGet, sim.URL+"/api/v3/admin/versioninfo", "", nil))
+
+	resp, err := client.PostForm(sim.URL+"/api/v3/login", url.Values{"username": {"administrator"}, "password": {"wrong"}})
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	login(t, sim, client)
+	var version map[string]string
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodGet, sim.URL+"/api/v3/admin/versioninfo", "", &version))
+	assert.Equal(t, "8.14.3", version["version"])
+
+	var token map[string]any
+	form := url.Values{"client_id": {"terraform"}, "client_secret": {"cl13nt"}, "role": {"OBSERVER"}, "grant_type": {"client_credentials"}}
+	resp, err = sim.Client().PostForm(sim.URL+"/oauth2/token", form)
+	assert.NoError(t, err)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&token))
+	_ = resp.Body.Close()
+	assert.Equal(t, "Bearer", token["token_type"])
+
+	req, err := http.NewRequest(http.MethodGet, sim.URL+"/api/v3/admin/versioninfo", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token["access_token"].(string))
+	resp, err = sim.Client().Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// Tests the criteria of the search requests
+func TestSimulatorSearch(t *testing.T) {
+	sim, client := newTestSimulator(t)
+	login(t, sim, client)
+
+	for _, tc := range []struct {
+		name           string
+		search         string
+		expectedStatus int
+		expectedNames  []string
+	}{
+		{
+			name:           "by name",
+			search:         `{"criteriaList":[{"expType":"EQ","expVal":"testRDS","filterType":"databaseServerByName","caseSensitive":true}],"logicalOperator":"AND","className":"DatabaseServer","environmentType":"CLOUD"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"testRDS"},
+		},
+		{
+			name:           "case sensitive name",
+			search:         `{"criteriaList":[{"expType":"EQ","expVal":"testrds","filterType":"databaseServerByName","caseSensitive":true}],"logicalOperator":"AND"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{},
+		},
+		{
+			name:           "by vendor id",
+			search:         `{"criteriaList":[{"expType":"EQ","expVal":"arn:aws:rds:us-east-2:111111111111:db:testRDS","filterType":"databaseServerByVendorId"}],"logicalOperator":"AND"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"testRDS"},
+		},
+		{
+			name:           "by cloud provider and guest name",
+			search:         `{"criteriaList":[{"expType":"EQ","expVal":"AZURE","filterType":"vmsByCloudProvider"},{"expType":"RXEQ","expVal":"linux.*|rhel.*","filterType":"vmsByGuestName"}],"logicalOperator":"AND","className":"VirtualMachine"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"linux-vm"},
+		},
+		{
+			name:           "by scope",
+			search:         `{"criteriaList":[],"logicalOperator":"AND","scope":["75919430323057"]}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"linux-vm"},
+		},
+		{
+			name:           "filter type not simulated",
+			search:         `{"criteriaList":[{"expType":"EQ","expVal":"x","filterType":"vmsByState"}],"logicalOperator":"AND"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus != http.StatusOK {
+				assert.Equal(t, tc.expectedStatus, call(t, client, http.MethodPost, sim.URL+"/api/v3/search", tc.search, nil))
+				return
+			}
+			var results []map[string]any
+			assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, sim.URL+"/api/v3/search", tc.search, &results))
+			names := []string{}
+			for _, result := range results {
+				names = append(names, result["displayName"].(string))
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+// Tests that the stats of an entity are returned for every snapshot of the requested period
+func TestSimulatorStatsPeriod(t *testing.T) {
+	sim, client := newTestSimulator(t)
+	login(t, sim, client)
+	statsURL := sim.URL + "/api/v3/stats/75919430323056"
+
+	var snapshots []apiStatSnapshot
+	body := `{"startDate":"2026-01-01T00:00:00Z","endDate":"2026-01-01T05:00:00Z","statistics":[{"name":"StorageAmount"}]}`
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, statsURL, body, &snapshots))
+	assert.Len(t, snapshots, 6)
+	dates := []string{}
+	values := []float64{}
+	for _, snapshot := range snapshots {
+		dates = append(dates, snapshot.Date)
+		require.Len(t, snapshot.Statistics, 1)
+		values = append(values, snapshot.Statistics[0].Values.Avg)
+	}
+	assert.Equal(t, "2026-01-01T00:00:00Z", dates[0])
+	assert.Equal(t, "2026-01-01T05:00:00Z", dates[5])
+	assert.Equal(t, []float64{1024, 2048, 4096, 1024, 2048, 4096}, values)
+
+	for _, tc := range []struct {
+		name              string
+		body              string
+		expectedStatus    int
+		expectedSnapshots int
+	}{
+		{
+			name:              "relative start date",
+			body:              `{"startDate":"-1d","statistics":[{"name":"StorageAmount"}]}`,
+			expectedStatus:    http.StatusOK,
+			expectedSnapshots: 25,
+		},
+		{
+			name:              "end date in the future",
+			body:              `{"startDate":"-2h","endDate":"+10m","statistics":[{"name":"StorageAmount"}]}`,
+			expectedStatus:    http.StatusOK,
+			expectedSnapshots: 3,
+		},
+		{
+			name:              "epoch milliseconds",
+			body:              `{"startDate":"1767225600000","endDate":"1767232800000"}`,
+			expectedStatus:    http.StatusOK,
+			expectedSnapshots: 3,
+		},
+		{
+			name:              "no start date",
+			body:              `{"endDate":"-1d"}`,
+			expectedStatus:    http.StatusOK,
+			expectedSnapshots: 1,
+		},
+		{
+			name:           "invalid date",
+			body:           `{"startDate":"yesterday"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "start date after the end date",
+			body:           `{"startDate":"-1d","endDate":"-2d"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus != http.StatusOK {
+				assert.Equal(t, tc.expectedStatus, call(t, client, http.MethodPost, statsURL, tc.body, nil))
+				return
+			}
+			var snapshots []apiStatSnapshot
+			assert.Equal(t, tc.expectedStatus, call(t, client, http.MethodPost, statsURL, tc.body, &snapshots))
+			assert.Len(t, snapshots, tc.expectedSnapshots)
+		})
+	}
+}
+
+// Tests that the tags added to an entity are kept, that an existing key is rejected and that a tag can be deleted
+func TestSimulatorTags(t *testing.T) {
+	sim, client := newTestSimulator(t)
+	login(t, sim, client)
+	tagsURL := sim.URL + "/api/v3/entities/75919430323057/tags"
+
+	var tags []apiTag
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, tagsURL,
+		`[{"key":"turbonomic_optimized_by","values":["turbonomic-terraform-provider"]}]`, &tags))
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodGet, tagsURL, "", &tags))
+	assert.Equal(t, []apiTag{
+		{Key: "owner", Values: []string{"infra"}},
+		{Key: "turbonomic_optimized_by", Values: []string{"turbonomic-terraform-provider"}},
+	}, tags)
+
+	var apiErr apiError
+	assert.Equal(t, http.StatusBadRequest, call(t, client, http.MethodPost, tagsURL, `[{"key":"owner","values":["apps"]}]`, &apiErr))
+	assert.Equal(t, TagAlreadyExistsMessage, apiErr.Message)
+
+	// a tag is replaced by deleting it first
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodDelete, tagsURL+"/owner", "", nil))
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, tagsURL, `[{"key":"owner","values":["apps"]}]`, &tags))
+	assert.Contains(t, tags, apiTag{Key: "owner", Values: []string{"apps"}})
+	assert.Equal(t, http.StatusNotFound, call(t, client, http.MethodDelete, tagsURL+"/team", "", &apiErr))
+}
+
+// Tests the actions of an entity and their execution
+func TestSimulatorExecuteAction(t *testing.T) {
+	sim, client := newTestSimulator(t)
+	login(t, sim, client)
+	actionsURL := sim.URL + "/api/v3/entities/75919430323056/actions"
+
+	var actions []apiAction
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, actionsURL, `{"actionTypeList":["SCALE"]}`, &actions))
+	assert.Len(t, actions, 1)
+	assert.Equal(t, int64(638877190762822), actions[0].ActionID)
+	assert.Equal(t, "testRDS", actions[0].Target.DisplayName)
+	assert.Equal(t, "StorageTier", actions[0].CompoundActions[1].CurrentEntity.ClassName)
+
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, actionsURL, `{"actionTypeList":["RESIZE"]}`, &actions))
+	assert.Empty(t, actions)
+
+	var action apiAction
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, sim.URL+"/api/v3/actions/638877190762822?accept=true", "", &action))
+	assert.Equal(t, "SUCCEEDED", action.ActionState)
+
+	entity, ok := sim.Entity("75919430323056")
+	assert.True(t, ok)
+	assert.Equal(t, "db.t4g.small-standard", entity.Template)
+
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, actionsURL, `{}`, &actions))
+	assert.Empty(t, actions)
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, actionsURL, `{"actionStateList":["SUCCEEDED"]}`, &actions))
+	assert.Len(t, actions, 1)
+
+	assert.EqualError(t, sim.ExecuteAction("638877190762822"), "action 638877190762822 is SUCCEEDED")
+	assert.EqualError(t, sim.ExecuteAction("1"), "action 1 not found")
+}
+
+// Tests that the stats of an entity are returned by name and that the requests are recorded
+func TestSimulatorStats(t *testing.T) {
+	sim, client := newTestSimulator(t)
+	login(t, sim, client)
+
+	var snapshots []apiStatSnapshot
+	body := `{"statistics":[{"name":"StorageAccess","relatedEntityType":"DatabaseServer","filters":[{"type":"relation","value":"sold"}]}]}`
+	assert.Equal(t, http.StatusOK, call(t, client, http.MethodPost, sim.URL+"/api/v3/stats/75919430323056", body, &snapshots))
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, "testRDS", snapshots[0].DisplayName)
+	assert.Equal(t, []apiStat{{
+		Name:     "StorageAccess",
+		Units:    "IOPS",
+		Value:    0.283,
+		Values:   &apiStatValues{Max: 0.283, Min: 0.283, Avg: 0.283, Total: 0.283},
+		Capacity: &apiStatValues{Max: 3000, Min: 3000, Avg: 3000, Total: 3000},
+		Filters:  []apiFilter{{Type: "relation", Value: "sold"}},
+	}}, snapshots[0].Statistics)
+
+	requests := sim.Requests()
+	assert.Equal(t, Request{Method: http.MethodPost, Path: "/api/v3/stats/75919430323056", Body: body}, requests[len(requests)-1])
+}
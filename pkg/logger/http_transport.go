@@ -0,0 +1,120 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxLoggedBodySize is the size after which the logged bodies are truncated
+const maxLoggedBodySize = 64 * 1024
+
+var _ http.RoundTripper = &TraceTransport{}
+
+// TraceTransport is an http.RoundTripper that logs the requests and the responses with their
+// bodies at the trace level, with the values of the sensitive headers and keys masked. It traces the
+// HTTP clients of the provider, the Turbonomic API client sends its requests with its own HTTP client
+type TraceTransport struct {
+	// Base is the transport that sends the requests, http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// RoundTrip logs the request, sends it with the base transport and logs the response
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// the bodies are only buffered when the messages are written to the Terraform logs
+	if !TraceEnabled() {
+		return base.RoundTrip(req)
+	}
+
+	log := TfLogAdapter{}
+	ctx := req.Context()
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	log.Trace(ctx, "turbonomic api request",
+		"method", req.Method,
+		"url", req.URL.Redacted(),
+		"headers", req.Header,
+		"body", truncateBody(RedactBody(req.Header.Get("Content-Type"), body)))
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	latency := time.Since(start)
+	if err != nil {
+		log.Trace(ctx, "turbonomic api request failed",
+			"method", req.Method,
+			"url", req.URL.Redacted(),
+			"latency_ms", latency.Milliseconds(),
+			"error", err.Error())
+		return nil, err
+	}
+
+	body, err = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	log.Trace(ctx, "turbonomic api response",
+		"method", req.Method,
+		"url", req.URL.Redacted(),
+		"status", resp.StatusCode,
+		"latency_ms", latency.Milliseconds(),
+		"headers", resp.Header,
+		"body", truncateBody(RedactBody(resp.Header.Get("Content-Type"), body)))
+
+	return resp, nil
+}
+
+// traceLevelEnvVars are the environment variables of the level of the provider logs, by precedence
+var traceLevelEnvVars = []string{"TF_LOG_PROVIDER_TURBONOMIC", "TF_LOG_PROVIDER", "TF_LOG"}
+
+// TraceEnabled reports whether the trace messages of the provider are written to the Terraform logs,
+// the provider logs every message and Terraform filters them with the level of these variables
+func TraceEnabled() bool {
+	for _, envVar := range traceLevelEnvVars {
+		if level := strings.ToUpper(os.Getenv(envVar)); level != "" {
+			return level == "TRACE" || level == "JSON"
+		}
+	}
+	return false
+}
+
+// truncateBody shortens the bodies larger than maxLoggedBodySize
+func truncateBody(body string) string {
+	if len(body) <= maxLoggedBodySize {
+		return body
+	}
+	return body[:maxLoggedBodySize] + "...(truncated)"
+}
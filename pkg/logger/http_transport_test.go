@@ -0,0 +1,138 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestTraceTransport(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_TURBONOMIC", "")
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG", "TRACE")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "client_id=12345&client_secret=xyz" {
+			t.Errorf("server received body %q", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"abc","expires_in":600}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/oauth2/token",
+		strings.NewReader("client_id=12345&client_secret=xyz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Basic YWRtaW46czNjcjN0")
+
+	client := http.Client{Transport: &TraceTransport{}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != `{"access_token":"abc","expires_in":600}` {
+		t.Errorf("client received body %q", body)
+	}
+
+	entries := logEntries(t, &output)
+	if len(entries) != 2 {
+		t.Fatalf("got %d log entries, want 2: %v", len(entries), entries)
+	}
+
+	request := entries[0]
+	if request["@level"] != "trace" || request["method"] != http.MethodPost {
+		t.Errorf("request entry = %v", request)
+	}
+	if request["body"] != "client_id=12345&client_secret=%2A%2A%2A" {
+		t.Errorf("request body = %v", request["body"])
+	}
+	headers, _ := request["headers"].(map[string]any)
+	if authorization, _ := headers["Authorization"].([]any); len(authorization) != 1 || authorization[0] != Redacted {
+		t.Errorf("request headers = %v", headers)
+	}
+
+	response := entries[1]
+	if response["status"] != float64(http.StatusOK) || response["body"] != `{"access_token":"***","expires_in":600}` {
+		t.Errorf("response entry = %v", response)
+	}
+	if _, ok := response["latency_ms"]; !ok {
+		t.Errorf("response entry without latency = %v", response)
+	}
+}
+
+// Tests that the bodies are neither buffered nor logged below the trace level
+func TestTraceTransportLevel(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_TURBONOMIC", "")
+	t.Setenv("TF_LOG_PROVIDER", "DEBUG")
+	t.Setenv("TF_LOG", "TRACE")
+
+	var sent io.Reader
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req.Body
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	body := strings.NewReader(`{"name":"vm-1"}`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://turbo/api/v3/search", io.NopCloser(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (&TraceTransport{Base: base}).RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if body.Len() == 0 || sent != req.Body {
+		t.Errorf("request body was buffered")
+	}
+	if output.Len() != 0 {
+		t.Errorf("got log output %q, want none", output.String())
+	}
+
+	for level, want := range map[string]bool{"": false, "info": false, "trace": true, "JSON": true} {
+		t.Setenv("TF_LOG_PROVIDER_TURBONOMIC", level)
+		t.Setenv("TF_LOG_PROVIDER", "")
+		t.Setenv("TF_LOG", "")
+		if got := TraceEnabled(); got != want {
+			t.Errorf("TraceEnabled() with level %q = %v, want %v", level, got, want)
+		}
+	}
+}
+
+// roundTripFunc is an http.RoundTripper calling the function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
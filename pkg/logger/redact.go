@@ -0,0 +1,136 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces the values of the sensitive keys in the logs
+const Redacted = "***"
+
+// sensitiveKeyParts are the parts of the keys whose values are masked, matched case-insensitively
+var sensitiveKeyParts = []string{"password", "token", "secret", "authorization", "cookie", "credential"}
+
+// IsSensitiveKey returns whether the value of a key must be masked in the logs,
+// E.G: password, client_secret, access_token or the Authorization header
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactValue masks a value of a sensitive key, and the sensitive keys of the headers and maps
+func RedactValue(key string, value any) any {
+	if IsSensitiveKey(key) {
+		return Redacted
+	}
+
+	switch v := value.(type) {
+	case http.Header:
+		return RedactHeaders(v)
+	case url.Values:
+		return RedactHeaders(http.Header(v))
+	case map[string][]string:
+		return RedactHeaders(v)
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for k, item := range v {
+			redacted[k] = item
+			if IsSensitiveKey(k) {
+				redacted[k] = Redacted
+			}
+		}
+		return redacted
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, item := range v {
+			redacted[k] = RedactValue(k, item)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = RedactValue("", item)
+		}
+		return redacted
+	}
+	return value
+}
+
+// RedactHeaders returns a copy of the headers with the values of the sensitive headers masked
+func RedactHeaders(headers map[string][]string) map[string][]string {
+	redacted := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		if IsSensitiveKey(key) {
+			redacted[key] = []string{Redacted}
+			continue
+		}
+		redacted[key] = values
+	}
+	return redacted
+}
+
+/*
+RedactBody masks the values of the sensitive keys of a JSON or form-urlencoded body, such as
+the password of a login request or the access token of a token response.
+
+Parameters:
+  - contentType: The Content-Type header of the body
+  - body: The body
+
+Returns:
+  - string: The body with the sensitive values masked, or the body as is when its format is unknown
+*/
+func RedactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return Redacted
+		}
+		for key := range values {
+			if IsSensitiveKey(key) {
+				values[key] = []string{Redacted}
+			}
+		}
+		return values.Encode()
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || json.Valid(body):
+		var value any
+		if err := json.Unmarshal(body, &value); err != nil {
+			return string(body)
+		}
+		redacted, err := json.Marshal(RedactValue("", value))
+		if err != nil {
+			return string(body)
+		}
+		return string(redacted)
+	}
+	return string(body)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"password":      true,
+		"client_secret": true,
+		"access_token":  true,
+		"Authorization": true,
+		"Set-Cookie":    true,
+		"username":      false,
+		"client_id":     false,
+		"hostname":      false,
+	} {
+		if got := IsSensitiveKey(key); got != want {
+			t.Errorf("IsSensitiveKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestRedactValue(t *testing.T) {
+	got := RedactValue("response", map[string]any{
+		"access_token": "abc",
+		"expires_in":   3600.0,
+		"items":        []any{map[string]any{"password": "s3cr3t", "name": "vm-1"}},
+	})
+	want := map[string]any{
+		"access_token": Redacted,
+		"expires_in":   3600.0,
+		"items":        []any{map[string]any{"password": Redacted, "name": "vm-1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactValue() = %v, want %v", got, want)
+	}
+
+	if got := RedactValue("labels", map[string]string{"team": "a", "api_token": "b"}); !reflect.DeepEqual(got,
+		map[string]string{"team": "a", "api_token": Redacted}) {
+		t.Errorf("RedactValue() = %v", got)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name:        "Form body",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "username=admin&password=s3cr3t",
+			want:        "password=%2A%2A%2A&username=admin",
+		},
+		{
+			name:        "JSON body",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"access_token":"abc","token_type":"Bearer","scope":"role:OBSERVER"}`,
+			want:        `{"access_token":"***","scope":"role:OBSERVER","token_type":"***"}`,
+		},
+		{
+			name:        "JSON body without content type",
+			contentType: "",
+			body:        `[{"uuid":"123","clientSecret":"xyz"}]`,
+			want:        `[{"clientSecret":"***","uuid":"123"}]`,
+		},
+		{
+			name:        "Plain body",
+			contentType: "text/plain",
+			body:        "ok",
+			want:        "ok",
+		},
+		{
+			name:        "Empty body",
+			contentType: "application/json",
+			body:        "",
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactBody(tt.contentType, []byte(tt.body)); got != tt.want {
+				t.Errorf("RedactBody() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
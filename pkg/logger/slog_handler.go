@@ -0,0 +1,148 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LevelTrace is the slog level of the messages logged at the tflog trace level
+const LevelTrace = slog.Level(-8)
+
+var _ slog.Handler = &TfLogHandler{}
+
+// TfLogHandler is a slog.Handler that logs the records with tflog, so that the libraries that
+// log with log/slog, such as the turbonomic client, write to the Terraform logs
+type TfLogHandler struct {
+	ctx    context.Context
+	attrs  []slog.Attr
+	prefix string
+}
+
+// NewTfLogHandler creates a handler that logs with the Terraform logger of ctx, which is used
+// when a record is logged without a context, E.G: with slog.Info instead of slog.InfoContext
+func NewTfLogHandler(ctx context.Context) *TfLogHandler {
+	return &TfLogHandler{ctx: ctx}
+}
+
+// SlogAdapter logs the messages of the turbonomic client with a slog.Logger, such as a logger
+// of a TfLogHandler, the levels of the client map to the slog levels
+type SlogAdapter struct {
+	Logger *slog.Logger
+}
+
+func (l *SlogAdapter) Trace(ctx context.Context, msg string, args ...any) {
+	l.Logger.Log(ctx, LevelTrace, msg, args...)
+}
+
+func (l *SlogAdapter) Info(ctx context.Context, msg string, args ...any) {
+	l.Logger.InfoContext(ctx, msg, args...)
+}
+
+func (l *SlogAdapter) Debug(ctx context.Context, msg string, args ...any) {
+	l.Logger.DebugContext(ctx, msg, args...)
+}
+
+func (l *SlogAdapter) Warn(ctx context.Context, msg string, args ...any) {
+	l.Logger.WarnContext(ctx, msg, args...)
+}
+
+func (l *SlogAdapter) Error(ctx context.Context, msg string, args ...any) {
+	l.Logger.ErrorContext(ctx, msg, args...)
+}
+
+// Enabled reports true for every level, tflog filters the messages with TF_LOG
+func (h *TfLogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle logs the record at the tflog level of its slog level
+func (h *TfLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx == nil || ctx == context.Background() || ctx == context.TODO() {
+		ctx = h.ctx
+	}
+
+	fields := make(map[string]any, len(h.attrs)+record.NumAttrs())
+	for _, attr := range h.attrs {
+		addAttr(fields, "", attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addAttr(fields, h.prefix, attr)
+		return true
+	})
+
+	switch {
+	case record.Level < slog.LevelDebug:
+		tflog.Trace(ctx, record.Message, fields)
+	case record.Level < slog.LevelInfo:
+		tflog.Debug(ctx, record.Message, fields)
+	case record.Level < slog.LevelWarn:
+		tflog.Info(ctx, record.Message, fields)
+	case record.Level < slog.LevelError:
+		tflog.Warn(ctx, record.Message, fields)
+	default:
+		tflog.Error(ctx, record.Message, fields)
+	}
+	return nil
+}
+
+// WithAttrs returns a handler that adds the attributes to every record
+func (h *TfLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.attrs = make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	handler.attrs = append(handler.attrs, h.attrs...)
+	for _, attr := range attrs {
+		if h.prefix != "" {
+			attr.Key = h.prefix + attr.Key
+		}
+		handler.attrs = append(handler.attrs, attr)
+	}
+	return &handler
+}
+
+// WithGroup returns a handler that prefixes the keys of the next attributes with the group name
+func (h *TfLogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.prefix = h.prefix + name + "."
+	return &handler
+}
+
+// addAttr adds an attribute to the fields, the keys of a group are prefixed with its name
+func addAttr(fields map[string]any, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, groupAttr := range value.Group() {
+			addAttr(fields, groupPrefix, groupAttr)
+		}
+		return
+	}
+	if attr.Key == "" {
+		return
+	}
+
+	key := prefix + attr.Key
+	fields[key] = RedactValue(attr.Key, value.Any())
+}
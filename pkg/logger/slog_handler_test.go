@@ -0,0 +1,112 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// logEntries decodes the JSON log entries written by the tflog test logger
+func logEntries(t *testing.T, output *bytes.Buffer) []map[string]any {
+	entries, err := tflogtest.MultilineJSONDecode(output)
+	if err != nil {
+		t.Fatalf("unable to decode the log entries: %v", err)
+	}
+	return entries
+}
+
+func TestTfLogHandler(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	log := slog.New(NewTfLogHandler(ctx)).With("client", "turbo-go-client")
+	log.Log(context.Background(), LevelTrace, "request body", "body", `{"name":"vm-1"}`)
+	log.Debug("searching entities", "name", "vm-1")
+	log.Info("login", slog.Group("creds", "username", "admin", "password", "s3cr3t"))
+	log.WithGroup("http").Warn("retrying request", "status", 429, "token", "abc")
+	log.ErrorContext(ctx, "request failed", "error", "timeout")
+
+	entries := logEntries(t, &output)
+	if len(entries) != 5 {
+		t.Fatalf("got %d log entries, want 5: %v", len(entries), entries)
+	}
+
+	wantLevels := []string{"trace", "debug", "info", "warn", "error"}
+	for i, entry := range entries {
+		if entry["@level"] != wantLevels[i] {
+			t.Errorf("entry %d level = %v, want %v", i, entry["@level"], wantLevels[i])
+		}
+		if entry["client"] != "turbo-go-client" {
+			t.Errorf("entry %d client = %v, want turbo-go-client", i, entry["client"])
+		}
+	}
+
+	if entries[2]["creds.username"] != "admin" || entries[2]["creds.password"] != Redacted {
+		t.Errorf("group attributes = %v", entries[2])
+	}
+	if entries[3]["http.status"] != float64(429) || entries[3]["http.token"] != Redacted {
+		t.Errorf("group attributes = %v", entries[3])
+	}
+}
+
+func TestTfLogAdapterLevels(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	log := TfLogAdapter{}
+	log.Trace(ctx, "trace message", "password", "s3cr3t")
+	log.Warn(ctx, "warn message", "key")
+
+	entries := logEntries(t, &output)
+	if len(entries) != 2 {
+		t.Fatalf("got %d log entries, want 2: %v", len(entries), entries)
+	}
+	if entries[0]["@level"] != "trace" || entries[0]["password"] != Redacted {
+		t.Errorf("trace entry = %v", entries[0])
+	}
+	if entries[1]["@level"] != "warn" || entries[1][BadKey] != "key" {
+		t.Errorf("warn entry = %v", entries[1])
+	}
+}
+
+func TestSlogAdapterLevels(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	log := SlogAdapter{Logger: slog.New(NewTfLogHandler(ctx))}
+	log.Trace(context.Background(), "trace message", "password", "s3cr3t")
+	log.Debug(ctx, "debug message", "uuid", "75878700658784")
+	log.Error(ctx, "error message", "key")
+
+	entries := logEntries(t, &output)
+	if len(entries) != 3 {
+		t.Fatalf("got %d log entries, want 3: %v", len(entries), entries)
+	}
+	if entries[0]["@level"] != "trace" || entries[0]["password"] != Redacted {
+		t.Errorf("trace entry = %v", entries[0])
+	}
+	if entries[1]["@level"] != "debug" || entries[1]["uuid"] != "75878700658784" {
+		t.Errorf("debug entry = %v", entries[1])
+	}
+	if entries[2]["@level"] != "error" || entries[2][BadKey] != "key" {
+		t.Errorf("error entry = %v", entries[2])
+	}
+}
@@ -21,8 +21,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// BadKey is the key of a trailing argument without a value, as in log/slog
+const BadKey = "!BADKEY"
+
+// TfLogAdapter logs the messages of the turbonomic client with tflog, the values of the
+// sensitive keys are masked
 type TfLogAdapter struct{}
 
+func (l *TfLogAdapter) Trace(ctx context.Context, msg string, args ...any) {
+	tflog.Trace(ctx, msg, argsToMap(args...))
+}
+
 func (l *TfLogAdapter) Info(ctx context.Context, msg string, args ...any) {
 	tflog.Info(ctx, msg, argsToMap(args...))
 }
@@ -31,19 +40,28 @@ func (l *TfLogAdapter) Debug(ctx context.Context, msg string, args ...any) {
 	tflog.Debug(ctx, msg, argsToMap(args...))
 }
 
+func (l *TfLogAdapter) Warn(ctx context.Context, msg string, args ...any) {
+	tflog.Warn(ctx, msg, argsToMap(args...))
+}
+
 func (l *TfLogAdapter) Error(ctx context.Context, msg string, args ...any) {
 	tflog.Error(ctx, msg, argsToMap(args...))
 }
 
-// Helper to convert slog-style args to map[string]interface{}
+// Helper to convert slog-style args to map[string]interface{}, a trailing argument without a
+// value is kept under the !BADKEY key and the values of the sensitive keys are masked
 func argsToMap(args ...any) map[string]any {
 	m := make(map[string]any)
-	for i := 0; i < len(args)-1; i += 2 {
+	for i := 0; i < len(args); i += 2 {
+		if i == len(args)-1 {
+			m[BadKey] = args[i]
+			break
+		}
 		key, ok := args[i].(string)
 		if !ok {
 			continue
 		}
-		m[key] = args[i+1]
+		m[key] = RedactValue(key, args[i+1])
 	}
 	return m
 }
@@ -16,6 +16,7 @@
 package logger
 
 import (
+	"net/http"
 	"reflect"
 	"testing"
 )
@@ -35,7 +36,17 @@ func Test_argsToMap(t *testing.T) {
 		{
 			name: "Odd number of arguments",
 			args: []any{"name", "Bob", "age"},
-			want: map[string]any{"name": "Bob"},
+			want: map[string]any{"name": "Bob", BadKey: "age"},
+		},
+		{
+			name: "Sensitive keys are masked",
+			args: []any{"username", "admin", "password", "s3cr3t", "Authorization", "Bearer abc", "client_secret", "xyz"},
+			want: map[string]any{"username": "admin", "password": Redacted, "Authorization": Redacted, "client_secret": Redacted},
+		},
+		{
+			name: "Sensitive headers are masked",
+			args: []any{"headers", http.Header{"Accept": {"application/json"}, "Cookie": {"JSESSIONID=1"}}},
+			want: map[string]any{"headers": map[string][]string{"Accept": {"application/json"}, "Cookie": {Redacted}}},
 		},
 		{
 			name: "Nil value",
@@ -16,111 +16,195 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/IBM/terraform-provider-turbonomic/internal/provider"
+	"github.com/IBM/terraform-provider-turbonomic/internal/simulator"
 )
 
 const (
-	// user specific fields to be set when checking acceptance testing
-	// use local/fyre turbo app details and a VM name available in the app
-	providerConfig = `
+	// AccHostnameEnvVar runs the acceptance tests against a Turbonomic instance instead of the
+	// simulator, the values below are then set to entities available in the instance
+	AccHostnameEnvVar     = "TURBO_ACC_HOSTNAME"
+	AccUsernameEnvVar     = "TURBO_ACC_USERNAME"
+	AccPasswordEnvVar     = "TURBO_ACC_PASSWORD"
+	AccClientIdEnvVar     = "TURBO_ACC_CLIENT_ID"
+	AccClientSecretEnvVar = "TURBO_ACC_CLIENT_SECRET"
+	AccRoleEnvVar         = "TURBO_ACC_ROLE"
+	// AccScenarioEnvVar is the scenario of the simulated Turbonomic instance
+	AccScenarioEnvVar = "TURBO_ACC_SCENARIO"
+
+	defaultScenario = "testdata/scenarios/acceptance.yaml"
+
+	providerConfigTemplate = `
         provider "turbonomic" {
-            username = "<username>"
-            password = "<password>"
-            hostname = "<hostname>"
+            username = "%s"
+            password = "%s"
+            hostname = "%s"
             skipverify = true
         }
 	`
-	providerConfigoAuth = `
+	providerConfigoAuthTemplate = `
         provider "turbonomic" {
-            client_id = "<client_id>"
-            client_secret = "<client_secret>"
-            role = "<role>"
-            hostname = "<hostname>"
+            client_id = "%s"
+            client_secret = "%s"
+            role = "%s"
+            hostname = "%s"
             skipverify = true
         }
 	`
 
 	// VM related values, used to test expected current and new size
-	// Requires a new VM, since it executes the plan
-	vmName     = "<vm-name>"
-	vmCurrSize = "<vmCurrSize>"
-	vmNewSize  = "<vmNewSize>"
+	vmName     = "acc-test-vm"
+	vmCurrSize = "t2.micro"
+	vmNewSize  = "t3.micro"
+
+	// VM whose action is executed between two steps, used to test that the new size becomes the current size
+	executedVMName     = "acc-test-vm-executed"
+	executedVMActionId = "638000000000002"
+	executedVMCurrSize = "m5.large"
+	executedVMNewSize  = "m5.xlarge"
 
 	// AWS EBS volume related values, used to test expected current and new type
-	// Requires a new volume, since it executes the plan
-	ebsVolName     = "<vol-name>"
-	ebsVolCurrType = "<volume-current-type>"
-	ebsVolNewType  = "<volume-new-type>"
+	ebsVolName     = "acc-test-ebs"
+	ebsVolCurrType = "gp2"
+	ebsVolNewType  = "gp3"
 
 	// AWS RDS related values, used to test expected current and new type.
-	rdsName                = "<rdsName>"
-	rdsCurrComputeClass    = "<rdsCurrComputeSize>"
-	rdsNewComputeClass     = "<rdsNewComputeSize>"
-	rdsDefaultComputeClass = "<rdsDefaultComputeSize>"
-	rdsCurrStorageType     = "<rdsCurrStorageSize>"
-	rdsNewStorageType      = "<rdsNewStorageSize>"
-	rdsDefaultStorageType  = "<rdsDefaultStorageSize>"
+	rdsName                = "acc-test-rds"
+	rdsCurrComputeClass    = "db.t4g.medium"
+	rdsNewComputeClass     = "db.t4g.small"
+	rdsDefaultComputeClass = "db.t4g.micro"
+	rdsCurrStorageType     = "gp3"
+	rdsNewStorageType      = "standard"
+	rdsDefaultStorageType  = "gp2"
 
 	// Azure Managed Disks related values, used to test expected current and new type.
-	azureDiskName        = "<azureDisksName>"
-	azureDiskCurrentType = "<azureDisksCurrentType>"
-	azureDiskNewType     = "<azureDisksNewType>"
-	azureDiskDefaultType = "<azureDisksDefaultType>"
+	azureDiskName        = "acc-test-azure-disk"
+	azureDiskCurrentType = "Standard_LRS"
+	azureDiskNewType     = "Premium_LRS"
+	azureDiskDefaultType = "StandardSSD_LRS"
 
 	// Google Compute Disk related values, used to test expected, current and new type
-	googleComputeDiskName        = "<googleComputeDiskName>"
-	googleComputeDiskCurrentType = "<googleComputeDiskCurrentType>"
-	googleComputeDiskNewType     = "<googleComputeDiskNewType>"
-	googleComputeDiskDefaultType = "<googleComputeDiskDefaultType>"
+	googleComputeDiskName        = "acc-test-gcp-disk"
+	googleComputeDiskCurrentType = "pd-standard"
+	googleComputeDiskNewType     = "pd-ssd"
+	googleComputeDiskDefaultType = "pd-balanced"
 
 	// Entity Action Data Source related values
-	entityActionEntityName             = "<entityActionEntityName>"
-	entityActionEntityType             = "<entityActionentityType>"
-	entityActionEntityUuid             = "<entityActionentityUuid>"
-	entityActionActionUuid             = "<entityActionActionUuid>"
-	entityActionAction0CurrentValue    = "<entityActionAction0CurrentValue>"
-	entityActionAction0NewValue        = "<entityActionAction0NewValue>"
-	entityActionAction0ReasonCommodity = "<entityActionAction0ReasonCommodity>"
-	entityActionAction1CurrentValue    = "<entityActionAction1CurrentValue>"
-	entityActionAction1NewValue        = "<entityActionAction1NewValue>"
-	entityActionAction1ReasonCommodity = "<entityActionAction1ReasonCommodity>"
-	entityActionAction2CurrentValue    = "<entityActionAction2CurrentValue>"
-	entityActionAction2NewValue        = "<entityActionAction2NewValue>"
-	entityActionAction2ReasonCommodity = "<entityActionAction2ReasonCommodity>"
+	entityActionEntityName             = "acc-test-compound-vm"
+	entityActionEntityType             = "VirtualMachine"
+	entityActionEntityUuid             = "74000000000012"
+	entityActionActionUuid             = "638000000000012"
+	entityActionAction0CurrentValue    = "16384.0"
+	entityActionAction0NewValue        = "8192.0"
+	entityActionAction0ReasonCommodity = "VMem"
+	entityActionAction1CurrentValue    = "4"
+	entityActionAction1NewValue        = "2"
+	entityActionAction1ReasonCommodity = "VCPU"
+	entityActionAction2CurrentValue    = "6400.0"
+	entityActionAction2NewValue        = "3200.0"
+	entityActionAction2ReasonCommodity = "StorageAccess"
 
 	// Google Compute Instance related values, used to test expected, current and new type
-	googleVMName        = "<googleVMName>"
-	googleVMCurrentType = "<googleVMCurrentType>"
-	googleVMNewType     = "<googleVMNewType>"
-	googleVMDefaultType = "<googleVMDefaultType>"
+	googleVMName        = "acc-test-gcp-vm"
+	googleVMCurrentType = "e2-medium"
+	googleVMNewType     = "e2-small"
+	googleVMDefaultType = "e2-micro"
 
 	// AWS Instance related values, used to test expected, current and new type
-	awsVMName        = "<awsVMName>"
-	awsVMCurrentType = "<awsVMCurrentType>"
-	awsVMNewType     = "<awsVMNewType>"
-	awsVMDefaultType = "<awsVMDefaultType>"
+	awsVMName        = "acc-test-aws-vm"
+	awsVMCurrentType = "t3.large"
+	awsVMNewType     = "t3.medium"
+	awsVMDefaultType = "t3.small"
 
 	// Azure Linux Instance related values, used to test expected, current and new type
-	azureLinuxVMName        = "<azureLinuxVMName>"
-	azureLinuxVMCurrentType = "<azureLinuxVMCurrentType>"
-	azureLinuxVMNewType     = "<azureLinuxVMNewType>"
-	azureLinuxVMDefaultType = "<azureLinuxVMDefaultType>"
+	azureLinuxVMName        = "acc-test-linux-vm"
+	azureLinuxVMCurrentType = "Standard_B1s"
+	azureLinuxVMNewType     = "Standard_B2s"
+	azureLinuxVMDefaultType = "Standard_B1ms"
 
 	// Azure Windows Instance related values, used to test expected, current and new type
-	azureWindowsVMName        = "<azureWindowsVMName>"
-	azureWindowsVMCurrentType = "<azureWindowsVMCurrentType>"
-	azureWindowsVMNewType     = "<azureWindowsVMNewType>"
-	azureWindowsVMDefaultType = "<azureWindowsVMDefaultType>"
+	azureWindowsVMName        = "acc-test-windows-vm"
+	azureWindowsVMCurrentType = "Standard_D2s_v3"
+	azureWindowsVMNewType     = "Standard_D4s_v3"
+	azureWindowsVMDefaultType = "Standard_D2as_v4"
 
 	// Azure Mssql Database related values, used to test expected, current and new sku name
-	azureMSSQLDatabaseName           = "<azureMSSQLDatabaseName>"
-	azureMSSQLDatabaseCurrentSkuName = "<azureMSSQLDatabaseSkuName>"
-	azureMSSQLDatabaseNewSkuName     = "<azureMSSQLDatabaseNewSkuName>"
-	azureMSSQLDatabaseDefaultSkuName = "<azureMSSQLDatabaseDefaultSkuName>"
+	azureMSSQLDatabaseName           = "acc-test-mssql"
+	azureMSSQLDatabaseCurrentSkuName = "s0"
+	azureMSSQLDatabaseNewSkuName     = "s1"
+	azureMSSQLDatabaseDefaultSkuName = "basic"
+
+	// Entity, Entities and Entity Stats Data Sources related values, the stats are only checked
+	// against the simulator
+	entityName         = "acc-test-entity"
+	entityType         = "VirtualMachine"
+	entityUuid         = "74000000000013"
+	entityVendorId     = "i-0a1b2c3d4e5f60013"
+	entityTemplate     = "m5.large"
+	entityTagKey       = "owner"
+	entityTagValue     = "platform"
+	entityUntagged     = "acc-test-entity-untagged"
+	entityStatName     = "VCPU"
+	entityStatUnits    = "MHz"
+	entityStatValue    = "600"
+	entityStatCapacity = "2400"
+
+	// Server Info Data Source related values, the version of the simulated Turbonomic instance
+	serverVersion = "8.14.3"
 )
 
+var (
+	// providers of the simulator, or of the Turbonomic instance of the AccHostnameEnvVar variables
+	providerConfig      string
+	providerConfigoAuth string
+
+	// sim is the simulated Turbonomic instance, nil when the tests run against a Turbonomic instance
+	sim *simulator.Simulator
+)
+
+/*
+startAcceptanceTarget sets the providers of the acceptance tests. The tests run against the
+simulator seeded from the AccScenarioEnvVar scenario, or against the Turbonomic instance of the
+AccHostnameEnvVar variables when it is set.
+
+Returns:
+  - func(): The function that stops the simulator
+  - error: An error if the scenario can not be loaded
+*/
+func startAcceptanceTarget() (func(), error) {
+	if hostname := os.Getenv(AccHostnameEnvVar); hostname != "" {
+		providerConfig = fmt.Sprintf(providerConfigTemplate,
+			os.Getenv(AccUsernameEnvVar), os.Getenv(AccPasswordEnvVar), hostname)
+		providerConfigoAuth = fmt.Sprintf(providerConfigoAuthTemplate,
+			os.Getenv(AccClientIdEnvVar), os.Getenv(AccClientSecretEnvVar), os.Getenv(AccRoleEnvVar), hostname)
+		return func() {}, nil
+	}
+
+	name := os.Getenv(AccScenarioEnvVar)
+	if name == "" {
+		name = defaultScenario
+	}
+	scenario, err := simulator.LoadScenario(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(scenario.Users) == 0 || len(scenario.Clients) == 0 {
+		return nil, fmt.Errorf("the scenario %s requires a user and an oauth client", name)
+	}
+
+	sim = simulator.New(scenario)
+	user, client := scenario.Users[0], scenario.Clients[0]
+	providerConfig = fmt.Sprintf(providerConfigTemplate, user.Username, user.Password, sim.Hostname())
+	providerConfigoAuth = fmt.Sprintf(providerConfigoAuthTemplate,
+		client.ClientID, client.ClientSecret, client.Role, sim.Hostname())
+	return sim.Close, nil
+}
+
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"turbonomic": providerserver.NewProtocol6WithError(provider.New("test", "turbonomic")()),
 }
@@ -123,6 +123,39 @@ func TestAccCloudDataSourceOAuth(t *testing.T) {
 	}
 }
 
+// Tests that the new size of an executed action becomes the current size of the entity
+func TestAccCloudDataSourceExecutedAction(t *testing.T) {
+	if sim == nil {
+		t.Skip("executes an action of the simulated Turbonomic instance")
+	}
+
+	config := providerConfig + fmt.Sprintf(vmConfig, executedVMName, "VirtualMachine", executedVMCurrSize)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbonomic_cloud_entity_recommendation.test", "current_instance_type", executedVMCurrSize),
+					resource.TestCheckResourceAttr("data.turbonomic_cloud_entity_recommendation.test", "new_instance_type", executedVMNewSize),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := sim.ExecuteAction(executedVMActionId); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbonomic_cloud_entity_recommendation.test", "current_instance_type", executedVMNewSize),
+					resource.TestCheckResourceAttr("data.turbonomic_cloud_entity_recommendation.test", "new_instance_type", executedVMNewSize),
+				),
+			},
+		},
+	})
+}
+
 func init() {
 	_ = os.Setenv("TF_ACC", "1")
 }
@@ -0,0 +1,76 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	entitiesConfig = `
+	data "turbonomic_entities" "test" {
+		entity_type = "%s"
+		name_regex  = "%s"
+		tag_filters = %s
+	}
+	`
+	entitiesDataSourceRef = "data.turbonomic_entities.test"
+)
+
+// Tests the `turbonomic_entities` data block filtered by name and tags
+func TestAccEntitiesDataSource(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		tagFilters    string
+		expectedNames []string
+	}{
+		{
+			name:          "Entities by name",
+			tagFilters:    "{}",
+			expectedNames: []string{entityName, entityUntagged},
+		},
+		{
+			name:          "Entities by name and tags",
+			tagFilters:    fmt.Sprintf("{ %s = %q }", entityTagKey, entityTagValue),
+			expectedNames: []string{entityName},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			checks := []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr(entitiesDataSourceRef, "entities.#", fmt.Sprint(len(tc.expectedNames))),
+			}
+			for i, name := range tc.expectedNames {
+				checks = append(checks,
+					resource.TestCheckResourceAttr(entitiesDataSourceRef, fmt.Sprintf("entities.%d.display_name", i), name),
+					resource.TestCheckResourceAttr(entitiesDataSourceRef, fmt.Sprintf("entities.%d.template", i), entityTemplate),
+				)
+			}
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig + fmt.Sprintf(entitiesConfig, entityType, "^"+entityName, tc.tagFilters),
+						Check:  resource.ComposeAggregateTestCheckFunc(checks...),
+					},
+				},
+			})
+		})
+	}
+}
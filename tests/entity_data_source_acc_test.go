@@ -0,0 +1,67 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	entityDataSourceRef = "data.turbonomic_entity.test"
+)
+
+// Tests the `turbonomic_entity` data block looked up by name, uuid and vendor id
+func TestAccEntityDataSource(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		lookup string
+	}{
+		{
+			name:   "Entity by name",
+			lookup: fmt.Sprintf("entity_name = %q\n entity_type = %q", entityName, entityType),
+		},
+		{
+			name:   "Entity by uuid",
+			lookup: fmt.Sprintf("entity_uuid = %q", entityUuid),
+		},
+		{
+			name:   "Entity by vendor id",
+			lookup: fmt.Sprintf("vendor_id = %q\n entity_type = %q", entityVendorId, entityType),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig + `data "turbonomic_entity" "test" {
+							` + tc.lookup + `
+						}`,
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr(entityDataSourceRef, "entity_uuid", entityUuid),
+							resource.TestCheckResourceAttr(entityDataSourceRef, "display_name", entityName),
+							resource.TestCheckResourceAttr(entityDataSourceRef, "class_name", entityType),
+							resource.TestCheckResourceAttr(entityDataSourceRef, "tags."+entityTagKey+".0", entityTagValue),
+						),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	entityStatsConfig = `
+	data "turbonomic_entity_stats" "test" {
+		entity_uuid = "%s"
+		commodities = ["%s"]
+	}
+	`
+	entityStatsDataSourceRef = "data.turbonomic_entity_stats.test"
+)
+
+// Tests the `turbonomic_entity_stats` data block, the values of the stats are only checked
+// against the simulator
+func TestAccEntityStatsDataSource(t *testing.T) {
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr(entityStatsDataSourceRef, "stats.#", "1"),
+		resource.TestCheckResourceAttr(entityStatsDataSourceRef, "stats.0.name", entityStatName),
+		resource.TestCheckResourceAttr(entityStatsDataSourceRef, "stats.0.units", entityStatUnits),
+		resource.TestCheckResourceAttrSet(entityStatsDataSourceRef, "stats.0.value"),
+	}
+	if sim != nil {
+		checks = append(checks,
+			resource.TestCheckResourceAttr(entityStatsDataSourceRef, "stats.0.value", entityStatValue),
+			resource.TestCheckResourceAttr(entityStatsDataSourceRef, "stats.0.capacity", entityStatCapacity),
+			resource.TestCheckResourceAttr(entityStatsDataSourceRef, "stats.0.utilization", "25"),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(entityStatsConfig, entityUuid, entityStatName),
+				Check:  resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+		},
+	})
+}
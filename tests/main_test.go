@@ -0,0 +1,23 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain runs the acceptance tests against the simulated Turbonomic instance
+func TestMain(m *testing.M) {
+	stop, err := startAcceptanceTarget()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to start the acceptance tests: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS-IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	serverInfoDataSourceRef = "data.turbonomic_server_info.test"
+)
+
+// Tests the `turbonomic_server_info` data block, the version is only checked against the simulator
+func TestAccServerInfoDataSource(t *testing.T) {
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttrSet(serverInfoDataSourceRef, "hostname"),
+		resource.TestCheckResourceAttrSet(serverInfoDataSourceRef, "version"),
+		resource.TestCheckResourceAttrSet(serverInfoDataSourceRef, "major"),
+		resource.TestCheckResourceAttrSet(serverInfoDataSourceRef, "capabilities.%"),
+	}
+	if sim != nil {
+		checks = append(checks,
+			resource.TestCheckResourceAttr(serverInfoDataSourceRef, "hostname", sim.Hostname()),
+			resource.TestCheckResourceAttr(serverInfoDataSourceRef, "version", serverVersion),
+			resource.TestCheckResourceAttr(serverInfoDataSourceRef, "major", "8"),
+			resource.TestCheckResourceAttr(serverInfoDataSourceRef, "minor", "14"),
+			resource.TestCheckResourceAttr(serverInfoDataSourceRef, "patch", "3"),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `data "turbonomic_server_info" "test" {}`,
+				Check:  resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+		},
+	})
+}
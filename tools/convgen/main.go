@@ -0,0 +1,498 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Convgen generates the functions copying the types of the Turbonomic go-client into the Terraform
+models of the provider, so that the data sources convert the API responses without reflection.
+
+It is run by go generate from the package of the models:
+
+	//go:generate go run ../../tools/convgen -output converters_gen.go ActionModel=ActionResults
+
+Each argument pairs a model of the package with a type of clientTypes. The fields of a model are
+copied from the fields of the same name of the client type, and the struct fields of a model must
+be named models themselves, converted by their own generated function. A nested model must declare
+only fields of its client type, a root model can declare more fields, like the inputs of a data
+source, which are left untouched.
+
+The anonymous structs of the go-client are declared as aliases in the generated file, a go-client
+release that changes one of them no longer compiles until the converters are generated again.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	turboclient "github.com/IBM/turbonomic-go-client"
+)
+
+const turboClientPath = "github.com/IBM/turbonomic-go-client"
+
+// clientTypes are the go-client types that models can be generated from, the element type is used for the slices
+var clientTypes = map[string]reflect.Type{
+	"ActionResults": reflect.TypeOf(turboclient.ActionResults{}).Elem(),
+	"EntityResults": reflect.TypeOf(turboclient.EntityResults{}),
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// importNames are the names of the imported packages that are not named after their path
+var importNames = map[string]string{
+	turboClientPath: "turboclient",
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("convgen: ")
+
+	output := flag.String("output", "converters_gen.go", "the name of the generated file")
+	flag.Parse()
+
+	src, err := run(".", *output, flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run generates the converters of the models of a package directory for a list of model=client arguments
+func run(dir string, output string, args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("at least one model=client argument is required")
+	}
+
+	pkg, models, err := parseModels(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newGenerator(pkg, models)
+	for _, arg := range args {
+		model, client, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid argument %q, expected model=client", arg)
+		}
+		typ, ok := clientTypes[client]
+		if !ok {
+			return nil, fmt.Errorf("unknown client type %s", client)
+		}
+		if err := g.addRoot(model, client, typ); err != nil {
+			return nil, err
+		}
+	}
+	return g.generate()
+}
+
+// modelField is a field of a model as declared in the source of the package
+type modelField struct {
+	name string
+	typ  string
+}
+
+// parseModels returns the name of the package of a directory and the fields of its struct types
+func parseModels(dir string, output string) (string, map[string][]modelField, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	pkg := ""
+	models := map[string][]modelField{}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") || filepath.Base(name) == filepath.Base(output) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		pkg = file.Name.Name
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				var fields []modelField
+				for _, field := range structType.Fields.List {
+					var typ bytes.Buffer
+					if err := printer.Fprint(&typ, fset, field.Type); err != nil {
+						return "", nil, err
+					}
+					for _, fieldName := range field.Names {
+						fields = append(fields, modelField{name: fieldName.Name, typ: typ.String()})
+					}
+				}
+				models[typeSpec.Name.Name] = fields
+			}
+		}
+	}
+	if pkg == "" {
+		return "", nil, fmt.Errorf("no go files found in %s", dir)
+	}
+	return pkg, models, nil
+}
+
+// conversion is the function generated for a model
+type conversion struct {
+	model  string
+	client reflect.Type
+	// origin is the path of the client type from its root, E.G: turboclient.ActionResults[].Target
+	origin  string
+	root    bool
+	body    []string
+	skipped []string
+}
+
+type generator struct {
+	pkg         string
+	models      map[string][]modelField
+	conversions []*conversion
+	byModel     map[string]*conversion
+	aliases     map[reflect.Type]string
+	imports     map[string]bool
+}
+
+func newGenerator(pkg string, models map[string][]modelField) *generator {
+	return &generator{
+		pkg:     pkg,
+		models:  models,
+		byModel: map[string]*conversion{},
+		aliases: map[reflect.Type]string{},
+		imports: map[string]bool{},
+	}
+}
+
+// addRoot adds the conversion of a model and of the models of its fields
+func (g *generator) addRoot(model string, client string, typ reflect.Type) error {
+	if _, ok := g.models[model]; !ok {
+		return fmt.Errorf("model %s not found in package %s", model, g.pkg)
+	}
+	origin := "turboclient." + client
+	if typ.Name() == "" {
+		origin += "[]"
+	}
+	conv, err := g.add(model, typ, origin)
+	if err != nil {
+		return err
+	}
+	conv.root = true
+
+	for i := 0; i < len(g.conversions); i++ {
+		if g.conversions[i].body == nil {
+			if err := g.convert(g.conversions[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// add registers the conversion of a model from a client type, a model is converted from a single client type
+func (g *generator) add(model string, typ reflect.Type, origin string) (*conversion, error) {
+	if conv, ok := g.byModel[model]; ok {
+		if conv.client != typ {
+			return nil, fmt.Errorf("model %s is converted from both %s and %s", model, conv.origin, origin)
+		}
+		return conv, nil
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("model %s can not be converted from %s, a struct is required", model, typ)
+	}
+
+	conv := &conversion{model: model, client: typ, origin: origin}
+	g.conversions = append(g.conversions, conv)
+	g.byModel[model] = conv
+	if _, ok := g.aliases[typ]; !ok && typ.Name() == "" {
+		g.aliases[typ] = "turbo" + strings.TrimSuffix(model, "Model")
+	}
+	return conv, nil
+}
+
+// convert generates the statements copying the fields of a model
+func (g *generator) convert(conv *conversion) error {
+	conv.body = []string{}
+	for _, field := range g.models[conv.model] {
+		clientField, ok := conv.client.FieldByName(field.name)
+		if !ok || !clientField.IsExported() {
+			if !conv.root {
+				return fmt.Errorf("%s.%s has no matching field in %s", conv.model, field.name, conv.origin)
+			}
+			conv.skipped = append(conv.skipped, field.name)
+			continue
+		}
+
+		stmt, err := g.convertField(conv, field, clientField.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", conv.model, field.name, err)
+		}
+		conv.body = append(conv.body, stmt)
+	}
+	return nil
+}
+
+// convertField returns the statement copying a client field into a model field
+func (g *generator) convertField(conv *conversion, field modelField, typ reflect.Type) (string, error) {
+	src, dst := "src."+field.name, "dst."+field.name
+	origin := conv.origin + "." + field.name
+
+	value := func(constructor string, kinds ...reflect.Kind) (string, error) {
+		if !slices.Contains(kinds, typ.Kind()) {
+			return "", fmt.Errorf("%s can not be converted to %s", typ, field.typ)
+		}
+		arg := src
+		if typ.Name() != kinds[0].String() || typ.PkgPath() != "" {
+			arg = kinds[0].String() + "(" + src + ")"
+		}
+		return fmt.Sprintf("%s = %s(%s)", dst, constructor, arg), nil
+	}
+
+	switch field.typ {
+	case "types.String":
+		return value("types.StringValue", reflect.String)
+	case "types.Int64":
+		return value("types.Int64Value", reflect.Int64, reflect.Int, reflect.Int32)
+	case "types.Float64":
+		return value("types.Float64Value", reflect.Float64, reflect.Float32)
+	case "types.Float32":
+		return value("types.Float32Value", reflect.Float32, reflect.Float64)
+	case "types.Bool":
+		return value("types.BoolValue", reflect.Bool)
+	case "jsontypes.Normalized":
+		if typ != rawMessageType {
+			return "", fmt.Errorf("%s can not be converted to %s", typ, field.typ)
+		}
+		return fmt.Sprintf("%s = normalizedJSON(%s)", dst, src), nil
+	case "[]types.String":
+		if typ.Kind() != reflect.Slice || typ.Elem() != reflect.TypeOf("") {
+			return "", fmt.Errorf("%s can not be converted to %s", typ, field.typ)
+		}
+		return fmt.Sprintf("%s = stringValues(%s)", dst, src), nil
+	}
+
+	if strings.HasPrefix(field.typ, "map[") {
+		if typ.String() != field.typ {
+			return "", fmt.Errorf("%s can not be converted to %s", typ, field.typ)
+		}
+		return fmt.Sprintf("%s = %s", dst, src), nil
+	}
+
+	if model, ok := strings.CutPrefix(field.typ, "[]"); ok && g.models[model] != nil {
+		if typ.Kind() != reflect.Slice {
+			return "", fmt.Errorf("%s can not be converted to %s", typ, field.typ)
+		}
+		if _, err := g.add(model, typ.Elem(), origin+"[]"); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s = make([]%s, len(%s))\nfor i := range %s {\ncopy%s(&%s[i], &%s[i])\n}",
+			dst, model, src, src, model, src, dst), nil
+	}
+
+	if g.models[field.typ] != nil {
+		if _, err := g.add(field.typ, typ, origin); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("copy%s(&%s, &%s)", field.typ, src, dst), nil
+	}
+
+	return "", fmt.Errorf("unsupported model type %s", field.typ)
+}
+
+// generate returns the formatted source of the converters
+func (g *generator) generate() ([]byte, error) {
+	var functions, aliases bytes.Buffer
+	declared := map[string]bool{}
+	for _, conv := range g.conversions {
+		fmt.Fprintf(&functions, "\n// copy%s copies a %s into %s %s\n", conv.model, conv.origin, article(conv.model), conv.model)
+		if len(conv.skipped) > 0 {
+			fmt.Fprintf(&functions, "// The fields without a source are left untouched: %s\n", strings.Join(conv.skipped, ", "))
+		}
+		fmt.Fprintf(&functions, "func copy%s(src *%s, dst *%s) {\n", conv.model, g.typeName(conv.client), conv.model)
+		for _, stmt := range conv.body {
+			fmt.Fprintln(&functions, stmt)
+		}
+		fmt.Fprintln(&functions, "}")
+
+		if alias, ok := g.aliases[conv.client]; ok && !declared[alias] {
+			declared[alias] = true
+			literal, err := g.structLiteral(conv.client)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(&aliases, "\n// %s is the type of %s\ntype %s = %s\n", alias, conv.origin, alias, literal)
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Copyright (c) IBM Corporation\n// SPDX-License-Identifier: Apache-2.0\n\n")
+	fmt.Fprintf(&src, "// Code generated by convgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.pkg)
+	std, others := g.importPaths()
+	for _, group := range [][]string{std, others} {
+		for _, path := range group {
+			if name, ok := importNames[path]; ok {
+				fmt.Fprintf(&src, "%s %q\n", name, path)
+			} else {
+				fmt.Fprintf(&src, "%q\n", path)
+			}
+		}
+		if len(group) > 0 {
+			fmt.Fprintln(&src)
+		}
+	}
+	fmt.Fprintf(&src, ")\n")
+	src.Write(aliases.Bytes())
+	src.Write(functions.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated source: %w\n%s", err, src.String())
+	}
+	return formatted, nil
+}
+
+// importPaths returns the sorted paths of the standard and of the other packages used by the generated source
+func (g *generator) importPaths() ([]string, []string) {
+	source := ""
+	for _, conv := range g.conversions {
+		source += strings.Join(conv.body, "\n")
+	}
+	if strings.Contains(source, "types.") {
+		g.imports["github.com/hashicorp/terraform-plugin-framework/types"] = true
+	}
+
+	var std, others []string
+	for path := range g.imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			others = append(others, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(others)
+	return std, others
+}
+
+// article returns the indefinite article of a name
+func article(name string) string {
+	if strings.ContainsRune("AEIOU", rune(name[0])) {
+		return "an"
+	}
+	return "a"
+}
+
+// typeName returns the name of a client type in the generated source
+func (g *generator) typeName(typ reflect.Type) string {
+	if alias, ok := g.aliases[typ]; ok {
+		return alias
+	}
+	// json.RawMessage is reported under the name of the type it aliases in the recent Go releases
+	if typ == rawMessageType {
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+	if typ.Name() == "" {
+		return typ.String()
+	}
+	if typ.PkgPath() == "" {
+		return typ.Name()
+	}
+
+	g.imports[typ.PkgPath()] = true
+	name, ok := importNames[typ.PkgPath()]
+	if !ok {
+		name = path.Base(typ.PkgPath())
+	}
+	return name + "." + typ.Name()
+}
+
+// typeExpr returns the expression of a client type, the anonymous structs with an alias use the alias
+func (g *generator) typeExpr(typ reflect.Type) (string, error) {
+	if alias, ok := g.aliases[typ]; ok {
+		return alias, nil
+	}
+	if typ.Name() != "" {
+		return g.typeName(typ), nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Pointer:
+		elem, err := g.typeExpr(typ.Elem())
+		if err != nil {
+			return "", err
+		}
+		if typ.Kind() == reflect.Pointer {
+			return "*" + elem, nil
+		}
+		return "[]" + elem, nil
+	case reflect.Array:
+		elem, err := g.typeExpr(typ.Elem())
+		return fmt.Sprintf("[%d]%s", typ.Len(), elem), err
+	case reflect.Map:
+		key, err := g.typeExpr(typ.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := g.typeExpr(typ.Elem())
+		return "map[" + key + "]" + elem, err
+	case reflect.Struct:
+		return g.structLiteral(typ)
+	}
+	return "", fmt.Errorf("unsupported client type %s", typ)
+}
+
+// structLiteral returns the struct type literal of an anonymous client struct, tags included
+func (g *generator) structLiteral(typ reflect.Type) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("struct {\n")
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			return "", fmt.Errorf("the unexported field %s of %s can not be declared", field.Name, typ)
+		}
+		expr, err := g.typeExpr(field.Type)
+		if err != nil {
+			return "", err
+		}
+		if !field.Anonymous {
+			sb.WriteString(field.Name + " ")
+		}
+		sb.WriteString(expr)
+		if field.Tag != "" {
+			tag := string(field.Tag)
+			if strings.Contains(tag, "`") {
+				sb.WriteString(" " + strconv.Quote(tag))
+			} else {
+				sb.WriteString(" `" + tag + "`")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}")
+	return sb.String(), nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const providerDir = "../../internal/provider"
+
+// Tests that the converters of the provider were generated from the current models and go-client
+func TestGeneratedConvertersUpToDate(t *testing.T) {
+	directive, err := os.ReadFile(filepath.Join(providerDir, "converters.go"))
+	require.NoError(t, err)
+
+	var args []string
+	for _, line := range strings.Split(string(directive), "\n") {
+		if command, ok := strings.CutPrefix(line, "//go:generate go run ../../tools/convgen "); ok {
+			args = strings.Fields(command)
+		}
+	}
+	require.Len(t, args, 4, "the go:generate directive of converters.go was not found")
+	require.Equal(t, "-output", args[0])
+
+	expected, err := run(providerDir, args[1], args[2:])
+	require.NoError(t, err)
+	actual, err := os.ReadFile(filepath.Join(providerDir, args[1]))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual), "run go generate ./internal/provider")
+}
+
+func TestRun(t *testing.T) {
+	dir := writeModels(t, `
+type ActionModel struct {
+	UUID          types.String      `+"`tfsdk:\"uuid\"`"+`
+	ActionID      types.Int64       `+"`tfsdk:\"action_id\"`"+`
+	Risk          RiskModel         `+"`tfsdk:\"risk\"`"+`
+	Stats         []StatModel       `+"`tfsdk:\"stats\"`"+`
+	Aspects       types.String      `+"`tfsdk:\"aspects\"`"+`
+	EntityName    types.String      `+"`tfsdk:\"entity_name\"`"+`
+}
+
+type RiskModel struct {
+	Importance        types.Float32  `+"`tfsdk:\"importance\"`"+`
+	ReasonCommodities []types.String `+"`tfsdk:\"reason_commodities\"`"+`
+}
+
+type StatModel struct {
+	Name  types.String  `+"`tfsdk:\"name\"`"+`
+	Value types.Float64 `+"`tfsdk:\"value\"`"+`
+}
+`)
+
+	src, err := run(dir, "converters_gen.go", []string{"ActionModel=ActionResults"})
+	require.NoError(t, err)
+
+	for _, expected := range []string{
+		"// Code generated by convgen. DO NOT EDIT.",
+		"type turboAction = struct {",
+		"type turboRisk = struct {",
+		"[]turboStat `json:\"stats\"`",
+		"// copyActionModel copies a turboclient.ActionResults[] into an ActionModel\n" +
+			"// The fields without a source are left untouched: Aspects, EntityName\n" +
+			"func copyActionModel(src *turboAction, dst *ActionModel) {",
+		"\tdst.ActionID = types.Int64Value(src.ActionID)\n",
+		"\tcopyRiskModel(&src.Risk, &dst.Risk)\n",
+		"\tdst.Stats = make([]StatModel, len(src.Stats))\n\tfor i := range src.Stats {\n\t\tcopyStatModel(&src.Stats[i], &dst.Stats[i])\n\t}\n",
+		"\tdst.ReasonCommodities = stringValues(src.ReasonCommodities)\n",
+		"// copyStatModel copies a turboclient.ActionResults[].Stats[] into a StatModel",
+	} {
+		assert.Contains(t, string(src), expected)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		models string
+		args   []string
+		err    string
+	}{
+		{
+			name:   "no arguments",
+			models: "type ActionModel struct{}",
+			err:    "at least one model=client argument is required",
+		},
+		{
+			name:   "invalid argument",
+			models: "type ActionModel struct{}",
+			args:   []string{"ActionModel"},
+			err:    `invalid argument "ActionModel", expected model=client`,
+		},
+		{
+			name:   "unknown client type",
+			models: "type ActionModel struct{}",
+			args:   []string{"ActionModel=Actions"},
+			err:    "unknown client type Actions",
+		},
+		{
+			name:   "unknown model",
+			models: "type EntityModel struct{}",
+			args:   []string{"ActionModel=ActionResults"},
+			err:    "model ActionModel not found in package models",
+		},
+		{
+			name: "nested field without source",
+			models: `type ActionModel struct { Risk RiskModel }
+type RiskModel struct { Score types.Float64 }`,
+			args: []string{"ActionModel=ActionResults"},
+			err:  "RiskModel.Score has no matching field in turboclient.ActionResults[].Risk",
+		},
+		{
+			name:   "type mismatch",
+			models: "type ActionModel struct { UUID types.Bool }",
+			args:   []string{"ActionModel=ActionResults"},
+			err:    "ActionModel.UUID: string can not be converted to types.Bool",
+		},
+		{
+			name:   "unsupported model type",
+			models: "type ActionModel struct { UUID *string }",
+			args:   []string{"ActionModel=ActionResults"},
+			err:    "ActionModel.UUID: unsupported model type *string",
+		},
+		{
+			name: "model converted from two client types",
+			models: `type ActionModel struct { CurrentEntity TierModel; Template TierModel }
+type TierModel struct { UUID types.String }`,
+			args: []string{"ActionModel=ActionResults"},
+			err:  "ActionModel.Template: model TierModel is converted from both turboclient.ActionResults[].CurrentEntity and turboclient.ActionResults[].Template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := run(writeModels(t, tc.models), "converters_gen.go", tc.args)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+// writeModels writes the declarations of a models package to a temporary directory
+func writeModels(t *testing.T, declarations string) string {
+	dir := t.TempDir()
+	src := "package models\n\n" + declarations + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0o600))
+	return dir
+}